@@ -0,0 +1,46 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS LikePost (
+	PostId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS DislikePost (
+	PostId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS LikeComment (
+	PostId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS DislikeComment (
+	PostId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+INSERT INTO LikePost (PostId, UserId)
+	SELECT TargetId, UserId FROM Reaction WHERE TargetType = 'Post' AND Kind <> 'dislike';
+
+INSERT INTO DislikePost (PostId, UserId)
+	SELECT TargetId, UserId FROM Reaction WHERE TargetType = 'Post' AND Kind = 'dislike';
+
+-- The reactions on comments are lost by this rollback: LikeComment and DislikeComment
+-- reference Post(Id), so the comment ids can't be written back while the foreign keys are on.
+
+DROP TRIGGER IF EXISTS ReactionPostCleanup;
+DROP TRIGGER IF EXISTS ReactionCommentCleanup;
+DROP TABLE IF EXISTS Reaction;
@@ -0,0 +1,40 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS Reaction (
+	TargetType VARCHAR(20) NOT NULL,
+	TargetId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+	Kind VARCHAR(20) NOT NULL,
+
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+
+	CHECK (TargetType IN ('Post', 'Comment')),
+	CHECK (Kind IN ('like', 'love', 'laugh', 'sad', 'angry', 'dislike'))
+);
+
+CREATE TRIGGER IF NOT EXISTS ReactionPostCleanup AFTER DELETE ON Post
+BEGIN
+	DELETE FROM Reaction WHERE TargetType = 'Post' AND TargetId = OLD.Id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS ReactionCommentCleanup AFTER DELETE ON Comment
+BEGIN
+	DELETE FROM Reaction WHERE TargetType = 'Comment' AND TargetId = OLD.Id;
+END;
+
+INSERT INTO Reaction (TargetType, TargetId, UserId, Kind)
+	SELECT 'Post', PostId, UserId, 'like' FROM LikePost;
+
+INSERT INTO Reaction (TargetType, TargetId, UserId, Kind)
+	SELECT 'Post', PostId, UserId, 'dislike' FROM DislikePost;
+
+INSERT INTO Reaction (TargetType, TargetId, UserId, Kind)
+	SELECT 'Comment', PostId, UserId, 'like' FROM LikeComment WHERE PostId IN (SELECT Id FROM Comment);
+
+INSERT INTO Reaction (TargetType, TargetId, UserId, Kind)
+	SELECT 'Comment', PostId, UserId, 'dislike' FROM DislikeComment WHERE PostId IN (SELECT Id FROM Comment);
+
+DROP TABLE IF EXISTS LikePost;
+DROP TABLE IF EXISTS DislikePost;
+DROP TABLE IF EXISTS LikeComment;
+DROP TABLE IF EXISTS DislikeComment;
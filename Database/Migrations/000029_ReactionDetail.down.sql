@@ -0,0 +1 @@
+DROP VIEW IF EXISTS ReactionDetail
\ No newline at end of file
@@ -0,0 +1,18 @@
+PRAGMA foreign_keys = ON;
+
+CREATE VIEW IF NOT EXISTS ReactionDetail AS
+	SELECT
+		r.TargetType,
+		r.TargetId,
+		r.UserId,
+
+		CASE 
+            WHEN u.Username = '' THEN CONCAT(u.FirstName, ' ', u.LastName)
+            ELSE u.Username 
+        END AS User_Name,
+
+		u.ProfilePicture AS User_Picture,
+		r.Kind
+
+	FROM Reaction AS r
+	INNER JOIN UserInfo AS u ON u.Id = r.UserId
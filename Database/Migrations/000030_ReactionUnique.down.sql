@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ReactionUnique
\ No newline at end of file
@@ -0,0 +1,7 @@
+PRAGMA foreign_keys = ON;
+
+DELETE FROM Reaction WHERE rowid NOT IN (
+	SELECT MIN(rowid) FROM Reaction GROUP BY TargetType, TargetId, UserId
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ReactionUnique ON Reaction (TargetType, TargetId, UserId);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS Bookmark;
\ No newline at end of file
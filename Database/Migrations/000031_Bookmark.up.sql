@@ -0,0 +1,13 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS Bookmark (
+	UserId VARCHAR(36) NOT NULL,
+	PostId VARCHAR(36) NOT NULL,
+	Collection VARCHAR(50) NOT NULL DEFAULT '',
+	CreationDate VARCHAR(30) NOT NULL,
+
+	PRIMARY KEY (UserId, PostId),
+
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE
+);
@@ -0,0 +1,26 @@
+PRAGMA foreign_keys = ON;
+
+ALTER TABLE Post ADD COLUMN RepostOf VARCHAR(36) REFERENCES "Post"("Id") ON DELETE SET NULL;
+ALTER TABLE Post ADD COLUMN RepostCount INTEGER DEFAULT 0;
+
+DROP VIEW IF EXISTS PostDetail;
+
+CREATE VIEW IF NOT EXISTS PostDetail AS
+  SELECT 
+    p.Id,
+	p.Text,
+	p.Image,
+	p.CreationDate,
+	p.IsGroup,
+	p.AuthorId,
+	p.LikeCount,
+	p.DislikeCount,
+	p.Status,
+	p.RepostOf,
+	p.RepostCount,
+	u.FirstName,
+	u.LastName,
+	u.ProfilePicture,
+	u.Username
+FROM Post AS p
+INNER JOIN UserInfo AS u ON p.AuthorId = u.Id;
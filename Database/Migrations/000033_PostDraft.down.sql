@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS PostDraftPublishDate;
+DROP TABLE IF EXISTS PostDraft;
\ No newline at end of file
@@ -0,0 +1,18 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS PostDraft (
+	Id VARCHAR(36) NOT NULL,
+	AuthorId VARCHAR(36) NOT NULL,
+	Text VARCHAR(1000) NOT NULL,
+	Image TEXT NOT NULL DEFAULT '',
+	Status TEXT NOT NULL,
+	IsGroup VARCHAR(36),
+	PublishDate VARCHAR(20) NOT NULL DEFAULT '',
+
+	PRIMARY KEY (Id),
+
+	CONSTRAINT fk_authorid FOREIGN KEY (AuthorId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_isgroup FOREIGN KEY (IsGroup) REFERENCES "Groups"("Id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS PostDraftPublishDate ON PostDraft (PublishDate);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS PollVoteUser;
+DROP TABLE IF EXISTS PollVote;
+DROP TABLE IF EXISTS PollOption;
+DROP TABLE IF EXISTS Poll;
\ No newline at end of file
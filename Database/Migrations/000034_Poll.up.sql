@@ -0,0 +1,38 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS Poll (
+	Id VARCHAR(36) NOT NULL,
+	PostId VARCHAR(36) NOT NULL UNIQUE,
+	MultipleChoice BOOLEAN NOT NULL DEFAULT 0,
+	Anonymous BOOLEAN NOT NULL DEFAULT 0,
+	ClosingDate VARCHAR(20) NOT NULL DEFAULT '',
+
+	PRIMARY KEY (Id),
+
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS PollOption (
+	Id VARCHAR(36) NOT NULL,
+	PollId VARCHAR(36) NOT NULL,
+	Position INTEGER NOT NULL,
+	Text VARCHAR(100) NOT NULL,
+
+	PRIMARY KEY (Id),
+
+	CONSTRAINT fk_pollid FOREIGN KEY (PollId) REFERENCES "Poll"("Id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS PollVote (
+	PollId VARCHAR(36) NOT NULL,
+	OptionId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+
+	PRIMARY KEY (OptionId, UserId),
+
+	CONSTRAINT fk_pollid FOREIGN KEY (PollId) REFERENCES "Poll"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_optionid FOREIGN KEY (OptionId) REFERENCES "PollOption"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS PollVoteUser ON PollVote (PollId, UserId);
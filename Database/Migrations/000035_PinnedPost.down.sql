@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS PinnedPostOwner;
+DROP TABLE IF EXISTS PinnedPost;
\ No newline at end of file
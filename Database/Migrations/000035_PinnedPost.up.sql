@@ -0,0 +1,13 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS PinnedPost (
+	PostId VARCHAR(36) NOT NULL,
+	OwnerId VARCHAR(36) NOT NULL,
+	Position INTEGER NOT NULL,
+
+	PRIMARY KEY (PostId),
+
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS PinnedPostOwner ON PinnedPost (OwnerId, Position);
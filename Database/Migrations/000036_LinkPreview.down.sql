@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS LinkPreviewPostCleanup;
+DROP TRIGGER IF EXISTS LinkPreviewMessageCleanup;
+DROP TABLE IF EXISTS LinkPreview;
@@ -0,0 +1,25 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS LinkPreview (
+	SourceType VARCHAR(20) NOT NULL,
+	SourceId VARCHAR(36) NOT NULL,
+	Url TEXT NOT NULL,
+	Title TEXT NOT NULL DEFAULT '',
+	Description TEXT NOT NULL DEFAULT '',
+	Image TEXT NOT NULL DEFAULT '',
+	FetchDate VARCHAR(30) NOT NULL,
+
+	PRIMARY KEY (SourceType, SourceId),
+
+	CHECK (SourceType IN ('Post', 'Message'))
+);
+
+CREATE TRIGGER IF NOT EXISTS LinkPreviewPostCleanup AFTER DELETE ON Post
+BEGIN
+	DELETE FROM LinkPreview WHERE SourceType = 'Post' AND SourceId = OLD.Id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS LinkPreviewMessageCleanup AFTER DELETE ON Chat
+BEGIN
+	DELETE FROM LinkPreview WHERE SourceType = 'Message' AND SourceId = OLD.Id;
+END;
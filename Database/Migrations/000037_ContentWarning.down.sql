@@ -0,0 +1,50 @@
+PRAGMA foreign_keys = ON;
+
+DROP VIEW IF EXISTS PostDetail;
+
+CREATE VIEW IF NOT EXISTS PostDetail AS
+  SELECT 
+    p.Id,
+	p.Text,
+	p.Image,
+	p.CreationDate,
+	p.IsGroup,
+	p.AuthorId,
+	p.LikeCount,
+	p.DislikeCount,
+	p.Status,
+	p.RepostOf,
+	p.RepostCount,
+	u.FirstName,
+	u.LastName,
+	u.ProfilePicture,
+	u.Username
+FROM Post AS p
+INNER JOIN UserInfo AS u ON p.AuthorId = u.Id;
+
+DROP VIEW IF EXISTS CommentDetail;
+
+CREATE VIEW IF NOT EXISTS CommentDetail AS
+  SELECT 
+    c.Id,
+	c.Text,
+	c.Image,
+	c.CreationDate,
+	c.AuthorId,
+	c.LikeCount,
+	c.DislikeCount,
+	c.PostId,
+	u.FirstName,
+	u.LastName,
+	u.ProfilePicture,
+	u.Username
+FROM Comment AS c
+INNER JOIN UserInfo AS u ON c.AuthorId = u.Id;
+
+ALTER TABLE UserInfo DROP COLUMN SensitiveContent;
+
+ALTER TABLE Comment DROP COLUMN SensitiveMedia;
+ALTER TABLE Comment DROP COLUMN ContentWarning;
+
+ALTER TABLE Post DROP COLUMN SensitiveMedia;
+ALTER TABLE Post DROP COLUMN ContentWarning;
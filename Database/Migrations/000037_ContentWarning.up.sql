@@ -0,0 +1,54 @@
+PRAGMA foreign_keys = ON;
+
+ALTER TABLE Post ADD COLUMN ContentWarning VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE Post ADD COLUMN SensitiveMedia BOOLEAN NOT NULL DEFAULT 0;
+
+ALTER TABLE Comment ADD COLUMN ContentWarning VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE Comment ADD COLUMN SensitiveMedia BOOLEAN NOT NULL DEFAULT 0;
+
+ALTER TABLE UserInfo ADD COLUMN SensitiveContent VARCHAR(10) NOT NULL DEFAULT 'hide' CHECK (SensitiveContent IN ('hide', 'expand'));
+
+DROP VIEW IF EXISTS PostDetail;
+
+CREATE VIEW IF NOT EXISTS PostDetail AS
+  SELECT 
+    p.Id,
+	p.Text,
+	p.Image,
+	p.CreationDate,
+	p.IsGroup,
+	p.AuthorId,
+	p.LikeCount,
+	p.DislikeCount,
+	p.Status,
+	p.RepostOf,
+	p.RepostCount,
+	p.ContentWarning,
+	p.SensitiveMedia,
+	u.FirstName,
+	u.LastName,
+	u.ProfilePicture,
+	u.Username
+FROM Post AS p
+INNER JOIN UserInfo AS u ON p.AuthorId = u.Id;
+
+DROP VIEW IF EXISTS CommentDetail;
+
+CREATE VIEW IF NOT EXISTS CommentDetail AS
+  SELECT 
+    c.Id,
+	c.Text,
+	c.Image,
+	c.CreationDate,
+	c.AuthorId,
+	c.LikeCount,
+	c.DislikeCount,
+	c.PostId,
+	c.ContentWarning,
+	c.SensitiveMedia,
+	u.FirstName,
+	u.LastName,
+	u.ProfilePicture,
+	u.Username
+FROM Comment AS c
+INNER JOIN UserInfo AS u ON c.AuthorId = u.Id;
@@ -0,0 +1,2 @@
+ALTER TABLE Reaction DROP COLUMN CreationDate;
+DROP TABLE IF EXISTS PostView;
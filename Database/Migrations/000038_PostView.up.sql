@@ -0,0 +1,14 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS PostView (
+	PostId VARCHAR(36) NOT NULL,
+	ViewerId VARCHAR(36) NOT NULL,
+	Day VARCHAR(10) NOT NULL,
+
+	PRIMARY KEY (PostId, ViewerId, Day),
+
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_viewerid FOREIGN KEY (ViewerId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+ALTER TABLE Reaction ADD COLUMN CreationDate VARCHAR(30) NOT NULL DEFAULT '';
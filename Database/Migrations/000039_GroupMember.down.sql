@@ -0,0 +1,38 @@
+DROP VIEW IF EXISTS GroupDetail;
+
+ALTER TABLE Groups ADD COLUMN MemberIds TEXT NOT NULL DEFAULT '';
+ALTER TABLE UserInfo ADD COLUMN GroupsJoined TEXT;
+
+UPDATE Groups SET MemberIds = COALESCE((
+  SELECT GROUP_CONCAT(m.UserId, ' | ')
+  FROM (
+    SELECT UserId FROM GroupMember WHERE GroupId = Groups.Id ORDER BY JoinedAt, rowid
+  ) AS m
+), '');
+
+UPDATE UserInfo SET GroupsJoined = (
+  SELECT GROUP_CONCAT(GroupId, ' | ') FROM GroupMember WHERE UserId = UserInfo.Id
+);
+
+DROP INDEX IF EXISTS GroupMemberUser;
+DROP TABLE IF EXISTS GroupMember;
+
+CREATE VIEW IF NOT EXISTS GroupDetail AS
+  SELECT 
+    g.Id,
+    g.LeaderId,
+    
+    CASE 
+      WHEN u.Username = '' THEN CONCAT(u.FirstName, ' ', u.LastName)
+      ELSE u.Username 
+    END AS Leader,
+
+    g.MemberIds,
+    g.groupName,
+    g.GroupDescription,
+    g.CreationDate,
+    g.GroupPicture,
+    g.Banner
+
+FROM Groups AS g
+INNER JOIN UserInfo AS u ON u.Id = g.LeaderId;
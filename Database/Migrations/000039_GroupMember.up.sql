@@ -0,0 +1,74 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS GroupMember (
+	GroupId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+	Role VARCHAR(20) NOT NULL DEFAULT 'member',
+	JoinedAt VARCHAR(30) NOT NULL DEFAULT '',
+
+	PRIMARY KEY (GroupId, UserId),
+
+	CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS GroupMemberUser ON GroupMember(UserId);
+
+INSERT OR IGNORE INTO GroupMember (GroupId, UserId, Role, JoinedAt)
+  SELECT g.Id, g.LeaderId, 'leader', g.CreationDate
+  FROM Groups AS g
+  WHERE g.LeaderId IN (SELECT Id FROM UserInfo);
+
+WITH RECURSIVE SplitMember(GroupId, JoinedAt, UserId, Rest) AS (
+  SELECT Id, CreationDate, '', MemberIds || ' | ' FROM Groups
+  UNION ALL
+  SELECT GroupId, JoinedAt, SUBSTR(Rest, 1, INSTR(Rest, ' | ') - 1), SUBSTR(Rest, INSTR(Rest, ' | ') + 3)
+  FROM SplitMember
+  WHERE Rest <> ''
+)
+INSERT OR IGNORE INTO GroupMember (GroupId, UserId, Role, JoinedAt)
+  SELECT GroupId, UserId, 'member', JoinedAt
+  FROM SplitMember
+  WHERE UserId IN (SELECT Id FROM UserInfo);
+
+WITH RECURSIVE SplitGroup(UserId, GroupId, Rest) AS (
+  SELECT Id, '', COALESCE(GroupsJoined, '') || ' | ' FROM UserInfo
+  UNION ALL
+  SELECT UserId, SUBSTR(Rest, 1, INSTR(Rest, ' | ') - 1), SUBSTR(Rest, INSTR(Rest, ' | ') + 3)
+  FROM SplitGroup
+  WHERE Rest <> ''
+)
+INSERT OR IGNORE INTO GroupMember (GroupId, UserId, Role, JoinedAt)
+  SELECT s.GroupId, s.UserId, 'member', g.CreationDate
+  FROM SplitGroup AS s
+  INNER JOIN Groups AS g ON g.Id = s.GroupId;
+
+DROP VIEW IF EXISTS GroupDetail;
+
+ALTER TABLE Groups DROP COLUMN MemberIds;
+ALTER TABLE UserInfo DROP COLUMN GroupsJoined;
+
+CREATE VIEW IF NOT EXISTS GroupDetail AS
+  SELECT 
+    g.Id,
+    g.LeaderId,
+    
+    CASE 
+      WHEN u.Username = '' THEN CONCAT(u.FirstName, ' ', u.LastName)
+      ELSE u.Username 
+    END AS Leader,
+
+    (
+      SELECT GROUP_CONCAT(m.UserId, ' | ')
+      FROM (
+        SELECT UserId FROM GroupMember WHERE GroupId = g.Id ORDER BY JoinedAt, rowid
+      ) AS m
+    ) AS MemberIds,
+    g.groupName,
+    g.GroupDescription,
+    g.CreationDate,
+    g.GroupPicture,
+    g.Banner
+
+FROM Groups AS g
+INNER JOIN UserInfo AS u ON u.Id = g.LeaderId;
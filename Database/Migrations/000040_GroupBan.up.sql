@@ -0,0 +1,15 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS GroupBan (
+	GroupId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+	BannedBy VARCHAR(36) NOT NULL,
+	Reason VARCHAR(500) NOT NULL DEFAULT '',
+	CreationDate VARCHAR(20) NOT NULL,
+	ExpirationDate VARCHAR(20) NOT NULL DEFAULT '',
+
+	PRIMARY KEY (GroupId, UserId),
+
+	CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
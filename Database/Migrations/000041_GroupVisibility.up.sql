@@ -0,0 +1,31 @@
+PRAGMA foreign_keys = ON;
+
+ALTER TABLE Groups ADD COLUMN Visibility VARCHAR(10) NOT NULL DEFAULT 'private';
+
+DROP VIEW IF EXISTS GroupDetail;
+
+CREATE VIEW IF NOT EXISTS GroupDetail AS
+  SELECT 
+    g.Id,
+    g.LeaderId,
+    
+    CASE 
+      WHEN u.Username = '' THEN CONCAT(u.FirstName, ' ', u.LastName)
+      ELSE u.Username 
+    END AS Leader,
+
+    (
+      SELECT GROUP_CONCAT(m.UserId, ' | ')
+      FROM (
+        SELECT UserId FROM GroupMember WHERE GroupId = g.Id ORDER BY JoinedAt, rowid
+      ) AS m
+    ) AS MemberIds,
+    g.groupName,
+    g.GroupDescription,
+    g.CreationDate,
+    g.GroupPicture,
+    g.Banner,
+    g.Visibility
+
+FROM Groups AS g
+INNER JOIN UserInfo AS u ON u.Id = g.LeaderId;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS GroupInviteLinkGroup;
+DROP TABLE IF EXISTS GroupInviteLink;
@@ -0,0 +1,19 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS GroupInviteLink (
+	Token VARCHAR(36) NOT NULL,
+	GroupId VARCHAR(36) NOT NULL,
+	CreatorId VARCHAR(36) NOT NULL,
+	CreationDate VARCHAR(20) NOT NULL,
+	ExpirationDate VARCHAR(20) NOT NULL DEFAULT '',
+	MaxUses INTEGER NOT NULL DEFAULT 0,
+	Uses INTEGER NOT NULL DEFAULT 0,
+	SkipApproval BOOLEAN NOT NULL DEFAULT 0,
+
+	PRIMARY KEY (Token),
+
+	CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_creatorid FOREIGN KEY (CreatorId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS GroupInviteLinkGroup ON GroupInviteLink (GroupId);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS GroupCategoryCategory;
+DROP TABLE IF EXISTS GroupCategory;
@@ -0,0 +1,12 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS GroupCategory (
+	GroupId VARCHAR(36) NOT NULL,
+	Category VARCHAR(50) NOT NULL,
+
+	PRIMARY KEY (GroupId, Category),
+
+	CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS GroupCategoryCategory ON GroupCategory (Category);
@@ -0,0 +1,33 @@
+DROP VIEW IF EXISTS GroupDetail;
+
+DROP TRIGGER IF EXISTS JoinGroupAnswerCleanup;
+DROP TABLE IF EXISTS JoinGroupAnswer;
+DROP TABLE IF EXISTS GroupQuestion;
+
+ALTER TABLE Groups DROP COLUMN Rules;
+
+CREATE VIEW IF NOT EXISTS GroupDetail AS
+  SELECT 
+    g.Id,
+    g.LeaderId,
+    
+    CASE 
+      WHEN u.Username = '' THEN CONCAT(u.FirstName, ' ', u.LastName)
+      ELSE u.Username 
+    END AS Leader,
+
+    (
+      SELECT GROUP_CONCAT(m.UserId, ' | ')
+      FROM (
+        SELECT UserId FROM GroupMember WHERE GroupId = g.Id ORDER BY JoinedAt, rowid
+      ) AS m
+    ) AS MemberIds,
+    g.groupName,
+    g.GroupDescription,
+    g.CreationDate,
+    g.GroupPicture,
+    g.Banner,
+    g.Visibility
+
+FROM Groups AS g
+INNER JOIN UserInfo AS u ON u.Id = g.LeaderId;
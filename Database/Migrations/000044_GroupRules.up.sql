@@ -0,0 +1,60 @@
+PRAGMA foreign_keys = ON;
+
+ALTER TABLE Groups ADD COLUMN Rules TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS GroupQuestion (
+	GroupId VARCHAR(36) NOT NULL,
+	Position INTEGER NOT NULL,
+	Question VARCHAR(300) NOT NULL,
+
+	PRIMARY KEY (GroupId, Position),
+
+	CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS JoinGroupAnswer (
+	GroupId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+	Position INTEGER NOT NULL,
+	Question VARCHAR(300) NOT NULL,
+	Answer VARCHAR(1000) NOT NULL,
+
+	PRIMARY KEY (GroupId, UserId, Position),
+
+	CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+CREATE TRIGGER IF NOT EXISTS JoinGroupAnswerCleanup AFTER DELETE ON JoinGroupRequest
+BEGIN
+	DELETE FROM JoinGroupAnswer WHERE GroupId = OLD.GroupId AND UserId = OLD.UserId;
+END;
+
+DROP VIEW IF EXISTS GroupDetail;
+
+CREATE VIEW IF NOT EXISTS GroupDetail AS
+  SELECT 
+    g.Id,
+    g.LeaderId,
+    
+    CASE 
+      WHEN u.Username = '' THEN CONCAT(u.FirstName, ' ', u.LastName)
+      ELSE u.Username 
+    END AS Leader,
+
+    (
+      SELECT GROUP_CONCAT(m.UserId, ' | ')
+      FROM (
+        SELECT UserId FROM GroupMember WHERE GroupId = g.Id ORDER BY JoinedAt, rowid
+      ) AS m
+    ) AS MemberIds,
+    g.groupName,
+    g.GroupDescription,
+    g.CreationDate,
+    g.GroupPicture,
+    g.Banner,
+    g.Visibility,
+    g.Rules
+
+FROM Groups AS g
+INNER JOIN UserInfo AS u ON u.Id = g.LeaderId;
@@ -0,0 +1,29 @@
+PRAGMA foreign_keys = ON;
+
+DROP VIEW IF EXISTS PostDetail;
+
+CREATE VIEW IF NOT EXISTS PostDetail AS
+  SELECT 
+    p.Id,
+	p.Text,
+	p.Image,
+	p.CreationDate,
+	p.IsGroup,
+	p.AuthorId,
+	p.LikeCount,
+	p.DislikeCount,
+	p.Status,
+	p.RepostOf,
+	p.RepostCount,
+	p.ContentWarning,
+	p.SensitiveMedia,
+	u.FirstName,
+	u.LastName,
+	u.ProfilePicture,
+	u.Username
+FROM Post AS p
+INNER JOIN UserInfo AS u ON p.AuthorId = u.Id;
+
+DROP TABLE IF EXISTS AnnouncementAck;
+
+ALTER TABLE Post DROP COLUMN IsAnnouncement;
@@ -0,0 +1,39 @@
+PRAGMA foreign_keys = ON;
+
+ALTER TABLE Post ADD COLUMN IsAnnouncement BOOLEAN NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS AnnouncementAck (
+	PostId VARCHAR(36) NOT NULL,
+	UserId VARCHAR(36) NOT NULL,
+	CreationDate VARCHAR(30) NOT NULL,
+
+	PRIMARY KEY (PostId, UserId),
+
+	CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+DROP VIEW IF EXISTS PostDetail;
+
+CREATE VIEW IF NOT EXISTS PostDetail AS
+  SELECT 
+    p.Id,
+	p.Text,
+	p.Image,
+	p.CreationDate,
+	p.IsGroup,
+	p.AuthorId,
+	p.LikeCount,
+	p.DislikeCount,
+	p.Status,
+	p.RepostOf,
+	p.RepostCount,
+	p.ContentWarning,
+	p.SensitiveMedia,
+	p.IsAnnouncement,
+	u.FirstName,
+	u.LastName,
+	u.ProfilePicture,
+	u.Username
+FROM Post AS p
+INNER JOIN UserInfo AS u ON p.AuthorId = u.Id;
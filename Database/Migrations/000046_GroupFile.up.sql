@@ -0,0 +1,18 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS GroupFile (
+	Id VARCHAR(36) NOT NULL,
+	GroupId VARCHAR(36) NOT NULL,
+	UploaderId VARCHAR(36) NOT NULL,
+	Folder VARCHAR(200) NOT NULL DEFAULT '',
+	Name VARCHAR(255) NOT NULL,
+	MimeType VARCHAR(100) NOT NULL,
+	Size INTEGER NOT NULL,
+	CreationDate VARCHAR(30) NOT NULL,
+
+	PRIMARY KEY (Id),
+	UNIQUE (GroupId, Folder, Name),
+
+	CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_uploaderid FOREIGN KEY (UploaderId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
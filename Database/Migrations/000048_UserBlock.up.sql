@@ -0,0 +1,12 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS UserBlock (
+	BlockerId VARCHAR(36) NOT NULL,
+	BlockedId VARCHAR(36) NOT NULL,
+	CreationDate VARCHAR(20) NOT NULL,
+
+	PRIMARY KEY (BlockerId, BlockedId),
+
+	CONSTRAINT fk_blockerid FOREIGN KEY (BlockerId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_blockedid FOREIGN KEY (BlockedId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS GroupMute;
+DROP TABLE IF EXISTS UserMute;
@@ -0,0 +1,25 @@
+PRAGMA foreign_keys = ON;
+
+CREATE TABLE IF NOT EXISTS UserMute (
+	UserId VARCHAR(36) NOT NULL,
+	MutedUserId VARCHAR(36) NOT NULL,
+	CreationDate VARCHAR(20) NOT NULL,
+	ExpirationDate VARCHAR(20) NOT NULL DEFAULT '',
+
+	PRIMARY KEY (UserId, MutedUserId),
+
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_muteduserid FOREIGN KEY (MutedUserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS GroupMute (
+	UserId VARCHAR(36) NOT NULL,
+	GroupId VARCHAR(36) NOT NULL,
+	CreationDate VARCHAR(20) NOT NULL,
+	ExpirationDate VARCHAR(20) NOT NULL DEFAULT '',
+
+	PRIMARY KEY (UserId, GroupId),
+
+	CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+	CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+PRAGMA foreign_keys = ON;
+
+ALTER TABLE PostDraft DROP COLUMN SensitiveMedia;
+ALTER TABLE PostDraft DROP COLUMN ContentWarning;
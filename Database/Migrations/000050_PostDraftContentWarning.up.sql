@@ -0,0 +1,6 @@
+PRAGMA foreign_keys = ON;
+
+-- The content warning of the drafts (000033) belongs with the one of the posts (000037), but a database
+-- already migrated past 000037 wouldn't run it again: this forward migration is required to add the columns.
+ALTER TABLE PostDraft ADD COLUMN ContentWarning VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE PostDraft ADD COLUMN SensitiveMedia BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// The format of the creation date of a bookmark, the strings can be compared to sort the bookmarks.
+const bookmarkDateFormat = "2006-01-02 15:04:05.000000"
+
+// The quantity of bookmarks sent when the request doesn't give any limit, and the maximum allowed.
+const (
+	defaultBookmarkLimit = 20
+	maxBookmarkLimit     = 100
+)
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the saving of a post in the bookmarks of the user, with an optional collection.
+Bookmarking an already saved post moves it to the given collection.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func BookmarkPost(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var bookmark model.Bookmark
+
+		// Decode the JSON request body into the bookmark struct.
+		if err := json.NewDecoder(r.Body).Decode(&bookmark); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [BookmarkPost] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(bookmark.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [BookmarkPost] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+		bookmark.UserId = userId
+
+		if len(bookmark.Collection) > 50 {
+			nw.Error("The name of the collection is too long")
+			log.Printf("[%s] [BookmarkPost] The name of the collection is too long", r.RemoteAddr)
+			return
+		}
+
+		// A user can only save a post they are allowed to see.
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": bookmark.PostId}); err != nil {
+			nw.Error("There is no post with this id")
+			log.Printf("[%s] [BookmarkPost] There is no post with the id %s : %v", r.RemoteAddr, bookmark.PostId, err)
+			return
+		}
+
+		if !CanSeePost(bookmark.UserId, post, db) {
+			nw.Error("You can't see this post")
+			log.Printf("[%s] [BookmarkPost] The user %s can't see the post %s", r.RemoteAddr, bookmark.UserId, bookmark.PostId)
+			return
+		}
+
+		where := map[string]any{"UserId": bookmark.UserId, "PostId": bookmark.PostId}
+		if utils.IfExistsInDB("Bookmark", db, where) == nil {
+			// The post is already saved, we only move it to the new collection.
+			err = bookmark.UpdateDb(db, map[string]any{"Collection": bookmark.Collection}, where)
+		} else {
+			bookmark.CreationDate = time.Now().UTC().Format(bookmarkDateFormat)
+			err = bookmark.InsertIntoDb(db)
+		}
+		if err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB: " + err.Error())
+			log.Printf("[%s] [BookmarkPost] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Post bookmarked successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [BookmarkPost] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the removal of a post from the bookmarks of the user.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UnbookmarkPost(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var bookmark model.Bookmark
+
+		// Decode the JSON request body into the bookmark struct.
+		if err := json.NewDecoder(r.Body).Decode(&bookmark); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [UnbookmarkPost] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(bookmark.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UnbookmarkPost] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		where := map[string]any{"UserId": userId, "PostId": bookmark.PostId}
+		if err = utils.IfExistsInDB("Bookmark", db, where); err != nil {
+			nw.Error("This post isn't bookmarked")
+			log.Printf("[%s] [UnbookmarkPost] The post %s isn't bookmarked by %s : %v", r.RemoteAddr, bookmark.PostId, userId, err)
+			return
+		}
+
+		if err = bookmark.DeleteFromDb(db, where); err != nil {
+			nw.Error("Internal Error: There is a problem during the delete in the DB")
+			log.Printf("[%s] [UnbookmarkPost] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Post unbookmarked successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [UnbookmarkPost] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the bookmarks of the user, from the newest to the oldest.
+An optional Collection can be given to only get the bookmarks of this collection, and Offset and Limit are used for the pagination.
+The bookmarked posts the user can't see anymore (unfollowed author, removed from the audience, left group...) are not sent.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetBookmarks(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId     string `json:"UserId"`
+			Collection string `json:"Collection"`
+
+			// The pagination of the bookmarks.
+			Offset int `json:"Offset"`
+			Limit  int `json:"Limit"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetBookmarks] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetBookmarks] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if datas.Offset < 0 || datas.Limit < 0 {
+			nw.Error("Invalid pagination")
+			log.Printf("[%s] [GetBookmarks] Invalid pagination : offset %d and limit %d", r.RemoteAddr, datas.Offset, datas.Limit)
+			return
+		}
+
+		if datas.Limit == 0 {
+			datas.Limit = defaultBookmarkLimit
+		}
+		datas.Limit = min(datas.Limit, maxBookmarkLimit)
+
+		where := map[string]any{"UserId": userId}
+		if datas.Collection != "" {
+			where["Collection"] = datas.Collection
+		}
+
+		var bookmarks model.Bookmarks
+		if err = bookmarks.SelectFromDb(db, where); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetBookmarks] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// The posts of all the bookmarks are fetched in one query.
+		postIds := []string{}
+		for _, bookmark := range bookmarks {
+			postIds = append(postIds, bookmark.PostId)
+		}
+
+		var posts model.Posts
+		if err = posts.SelectFromDb(db, map[string]any{"Id": postIds}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetBookmarks] Error during the fetch of the posts : %v", r.RemoteAddr, err)
+			return
+		}
+
+		postsById := map[string]model.Post{}
+		for _, post := range posts {
+			postsById[post.Id] = post
+		}
+
+		// We keep only the bookmarks whose post is still visible, before the pagination so the pages stay full.
+		var visibleBookmarks model.Bookmarks
+		for _, bookmark := range bookmarks {
+			post, isOk := postsById[bookmark.PostId]
+			if !isOk || !CanSeePost(userId, post, db) {
+				continue
+			}
+
+			bookmark.Post = post
+			visibleBookmarks = append(visibleBookmarks, bookmark)
+		}
+
+		page := model.Bookmarks{}
+		if datas.Offset < len(visibleBookmarks) {
+			page = visibleBookmarks[datas.Offset:min(datas.Offset+datas.Limit, len(visibleBookmarks))]
+		}
+
+		// The link previews and the polls are only attached to the posts of the page.
+		pagePosts := model.Posts{}
+		for _, bookmark := range page {
+			pagePosts = append(pagePosts, bookmark.Post)
+		}
+		attachPostLinkPreviews(db, pagePosts)
+		attachPostPolls(db, pagePosts)
+		for i := range page {
+			page[i].Post = pagePosts[i]
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Bookmarks getted successfully",
+
+			"Value": page,
+			// The quantity of visible bookmarks, to know if there is another page.
+			"Total": len(visibleBookmarks),
+		})
+		if err != nil {
+			log.Printf("[%s] [GetBookmarks] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestBookmarks(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	publicPost := CreateReactionTarget(t, db)
+
+	reader := model.Register{
+		Auth:      model.Auth{Id: "readerId", Email: "reader", Password: "password"},
+		FirstName: "reader",
+		LastName:  "reader",
+		BirthDate: "monday",
+	}
+	if err = reader.Auth.InsertIntoDb(db); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err = reader.InsertIntoDb(db); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	privatePost := model.Post{Id: "privateId", AuthorId: publicPost.AuthorId, Text: "text", CreationDate: "now", Status: "private"}
+	if err = privatePost.InsertIntoDb(db); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	jwt := utils.GenerateJWT(reader.Id)
+
+	if success, rr := TryRequest(t, BookmarkPost(db), map[string]any{"UserId": jwt, "PostId": publicPost.Id}); !success {
+		t.Fatalf("The public post should be bookmarked : %s", rr.Body.String())
+	}
+
+	// The reader doesn't follow the author so the private post can't be saved
+	if success, _ := TryRequest(t, BookmarkPost(db), map[string]any{"UserId": jwt, "PostId": privatePost.Id}); success {
+		t.Fatal("A post the user can't see shouldn't be bookmarked")
+	}
+
+	follow := model.Follower{Id: "followId", FollowerId: reader.Id, FollowedId: privatePost.AuthorId}
+	if err = follow.InsertIntoDb(db); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if success, rr := TryRequest(t, BookmarkPost(db), map[string]any{"UserId": jwt, "PostId": privatePost.Id, "Collection": "later"}); !success {
+		t.Fatalf("The private post should be bookmarked : %s", rr.Body.String())
+	}
+
+	var bodyValue struct {
+		Value model.Bookmarks
+		Total int
+	}
+
+	_, rr := TryRequest(t, GetBookmarks(db), map[string]any{"UserId": jwt, "Limit": 1})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	// The newest bookmark comes first
+	if bodyValue.Total != 2 || len(bodyValue.Value) != 1 || bodyValue.Value[0].PostId != privatePost.Id || bodyValue.Value[0].Post.Text != "text" {
+		t.Fatalf("The bookmarks are not the good ones : %s", rr.Body.String())
+	}
+
+	_, rr = TryRequest(t, GetBookmarks(db), map[string]any{"UserId": jwt, "Collection": "later"})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if bodyValue.Total != 1 || bodyValue.Value[0].Collection != "later" {
+		t.Fatalf("Only the bookmarks of the collection should be sent : %s", rr.Body.String())
+	}
+
+	// Once the author is unfollowed, the private post must not leak anymore
+	if err = follow.DeleteFromDb(db, map[string]any{"Id": follow.Id}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, rr = TryRequest(t, GetBookmarks(db), map[string]any{"UserId": jwt})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if bodyValue.Total != 1 || bodyValue.Value[0].PostId != publicPost.Id {
+		t.Fatalf("The invisible post should be filtered out : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, UnbookmarkPost(db), map[string]any{"UserId": jwt, "PostId": publicPost.Id}); !success {
+		t.Fatalf("The post should be unbookmarked : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, UnbookmarkPost(db), map[string]any{"UserId": jwt, "PostId": publicPost.Id}); success {
+		t.Fatal("A post who isn't bookmarked can't be unbookmarked")
+	}
+}
+
+func TestCanSeePost(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	if !CanSeePost("otherId", post, db) {
+		t.Fatal("A public post should be visible")
+	}
+
+	post.Status = "almost private | readerId"
+	if !CanSeePost("readerId", post, db) || CanSeePost("otherId", post, db) {
+		t.Fatal("An almost private post should only be visible for its audience")
+	}
+
+	post.Status = "private"
+	if !CanSeePost(post.AuthorId, post, db) || CanSeePost("otherId", post, db) {
+		t.Fatal("A private post should only be visible for the author and the followers")
+	}
+}
@@ -112,6 +112,14 @@ func HandleChangeUserData(db *sql.DB) http.HandlerFunc {
 			}
 		}
 
+		if userPreviousData.SensitiveContent != userInfo.SensitiveContent && (userInfo.SensitiveContent == "hide" || userInfo.SensitiveContent == "expand") {
+			if err = userPreviousData.UpdateDb(db, map[string]any{"SensitiveContent": userInfo.SensitiveContent}, map[string]any{"Id": userInfo.Id}); err != nil {
+				nw.Error("Error during the updating of the DB")
+				log.Printf("[%s] [ChangeUserData] Error during the updating of the DB : %s", r.RemoteAddr, err.Error())
+				return
+			}
+		}
+
 		if userPreviousData.ProfilePicture != userInfo.ProfilePicture && userInfo.ProfilePicture != "" {
 			if err = userPreviousData.UpdateDb(db, map[string]any{"ProfilePicture": userInfo.ProfilePicture}, map[string]any{"Id": userInfo.Id}); err != nil {
 				nw.Error("Error during the updating of the DB") // Handle JWT decryption error
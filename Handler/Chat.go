@@ -65,6 +65,37 @@ func AddMessage(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		if message.ReceiverId != "" && isBlocked(db, message.SenderId, message.ReceiverId) {
+			nw.Error("You can't send a message to this user")
+			log.Printf("[%s] [AddMessage] There is a block between the users %s and %s", r.RemoteAddr, message.SenderId, message.ReceiverId)
+			return
+		}
+
+		banned, err := isGroupBanned(db, message.GroupId, message.SenderId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [AddMessage] Error during the fetch of the bans : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if banned {
+			nw.Error("You are banned from this group")
+			log.Printf("[%s] [AddMessage] The user %s is banned from the group %s", r.RemoteAddr, message.SenderId, message.GroupId)
+			return
+		}
+
+		if message.GroupId != "" && !IsGroupMember(message.GroupId, message.SenderId, db) {
+			nw.Error("Only the members can write in the chat of the group")
+			log.Printf("[%s] [AddMessage] The user %s isn't a member of the group %s", r.RemoteAddr, message.SenderId, message.GroupId)
+			return
+		}
+
+		if message.GroupId != "" && isGroupArchived(db, message.GroupId) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [AddMessage] The group %s is archived", r.RemoteAddr, message.GroupId)
+			return
+		}
+
 		messageId, err := uuid.NewV7()
 		if err != nil {
 			nw.Error("There is a problem with the generation of the uuid") // Handle UUID generation error
@@ -80,6 +111,9 @@ func AddMessage(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		// The preview of the first link of the message is fetched in the background.
+		StartLinkPreview(db, "Message", message.Id, message.Message)
+
 		if message.ReceiverId != "" {
 			notifId, err := uuid.NewV7()
 			if err != nil {
@@ -111,10 +145,15 @@ func AddMessage(db *sql.DB) http.HandlerFunc {
 				OtherUserId: message.SenderId,
 			}
 
-			if err = notification.InsertIntoDb(db); err != nil {
-				nw.Error("There is a probleme during the sending of a notification")
-				log.Printf("[%s] [AddMessage] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
-				return
+			// The receiver isn't notified if they muted the sender, the message is still saved.
+			muted := isMuted(db, message.ReceiverId, message.SenderId, "")
+
+			if !muted {
+				if err = notification.InsertIntoDb(db); err != nil {
+					nw.Error("There is a probleme during the sending of a notification")
+					log.Printf("[%s] [AddMessage] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
+					return
+				}
 			}
 
 			model.ConnectedWebSocket.Mu.Lock()
@@ -142,7 +181,7 @@ func AddMessage(db *sql.DB) http.HandlerFunc {
 				}
 
 				_, isOk2 := model.ConnectedWebSocket.Conn[message.ReceiverId]
-				if isOk2 {
+				if isOk2 && !muted {
 					if err = model.ConnectedWebSocket.Conn[message.ReceiverId].WriteJSON(WebsocketMessage); err != nil {
 
 						nw.Error("Error during the communication with the websocket")
@@ -178,6 +217,11 @@ func AddMessage(db *sql.DB) http.HandlerFunc {
 			}
 
 			for i := range group.SplitMemberIds {
+				// The members who muted the group or the sender don't receive the message in real time.
+				if message.SenderId != group.SplitMemberIds[i] && isMuted(db, group.SplitMemberIds[i], message.SenderId, message.GroupId) {
+					continue
+				}
+
 				notifId, err := uuid.NewV7()
 				if err != nil {
 					nw.Error("There is a problem with the generation of the uuid") // Handle UUID generation error
@@ -279,6 +323,25 @@ func GetMessage(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		banned, err := isGroupBanned(db, message.GroupId, message.SenderId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetMessage] Error during the fetch of the bans : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if banned {
+			nw.Error("You are banned from this group")
+			log.Printf("[%s] [GetMessage] The user %s is banned from the group %s", r.RemoteAddr, message.SenderId, message.GroupId)
+			return
+		}
+
+		if message.GroupId != "" && !canSeeGroupContent(db, message.GroupId, message.SenderId) {
+			nw.Error("You can't see the chat of this group")
+			log.Printf("[%s] [GetMessage] The user %s can't see the content of the group %s", r.RemoteAddr, message.SenderId, message.GroupId)
+			return
+		}
+
 		var messages model.Messages
 		if message.GroupId != "" {
 			if err = messages.GetGroupsMessages(db, message); err != nil {
@@ -294,6 +357,8 @@ func GetMessage(db *sql.DB) http.HandlerFunc {
 			}
 		}
 
+		attachMessageLinkPreviews(db, messages)
+
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
 			"Success": true,
@@ -38,6 +38,12 @@ func CreateComment(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		if err := checkContentWarning(comment.ContentWarning); err != nil {
+			nw.Error("Invalid content warning")
+			log.Printf("[%s] [CreateComment] Invalid content warning : %v", r.RemoteAddr, err)
+			return
+		}
+
 		// Decrypt the AuthorId from the JWT to get the actual author ID
 		decryptAuthorId, err := utils.DecryptJWT(comment.AuthorId, db)
 		if err != nil {
@@ -66,6 +72,18 @@ func CreateComment(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		if post.IsGroup != "" && isGroupArchived(db, post.IsGroup) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [CreateComment] The group %s is archived", r.RemoteAddr, post.IsGroup)
+			return
+		}
+
+		if isBlocked(db, comment.AuthorId, post.AuthorId) {
+			nw.Error("You can't comment this post")
+			log.Printf("[%s] [CreateComment] There is a block between the users %s and %s", r.RemoteAddr, comment.AuthorId, post.AuthorId)
+			return
+		}
+
 		// Generate a new UUID for the comment
 		uid, err := uuid.NewV7()
 		if err != nil {
@@ -112,10 +130,13 @@ func CreateComment(db *sql.DB) http.HandlerFunc {
 			OtherUserId: "",
 		}
 
-		if err = notification.InsertIntoDb(db); err != nil {
-			nw.Error("There is a probleme during the sending of a notification")
-			log.Printf("[%s] [CreateComment] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
-			return
+		// The author of the post isn't notified if they muted the author of the comment.
+		if !isMuted(db, post.AuthorId, comment.AuthorId, "") {
+			if err = notification.InsertIntoDb(db); err != nil {
+				nw.Error("There is a probleme during the sending of a notification")
+				log.Printf("[%s] [CreateComment] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
+				return
+			}
 		}
 
 		model.ConnectedWebSocket.Mu.Lock()
@@ -185,7 +206,7 @@ func GetComment(db *sql.DB) http.HandlerFunc {
 		}
 
 		// Decrypt the AuthorId from the JWT to ensure the request is valid
-		_, err := utils.DecryptJWT(comment.AuthorId, db)
+		userId, err := utils.DecryptJWT(comment.AuthorId, db)
 		if err != nil {
 			nw.Error("Invalid JWT") // Handle invalid JWT error
 			log.Printf("[%s] [GetComment] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
@@ -208,6 +229,9 @@ func GetComment(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		// The comments of the users with a block with the current user are hidden.
+		comments = removeBlockedComments(db, comments, userId)
+
 		// Send a success response with the retrieved comments
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
@@ -220,3 +244,77 @@ func GetComment(db *sql.DB) http.HandlerFunc {
 		}
 	}
 }
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the removal of a comment, with the same rights as for the posts:
+the author of the comment or the leader and the moderators of the group of the post.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func DeleteComment(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId    string `json:"UserId"`
+			CommentId string `json:"CommentId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [DeleteComment] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [DeleteComment] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var comment model.Comment
+		if err = comment.SelectFromDb(db, map[string]any{"Id": datas.CommentId}); err != nil {
+			nw.Error("There is no comment with this id")
+			log.Printf("[%s] [DeleteComment] There is no comment with the id %s : %v", r.RemoteAddr, datas.CommentId, err)
+			return
+		}
+
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": comment.PostId}); err != nil {
+			nw.Error("There is no post for this comment")
+			log.Printf("[%s] [DeleteComment] There is no post with the id %s : %v", r.RemoteAddr, comment.PostId, err)
+			return
+		}
+
+		if !canModerateContent(db, userId, comment.AuthorId, post.IsGroup) {
+			nw.Error("You can't remove this comment")
+			log.Printf("[%s] [DeleteComment] The user %s can't remove the comment %s", r.RemoteAddr, userId, comment.Id)
+			return
+		}
+
+		if err = comment.DeleteFromDb(db, map[string]any{"Id": comment.Id}); err != nil {
+			nw.Error("Internal Error: There is a problem during the delete in the DB")
+			log.Printf("[%s] [DeleteComment] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Comment deleted successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [DeleteComment] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"unicode/utf8"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// The maximum quantity of characters of a content warning.
+const maxContentWarningLength = 200
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the change of the content warning and of the sensitive media flag of a post.
+The author can change the flags of their post, and the leader and the moderators of a group can change the flags of the posts of the group.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func FlagPost(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId         string `json:"UserId"`
+			PostId         string `json:"PostId"`
+			ContentWarning string `json:"ContentWarning"`
+			SensitiveMedia bool   `json:"SensitiveMedia"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [FlagPost] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [FlagPost] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = checkContentWarning(datas.ContentWarning); err != nil {
+			nw.Error("Invalid content warning")
+			log.Printf("[%s] [FlagPost] Invalid content warning : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": datas.PostId}); err != nil {
+			nw.Error("There is no post with this id")
+			log.Printf("[%s] [FlagPost] There is no post with the id %s : %v", r.RemoteAddr, datas.PostId, err)
+			return
+		}
+
+		if !canModerateContent(db, userId, post.AuthorId, post.IsGroup) {
+			nw.Error("You can't change the flags of this post")
+			log.Printf("[%s] [FlagPost] The user %s can't change the flags of the post %s", r.RemoteAddr, userId, post.Id)
+			return
+		}
+
+		if err = post.UpdateDb(db, map[string]any{"ContentWarning": datas.ContentWarning, "SensitiveMedia": datas.SensitiveMedia}, map[string]any{"Id": post.Id}); err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [FlagPost] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Post flags updated successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [FlagPost] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the change of the content warning and of the sensitive media flag of a comment,
+with the same rights as for the posts: the author of the comment or the leader and the moderators of the group of the post.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func FlagComment(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId         string `json:"UserId"`
+			CommentId      string `json:"CommentId"`
+			ContentWarning string `json:"ContentWarning"`
+			SensitiveMedia bool   `json:"SensitiveMedia"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [FlagComment] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [FlagComment] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = checkContentWarning(datas.ContentWarning); err != nil {
+			nw.Error("Invalid content warning")
+			log.Printf("[%s] [FlagComment] Invalid content warning : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var comment model.Comment
+		if err = comment.SelectFromDb(db, map[string]any{"Id": datas.CommentId}); err != nil {
+			nw.Error("There is no comment with this id")
+			log.Printf("[%s] [FlagComment] There is no comment with the id %s : %v", r.RemoteAddr, datas.CommentId, err)
+			return
+		}
+
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": comment.PostId}); err != nil {
+			nw.Error("There is no post for this comment")
+			log.Printf("[%s] [FlagComment] There is no post with the id %s : %v", r.RemoteAddr, comment.PostId, err)
+			return
+		}
+
+		if !canModerateContent(db, userId, comment.AuthorId, post.IsGroup) {
+			nw.Error("You can't change the flags of this comment")
+			log.Printf("[%s] [FlagComment] The user %s can't change the flags of the comment %s", r.RemoteAddr, userId, comment.Id)
+			return
+		}
+
+		if err = comment.UpdateDb(db, map[string]any{"ContentWarning": datas.ContentWarning, "SensitiveMedia": datas.SensitiveMedia}, map[string]any{"Id": comment.Id}); err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [FlagComment] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Comment flags updated successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [FlagComment] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the content warning of a post or of a comment (can be empty)
+
+The purpose of this function is to check the length of a content warning.
+
+The function returns an error if the warning is too long.
+*/
+func checkContentWarning(warning string) error {
+	if utf8.RuneCountInString(warning) > maxContentWarningLength {
+		return errors.New("the content warning is too long")
+	}
+
+	return nil
+}
+
+/*
+This function takes 4 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the user who wants to change the flags
+  - a string containing the id of the author of the content
+  - a string containing the id of the group of the content (empty outside of a group)
+
+The purpose of this function is to check if a user can change the content warning and the sensitive media flag of a content:
+the author always can, and the leader and the moderators of the group moderate the content of the group.
+
+The function returns true if the user can change the flags.
+*/
+func canModerateContent(db *sql.DB, userId, authorId, groupId string) bool {
+	if userId == authorId {
+		return true
+	}
+
+	return groupId != "" && isGroupModerator(db, groupId, userId)
+}
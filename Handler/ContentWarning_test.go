@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"strings"
+	"testing"
+)
+
+func TestFlagPost(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+	CreateTestUser(t, db, "leaderId")
+	jwt := utils.GenerateJWT(post.AuthorId)
+
+	newPost := map[string]any{
+		"AuthorId":       jwt,
+		"Text":           "text",
+		"CreationDate":   "now",
+		"Status":         "public",
+		"ContentWarning": strings.Repeat("a", maxContentWarningLength+1),
+		"SensitiveMedia": true,
+	}
+
+	if success, _ := TryRequest(t, CreatePost(db), newPost); success {
+		t.Fatal("A content warning can't be too long")
+	}
+
+	newPost["ContentWarning"] = "spoilers"
+	success, rr := TryRequest(t, CreatePost(db), newPost)
+	if !success {
+		t.Fatalf("The post should be created : %s", rr.Body.String())
+	}
+
+	var created struct {
+		IdPost string
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	var flagged model.Post
+	if err = flagged.SelectFromDb(db, map[string]any{"Id": created.IdPost}); err != nil || flagged.ContentWarning != "spoilers" || !flagged.SensitiveMedia {
+		t.Fatalf("The flags should be in the post detail : %+v %v", flagged, err)
+	}
+
+	// Outside of a group, only the author can change the flags
+	if success, _ := TryRequest(t, FlagPost(db), map[string]any{"UserId": utils.GenerateJWT("leaderId"), "PostId": post.Id, "SensitiveMedia": true}); success {
+		t.Fatal("Another user can't flag the post")
+	}
+
+	group := model.Group{
+		Id:           "groupId",
+		LeaderId:     "leaderId",
+		MemberIds:    post.AuthorId,
+		GroupName:    "group",
+		CreationDate: "now",
+	}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	groupPost := model.Post{Id: "groupPostId", AuthorId: post.AuthorId, Text: "group", CreationDate: "now", Status: "public", IsGroup: group.Id}
+	if err = groupPost.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, FlagPost(db), map[string]any{"UserId": utils.GenerateJWT("leaderId"), "PostId": groupPost.Id, "ContentWarning": "violence", "SensitiveMedia": true}); !success {
+		t.Fatalf("The leader of the group should flag the group post : %s", rr.Body.String())
+	}
+
+	if err = flagged.SelectFromDb(db, map[string]any{"Id": groupPost.Id}); err != nil || flagged.ContentWarning != "violence" || !flagged.SensitiveMedia {
+		t.Fatalf("The group post should be flagged : %+v %v", flagged, err)
+	}
+
+	comment := model.Comment{Id: "commentId", AuthorId: post.AuthorId, Text: "comment", CreationDate: "now", PostId: groupPost.Id}
+	if err = comment.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, FlagComment(db), map[string]any{"UserId": utils.GenerateJWT("leaderId"), "CommentId": comment.Id, "SensitiveMedia": true}); !success {
+		t.Fatalf("The leader of the group should flag the comment : %s", rr.Body.String())
+	}
+
+	if err = comment.SelectFromDb(db, map[string]any{"Id": comment.Id}); err != nil || !comment.SensitiveMedia {
+		t.Fatalf("The comment should be flagged : %+v %v", comment, err)
+	}
+}
+
+func TestSensitiveContentPreference(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	register := CreateTestUser(t, db, "userId")
+
+	var user model.Register
+	if err = user.SelectFromDb(db, map[string]any{"Id": register.Id}); err != nil || user.SensitiveContent != "hide" {
+		t.Fatalf("The sensitive content should be hidden by default : %+v %v", user, err)
+	}
+
+	if success, rr := TryRequest(t, HandleChangeUserData(db), map[string]any{"Id": utils.GenerateJWT(register.Id), "SensitiveContent": "expand"}); !success {
+		t.Fatalf("The preference should be updated : %s", rr.Body.String())
+	}
+
+	if err = user.SelectFromDb(db, map[string]any{"Id": register.Id}); err != nil || user.SensitiveContent != "expand" {
+		t.Fatalf("The sensitive content should be expanded : %+v %v", user, err)
+	}
+}
+
+func TestContentWarningOfDraftsAndReposts(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+	CreateTestUser(t, db, "reposterId")
+	reposterJwt := utils.GenerateJWT("reposterId")
+
+	if success, _ := TryRequest(t, SavePostDraft(db), map[string]any{"AuthorId": utils.GenerateJWT(post.AuthorId), "Text": "text", "ContentWarning": strings.Repeat("a", maxContentWarningLength+1)}); success {
+		t.Fatal("The content warning of a draft can't be too long")
+	}
+
+	// The flags of a scheduled draft are kept once it is published
+	draft := model.PostDraft{
+		Id:             "draftId",
+		AuthorId:       post.AuthorId,
+		Text:           "scheduled",
+		Status:         "public",
+		PublishDate:    "2000-01-01 10:00",
+		ContentWarning: "spoilers",
+		SensitiveMedia: true,
+	}
+	if err = draft.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = publishPostDraft(db, draft); err != nil {
+		t.Fatalf("Error during the publication : %v", err)
+	}
+
+	var posts model.Posts
+	if err = posts.SelectFromDb(db, map[string]any{"Text": "scheduled"}); err != nil || len(posts) != 1 || posts[0].ContentWarning != "spoilers" || !posts[0].SensitiveMedia {
+		t.Fatalf("The flags of the draft should be given to the post : %+v %v", posts, err)
+	}
+
+	if success, _ := TryRequest(t, RepostPost(db), map[string]any{"AuthorId": reposterJwt, "RepostOf": post.Id, "Text": "quote", "CreationDate": "now", "ContentWarning": strings.Repeat("a", maxContentWarningLength+1)}); success {
+		t.Fatal("The content warning of a quote post can't be too long")
+	}
+
+	if success, rr := TryRequest(t, RepostPost(db), map[string]any{"AuthorId": reposterJwt, "RepostOf": post.Id, "Text": "quote", "CreationDate": "now", "ContentWarning": "spoilers"}); !success {
+		t.Fatalf("The quote post should be created : %s", rr.Body.String())
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"slices"
 	"strings"
 
 	model "social-network/Model"
@@ -55,13 +54,18 @@ func CreateEvent(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		group.SplitMembers()
-		if !slices.Contains(group.SplitMemberIds, event.OrganisatorId) {
+		if !IsGroupMember(group.Id, event.OrganisatorId, db) {
 			nw.Error("You are not in this group")
 			log.Printf("[%s] [CreateEvent] You are not in this group", r.RemoteAddr)
 			return
 		}
 
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [CreateEvent] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
 		// Generate a new UUID for the event
 		uid, err := uuid.NewV7()
 		if err != nil {
@@ -181,12 +185,21 @@ func JoinEvent(db *sql.DB) http.HandlerFunc {
 		// Set the decrypted User ID
 		joinEvent.UserId = decryptAuthorId
 
-		if err = utils.IfExistsInDB("Event", db, map[string]any{"Id": joinEvent.EventId}); err != nil {
+		var event model.Event
+		events, err := event.SelectFromDb(db, map[string]any{"Id": joinEvent.EventId})
+		if err != nil || len(events) != 1 {
 			nw.Error("Invalid event id")
 			log.Printf("[%s] [JoinEvent] Invalid event id : %v", r.RemoteAddr, err)
 			return
 		}
 
+		// The answers to the events of an archived group can't change anymore.
+		if isGroupArchived(db, events[0].GroupId) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [JoinEvent] The group %s is archived", r.RemoteAddr, events[0].GroupId)
+			return
+		}
+
 		if err = utils.IfNotExistsInDB("JoinEvent", db, map[string]any{"EventId": joinEvent.EventId, "UserId": joinEvent.UserId}); err != nil {
 			nw.Error("Event already joined")
 			log.Printf("[%s] [JoinEvent] Event already joined : %v", r.RemoteAddr, err)
@@ -274,12 +287,21 @@ func DeclineEvent(db *sql.DB) http.HandlerFunc {
 		// Set the decrypted User ID
 		declineEvent.UserId = decryptAuthorId
 
-		if err = utils.IfExistsInDB("Event", db, map[string]any{"Id": declineEvent.EventId}); err != nil {
+		var event model.Event
+		events, err := event.SelectFromDb(db, map[string]any{"Id": declineEvent.EventId})
+		if err != nil || len(events) != 1 {
 			nw.Error("Invalid event id")
 			log.Printf("[%s] [DeclineEvent] Invalid event id : %v", r.RemoteAddr, err)
 			return
 		}
 
+		// The answers to the events of an archived group can't change anymore.
+		if isGroupArchived(db, events[0].GroupId) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [DeclineEvent] The group %s is archived", r.RemoteAddr, events[0].GroupId)
+			return
+		}
+
 		if err = utils.IfNotExistsInDB("JoinEvent", db, map[string]any{"EventId": declineEvent.EventId, "UserId": declineEvent.UserId}); err != nil {
 			var joinEvent = model.JoinEvent(declineEvent)
 
@@ -417,13 +439,19 @@ func GetAllGroupEvents(db *sql.DB) http.HandlerFunc {
 		}
 
 		// Decrypt the OrganisatorId from the JWT to get the actual Organisator ID
-		_, err := utils.DecryptJWT(datas.UserId, db)
+		userId, err := utils.DecryptJWT(datas.UserId, db)
 		if err != nil {
 			nw.Error("Invalid JWT")
 			log.Printf("[%s] [GetAllGroupEvents] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
 			return
 		}
 
+		if !canSeeGroupContent(db, datas.GroupId, userId) {
+			nw.Error("You can't see the events of this group")
+			log.Printf("[%s] [GetAllGroupEvents] The user %s can't see the content of the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
 		var event model.Event
 		events, err := event.SelectFromDb(db, map[string]any{"GroupId": datas.GroupId})
 		if err != nil {
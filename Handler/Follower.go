@@ -85,6 +85,12 @@ func AddFollower(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		if isBlocked(db, follower.FollowerId, follower.FollowedId) {
+			nw.Error("You can't follow this user")
+			log.Printf("[%s] [AddFollower] There is a block between the users %s and %s", r.RemoteAddr, follower.FollowerId, follower.FollowedId)
+			return
+		}
+
 		notifMessage := ""
 
 		var followedData model.Register
@@ -156,10 +162,15 @@ func AddFollower(db *sql.DB) http.HandlerFunc {
 			OtherUserId: "",
 		}
 
-		if err = notification.InsertIntoDb(db); err != nil {
-			nw.Error("There is a probleme during the sending of a notification")
-			log.Printf("[%s] [AddFollower] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
-			return
+		// The followed user isn't notified if they muted the follower.
+		muted := isMuted(db, follower.FollowedId, follower.FollowerId, "")
+
+		if !muted {
+			if err = notification.InsertIntoDb(db); err != nil {
+				nw.Error("There is a probleme during the sending of a notification")
+				log.Printf("[%s] [AddFollower] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
+				return
+			}
 		}
 
 		model.ConnectedWebSocket.Mu.Lock()
@@ -184,7 +195,7 @@ func AddFollower(db *sql.DB) http.HandlerFunc {
 			}
 
 			_, isOk2 := model.ConnectedWebSocket.Conn[follower.FollowedId] 
-			if isOk2 {
+			if isOk2 && !muted {
 				if err = model.ConnectedWebSocket.Conn[follower.FollowedId].WriteJSON(WebsocketMessage); err != nil {
 					nw.Error("Error during the communication with the websocket")
 					log.Printf("[%s] [AddFollower] Error during the communication with the websocket : %s", r.RemoteAddr, err)
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"slices"
+	"time"
 
 	model "social-network/Model"
 	utils "social-network/Utils"
@@ -50,7 +50,7 @@ func CreateGroup(db *sql.DB) http.HandlerFunc {
 		// Assign the decrypted LeaderId back to the group.
 		group.LeaderId = decryptAuthorId
 
-		// Initialize MemberIds with the LeaderId.
+		// The leader is the only member of a new group, the others join by request or invitation.
 		group.MemberIds = decryptAuthorId
 
 		// Validate that required fields are provided.
@@ -61,6 +61,23 @@ func CreateGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		// Without a visibility, the group keeps the join request flow.
+		if group.Visibility == "" {
+			group.Visibility = groupVisibilityPrivate
+		}
+
+		if !isValidGroupVisibility(group.Visibility) {
+			nw.Error("Invalid visibility")
+			log.Printf("[%s] [CreateGroup] Invalid visibility : %s", r.RemoteAddr, group.Visibility)
+			return
+		}
+
+		if group.Categories, err = normalizeGroupCategories(group.Categories); err != nil {
+			nw.Error(err.Error())
+			log.Printf("[%s] [CreateGroup] Invalid categories : %v", r.RemoteAddr, err)
+			return
+		}
+
 		// Generate a new UUID for the group.
 		uuid, err := uuid.NewV7()
 		if err != nil {
@@ -146,14 +163,6 @@ func LeaveGroup(db *sql.DB) http.HandlerFunc {
 		// Assign the decrypted UserId back to the datas struct.
 		datas.UserId = decryptAuthorId
 
-		var user model.Register
-		if err = user.SelectFromDb(db, map[string]any{"Id": datas.UserId}); err != nil {
-			// Return error if there is a problem during the database query.
-			nw.Error("Internal error: Problem during database query")
-			log.Printf("[%s] [LeaveGroup] %v", r.RemoteAddr, err)
-			return
-		}
-
 		var group model.Group
 		// Query the database for the group using the provided GroupId.
 		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
@@ -163,42 +172,21 @@ func LeaveGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		// Create a copy of the group data for modification.
-		DetailGroup := group
-
-		// Split the members into a manageable format.
-		user.SplitGroups()
-		DetailGroup.SplitMembers()
-
-		index := slices.Index(DetailGroup.SplitMemberIds, datas.UserId)
-		if index != -1 {
-			if index < len(DetailGroup.SplitMemberIds)-1 {
-				DetailGroup.SplitMemberIds = append(DetailGroup.SplitMemberIds[:index], DetailGroup.SplitMemberIds[index+1:]...)
-			} else {
-				DetailGroup.SplitMemberIds = DetailGroup.SplitMemberIds[:index]
-			}
-		}
-
-		index = slices.Index(user.SplitGroupsJoined, datas.GroupId)
-		if index != -1 {
-			if index < len(user.SplitGroupsJoined)-1 {
-				user.SplitGroupsJoined = append(user.SplitGroupsJoined[:index], user.SplitGroupsJoined[index+1:]...)
-			} else {
-				user.SplitGroupsJoined = user.SplitGroupsJoined[:index]
-			}
+		// Remove the membership, the leadership is given to the oldest moderator or member and the group is archived when it's empty.
+		member := model.GroupMember{GroupId: group.Id, UserId: datas.UserId}
+		groupEmpty, newLeaderId, err := member.Leave(db, time.Now().UTC().Format(scheduleDateFormat))
+		if err != nil {
+			// Return error if there is a problem during database update.
+			nw.Error("Internal error: Problem during database update : " + err.Error())
+			log.Printf("[%s] [LeaveGroup] %v", r.RemoteAddr, err)
+			return
 		}
 
-		if len(DetailGroup.SplitMemberIds) == 0 {
-			if err = group.DeleteFromDb(db, map[string]any{"Id": group.Id}); err != nil {
-				nw.Error("Internal error: Error during the delete of the group : " + err.Error())
-				log.Printf("[%s] [LeaveGroup] Error during the delete of the group : %v", r.RemoteAddr, err)
-				return
-			}
-
+		if groupEmpty {
 			w.Header().Set("Content-Type", "application/json")
 			err = json.NewEncoder(w).Encode(map[string]any{
 				"Success": true,
-				"Message": "Group delete successfully",
+				"Message": "Group archived successfully",
 			})
 			if err != nil {
 				// Log any error that occurs while encoding the response.
@@ -208,26 +196,13 @@ func LeaveGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		// Update the LeaderId to the first member's ID after a user leaves.
-		DetailGroup.LeaderId = DetailGroup.SplitMemberIds[0]
-
-		// Update the member list format.
-		user.JoinGroups()
-		DetailGroup.JoinMembers()
-
-		if err = user.UpdateDb(db, map[string]any{"GroupsJoined": user.GroupsJoined}, map[string]any{"Id": user.Id}); err != nil {
-			// Return error if there is a problem during database update.
-			nw.Error("Internal error: Problem during database update : " + err.Error())
-			log.Printf("[%s] [LeaveGroup] %v", r.RemoteAddr, err)
-			return
-		}
-
-		// Update the group's member list in the database.
-		if err = DetailGroup.UpdateDb(db, map[string]any{"LeaderId": DetailGroup.LeaderId, "MemberIds": DetailGroup.MemberIds}, map[string]any{"Id": DetailGroup.Id}); err != nil {
-			// Return error if there is a problem during database update.
-			nw.Error("Internal error: Problem during database update : " + err.Error())
-			log.Printf("[%s] [LeaveGroup] %v", r.RemoteAddr, err)
-			return
+		// The members of the group are told who manages the group now.
+		if newLeaderId != "" {
+			if err = notifyGroupRoleChange(db, group, newLeaderId, groupRoleLeader); err != nil {
+				nw.Error("There is a probleme during the sending of a notification")
+				log.Printf("[%s] [LeaveGroup] %v", r.RemoteAddr, err)
+				return
+			}
 		}
 
 		model.ConnectedWebSocket.Mu.Lock()
@@ -329,7 +304,40 @@ func GetGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		group.SplitMembers()
+		// A secret group doesn't exist for the users outside of it.
+		if !canSeeGroup(db, group, datas.UserId) {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [GetGroup] The user %s can't see the group %s", r.RemoteAddr, datas.UserId, group.Id)
+			return
+		}
+
+		if err = group.SelectCategories(db); err != nil {
+			nw.Error("Internal error: Problem during database query")
+			log.Printf("[%s] [GetGroup] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// The rules and the questions are shown to the users before they ask to join the group.
+		if err = group.SelectQuestions(db); err != nil {
+			nw.Error("Internal error: Problem during database query")
+			log.Printf("[%s] [GetGroup] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// The members with their role, to show the leader and the moderators.
+		members := model.GroupMembers{}
+		if group.Visibility == groupVisibilityPublic || IsGroupMember(group.Id, datas.UserId, db) {
+			group.SplitMembers()
+
+			if err = members.SelectFromDb(db, map[string]any{"GroupId": group.Id}); err != nil {
+				nw.Error("Internal error: Problem during database query")
+				log.Printf("[%s] [GetGroup] %v", r.RemoteAddr, err)
+				return
+			}
+		} else {
+			// A non-member only sees the name and the description of the group.
+			group = groupPreview(group)
+		}
 
 		// Set the response header to indicate JSON content and respond with the group data.
 		w.Header().Set("Content-Type", "application/json")
@@ -338,7 +346,8 @@ func GetGroup(db *sql.DB) http.HandlerFunc {
 			// Success message for retrieving the group.
 			"Message": "Group obtained successfully",
 			// The retrieved group data.
-			"Group": group,
+			"Group":   group,
+			"Members": members,
 		})
 		if err != nil {
 			// Log any error that occurs while encoding the response.
@@ -363,20 +372,23 @@ func GetAllGroups(db *sql.DB) http.HandlerFunc {
 		}
 
 		// Decrypt the OrganisatorId from the JWT to get the actual Organisator ID
-		_, err := utils.DecryptJWT(userJWT, db)
+		userId, err := utils.DecryptJWT(userJWT, db)
 		if err != nil {
 			nw.Error("Invalid JWT")
 			log.Printf("[%s] [GetAllGroups] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
 			return
 		}
 
-		var groups model.Groups
-		if err = groups.SelectFromDb(db, map[string]any{}); err != nil {
+		var allGroups model.Groups
+		if err = allGroups.SelectFromDb(db, map[string]any{}); err != nil {
 			nw.Error("Error during fetching the groups")
 			log.Printf("[%s] [GetAllGroups] Error during fetching the groups : %v", r.RemoteAddr, err)
 			return
 		}
 
+		// The secret groups are hidden and the private groups are reduced for the users outside of them.
+		groups := visibleGroups(db, allGroups, userId)
+
 		// Set the response header to indicate JSON content and respond with success message.
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
@@ -415,33 +427,33 @@ func GetGroupsJoined(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		var groups model.Groups
-		if err = groups.SelectFromDb(db, map[string]any{}); err != nil {
+		// The memberships of the user give the groups joined without reading all the groups.
+		var members model.GroupMembers
+		if err = members.SelectFromDb(db, map[string]any{"UserId": userId}); err != nil {
 			nw.Error("Error during fetching the groups")
-			log.Printf("[%s] [GetGroupsJoined] Error during fetching the groups : %v", r.RemoteAddr, err)
+			log.Printf("[%s] [GetGroupsJoined] Error during fetching the memberships : %v", r.RemoteAddr, err)
 			return
 		}
 
-		for i := 0; i < len(groups); i++ {
-			groups[i].SplitMembers()
-
-			if !slices.Contains(groups[i].SplitMemberIds, userId) {
-				if i < len(groups)-1 {
-					groups = append(groups[:i], groups[i+1:]...)
-				} else {
-					groups = groups[:i]
-				}
-				i--
-			} else {
-				var notifications model.Notifications
-				if err = notifications.SelectFromDb(db, map[string]any{"GroupId": groups[i].Id, "UserId": userId}); err != nil {
-					nw.Error("Error during fetching the groups notifications quantity")
-					log.Printf("[%s] [GetGroupsJoined] Error during fetching the groups notifications : %v", r.RemoteAddr, err)
-					return
-				}
+		groups := model.Groups{}
+		for _, member := range members {
+			var group model.Group
+			if err = group.SelectFromDb(db, map[string]any{"Id": member.GroupId}); err != nil {
+				nw.Error("Error during fetching the groups")
+				log.Printf("[%s] [GetGroupsJoined] Error during fetching the groups : %v", r.RemoteAddr, err)
+				return
+			}
+			group.SplitMembers()
 
-				groups[i].NotificationQuantity = len(notifications)
+			var notifications model.Notifications
+			if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "UserId": userId}); err != nil {
+				nw.Error("Error during fetching the groups notifications quantity")
+				log.Printf("[%s] [GetGroupsJoined] Error during fetching the groups notifications : %v", r.RemoteAddr, err)
+				return
 			}
+
+			group.NotificationQuantity = len(notifications)
+			groups = append(groups, group)
 		}
 
 		// Set the response header to indicate JSON content and respond with success message.
@@ -479,7 +491,7 @@ func GetGroupsPosts(db *sql.DB) http.HandlerFunc {
 		}
 
 		// Decrypt the OrganisatorId from the JWT to get the actual Organisator ID
-		_, err := utils.DecryptJWT(datas.UserId, db)
+		userId, err := utils.DecryptJWT(datas.UserId, db)
 		if err != nil {
 			nw.Error("Invalid JWT")
 			log.Printf("[%s] [GetGroupsPosts] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
@@ -492,6 +504,25 @@ func GetGroupsPosts(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		banned, err := isGroupBanned(db, datas.GroupId, userId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetGroupsPosts] Error during the fetch of the bans : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if banned {
+			nw.Error("You are banned from this group")
+			log.Printf("[%s] [GetGroupsPosts] The user %s is banned from the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
+		if !canSeeGroupContent(db, datas.GroupId, userId) {
+			nw.Error("You can't see the posts of this group")
+			log.Printf("[%s] [GetGroupsPosts] The user %s can't see the content of the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
 		var posts model.Posts
 		if err = posts.SelectFromDb(db, map[string]any{"IsGroup": datas.GroupId}); err != nil {
 			nw.Error("Error during the fetch of the DB")
@@ -499,12 +530,36 @@ func GetGroupsPosts(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		// The pinned posts are sent apart, in the order chosen by the leader of the group.
+		pinnedPosts, err := getVisiblePinnedPosts(db, datas.GroupId, userId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetGroupsPosts] Error during the fetch of the pinned posts: %v", r.RemoteAddr, err)
+			return
+		}
+
+		// The posts of the users with a block with the current user are hidden.
+		posts = removeBlockedPosts(db, posts, userId)
+		posts = removeOrphanReposts(posts)
+
+		// The announcements stay at the top of the posts until they are removed.
+		if err = sortAnnouncements(db, posts, userId); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetGroupsPosts] Error during the fetch of the acknowledgements: %v", r.RemoteAddr, err)
+			return
+		}
+
+		attachPostLinkPreviews(db, posts)
+		attachPostPolls(db, posts)
+		RecordPostViews(userId, posts)
+
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
 			"Success": true,
 			"Message": "Group's posts getted successfully",
 
-			"Posts": posts,
+			"Posts":       posts,
+			"PinnedPosts": pinnedPosts,
 		})
 		if err != nil {
 			// Log any error that occurs while encoding the response.
@@ -518,6 +573,7 @@ This function takes 1 argument:
   - a pointer to an SQL database object
 
 The purpose of this function is to handle user requests to delete a group from the database.
+Only the leader can delete the group, once it has been archived for the retention period.
 
 The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
 */
@@ -575,6 +631,28 @@ func DeleteGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		// The content of a group is only removed once the group has been archived for the retention period.
+		archivedAt, err := time.Parse(scheduleDateFormat, group.ArchivedAt)
+		if err != nil {
+			nw.Error("The group must be archived before its deletion")
+			log.Printf("[%s] [DeleteGroup] The group %s isn't archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		if time.Since(archivedAt) < groupArchiveRetention {
+			nw.Error(fmt.Sprintf("The group can only be deleted %d days after its archiving", int(groupArchiveRetention.Hours()/24)))
+			log.Printf("[%s] [DeleteGroup] The retention period of the group %s isn't over", r.RemoteAddr, group.Id)
+			return
+		}
+
+		// The files of the library are removed from the DB with the group, their content is removed from the store after.
+		var files model.GroupFiles
+		if err = files.SelectFromDb(db, map[string]any{"GroupId": group.Id}); err != nil {
+			nw.Error("Error during the select in the db")
+			log.Printf("[%s] [DeleteGroup] Error during the select of the files: %v", r.RemoteAddr, err)
+			return
+		}
+
 		// Delete the group from the database.
 		if err = group.DeleteFromDb(db, map[string]any{"Id": group.Id}); err != nil {
 			// Return error if there is a problem during the database delete operation.
@@ -583,6 +661,12 @@ func DeleteGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		for _, file := range files {
+			if err = groupMediaStore.Delete(file.Id); err != nil {
+				log.Printf("[%s] [DeleteGroup] Error during the remove of the content of the file %s : %v", r.RemoteAddr, file.Id, err)
+			}
+		}
+
 		// Set the response header to indicate JSON content and respond with success message.
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
@@ -631,94 +715,93 @@ func JoinGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		group.SplitMembers()
-		if slices.Contains(group.SplitMemberIds, datas.UserId) {
+		if IsGroupMember(group.Id, datas.UserId, db) {
 			nw.Error("You are already in the group")
 			log.Printf("[%s] [JoinGroup] This user is already in the group : %v", r.RemoteAddr, err)
 			return
 		}
 
-		if err = utils.IfNotExistsInDB("JoinGroupRequest", db, map[string]any{"UserId": datas.UserId, "GroupId": datas.GroupId}); err != nil {
-			nw.Error("You are already send a request to join this group")
-			log.Printf("[%s] [JoinGroup] You are already send a request to join this group : %v", r.RemoteAddr, err)
+		banned, err := isGroupBanned(db, group.Id, datas.UserId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [JoinGroup] Error during the fetch of the bans : %v", r.RemoteAddr, err)
 			return
 		}
 
-		if err = datas.InsertIntoDb(db); err != nil {
-			nw.Error("There is an error storing the query")
-			log.Printf("[%s] [JoinGroup] There is an error storing the query : %v", r.RemoteAddr, err)
+		if banned {
+			nw.Error("You are banned from this group")
+			log.Printf("[%s] [JoinGroup] The user %s is banned from the group %s", r.RemoteAddr, datas.UserId, group.Id)
 			return
 		}
 
-		notifId, err := uuid.NewV7()
-		if err != nil {
-			nw.Error("There is a problem with the generation of the uuid") // Handle UUID generation error
-			log.Printf("[%s] [JoinGroup] There is a problem with the generation of the uuid : %s", r.RemoteAddr, err)
+		if !canSeeGroup(db, group, datas.UserId) {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [JoinGroup] The user %s can't see the group %s", r.RemoteAddr, datas.UserId, group.Id)
 			return
 		}
 
-		var userData model.Register
-		if err = userData.SelectFromDb(db, map[string]any{"Id": datas.UserId}); err != nil {
-			nw.Error("There is a problem during the fetching of the user") // Handle UUID generation error
-			log.Printf("[%s] [JoinGroup] There is a problem during the fetching of the user : %s", r.RemoteAddr, err)
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [JoinGroup] The group %s is archived", r.RemoteAddr, group.Id)
 			return
 		}
 
-		var userDataName string
-		if userData.Username == "" {
-			userDataName = userData.FirstName + " " + userData.LastName
-		} else {
-			userDataName = userData.Username
+		if group.Visibility == groupVisibilitySecret {
+			nw.Error("This group can only be joined by invitation")
+			log.Printf("[%s] [JoinGroup] The group %s is secret", r.RemoteAddr, group.Id)
+			return
 		}
 
-		notification := model.Notification{
-			Id:          notifId.String(),
-			UserId:      group.LeaderId,
-			Status:      "Group",
-			Description: fmt.Sprintf("An join request as been send to join the group \"%s\" by %s", group.GroupName, userDataName),
-			GroupId:     group.Id,
-			OtherUserId: "",
-		}
+		// A public group is joined without a request.
+		if group.Visibility == groupVisibilityPublic {
+			member := model.GroupMember{GroupId: group.Id, UserId: datas.UserId}
+			if err = member.InsertIntoDb(db); err != nil {
+				nw.Error("There is an error storing the query")
+				log.Printf("[%s] [JoinGroup] There is an error storing the membership : %v", r.RemoteAddr, err)
+				return
+			}
 
-		if err = notification.InsertIntoDb(db); err != nil {
-			nw.Error("There is a probleme during the sending of a notification")
-			log.Printf("[%s] [JoinGroup] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
+			w.Header().Set("Content-Type", "application/json")
+			err = json.NewEncoder(w).Encode(map[string]any{
+				"Success": true,
+				"Message": "Group joined successfully",
+			})
+			if err != nil {
+				log.Printf("[%s] [JoinGroup] %s", r.RemoteAddr, err.Error())
+			}
 			return
 		}
 
-		model.ConnectedWebSocket.Mu.Lock()
-		_, isOk := model.ConnectedWebSocket.Conn[group.LeaderId]
-		if isOk {
-			var WebsocketMessage struct {
-				Type        string
-				GroupId     string
-				Description string
-				Value       model.Group
-				JoinRequest model.JoinGroupRequest
-			}
-
-			WebsocketMessage.Type = "JoinGroup"
-			WebsocketMessage.GroupId = group.Id
-			WebsocketMessage.Description = "A join request has been send to your group"
-			WebsocketMessage.Value = group
+		if err = utils.IfNotExistsInDB("JoinGroupRequest", db, map[string]any{"UserId": datas.UserId, "GroupId": datas.GroupId}); err != nil {
+			nw.Error("You are already send a request to join this group")
+			log.Printf("[%s] [JoinGroup] You are already send a request to join this group : %v", r.RemoteAddr, err)
+			return
+		}
 
-			var tmp model.JoinGroupRequests
-			if err = tmp.SelectFromDb(db, map[string]any{"UserId": datas.UserId, "GroupId": datas.GroupId}); err != nil {
-				nw.Error("Error during the fetch of the request")
-				log.Printf("[%s] [JoinGroup] Error during the fetch of the request : %s", r.RemoteAddr, err)
-				return
-			}
+		// The answers to the questions of the group are saved with the request, for the moderators.
+		if err = group.SelectQuestions(db); err != nil {
+			nw.Error("There is a problem during the fetch of the DB")
+			log.Printf("[%s] [JoinGroup] %v", r.RemoteAddr, err)
+			return
+		}
 
-			WebsocketMessage.JoinRequest = tmp[0]
+		if datas.Answers, err = prepareJoinAnswers(group, datas.Answers); err != nil {
+			nw.Error(err.Error())
+			log.Printf("[%s] [JoinGroup] Invalid answers : %v", r.RemoteAddr, err)
+			return
+		}
 
-			if err = model.ConnectedWebSocket.Conn[group.LeaderId].WriteJSON(WebsocketMessage); err != nil {
+		if err = datas.InsertIntoDb(db); err != nil {
+			nw.Error("There is an error storing the query")
+			log.Printf("[%s] [JoinGroup] There is an error storing the query : %v", r.RemoteAddr, err)
+			return
+		}
 
-				nw.Error("Error during the communication with the websocket")
-				log.Printf("[%s] [JoinGroup] Error during the communication with the websocket : %s", r.RemoteAddr, err)
-				return
-			}
+		if err = notifyJoinRequest(db, group, datas.UserId); err != nil {
+			nw.Error("There is a probleme during the sending of a notification")
+			log.Printf("[%s] [JoinGroup] %v", r.RemoteAddr, err)
+			return
 		}
-		model.ConnectedWebSocket.Mu.Unlock()
 
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
@@ -758,9 +841,9 @@ func GetJoinRequest(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		if err = utils.IfExistsInDB("Groups", db, map[string]any{"Id": datas.GroupId, "LeaderId": datas.UserId}); err != nil {
-			nw.Error("The current user isn't the leader of this goup")
-			log.Printf("[%s] [GetJoinRequest] The current user isn't the leader of this goup : %v", r.RemoteAddr, err)
+		if !isGroupModerator(db, datas.GroupId, datas.UserId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [GetJoinRequest] The current user isn't the leader or a moderator of this goup", r.RemoteAddr)
 			return
 		}
 
@@ -771,6 +854,15 @@ func GetJoinRequest(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		// The answers to the questions of the group, to review the requests.
+		for i := range requests {
+			if err = requests[i].Answers.SelectFromDb(db, map[string]any{"GroupId": datas.GroupId, "UserId": requests[i].UserId}); err != nil {
+				nw.Error("There is an error during the fetching of the DB")
+				log.Printf("[%s] [GetJoinRequest] There is an error during the fetching of the answers : %v", r.RemoteAddr, err)
+				return
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
 			"Success": true,
@@ -857,9 +949,9 @@ func DeclineJoinRequest(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		if err = utils.IfExistsInDB("Groups", db, map[string]any{"Id": datas.GroupId, "LeaderId": datas.UserId}); err != nil {
-			nw.Error("The current user isn't the leader of this goup")
-			log.Printf("[%s] [DeclineJoinRequest] The current user isn't the leader of this goup : %v", r.RemoteAddr, err)
+		if !isGroupModerator(db, datas.GroupId, datas.UserId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [DeclineJoinRequest] The current user isn't the leader or a moderator of this goup", r.RemoteAddr)
 			return
 		}
 
@@ -942,15 +1034,34 @@ func AcceptJoinRequest(db *sql.DB) http.HandlerFunc {
 		}
 
 		var group model.Group
-		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId, "LeaderId": datas.UserId}); err != nil {
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
 			nw.Error("There is an error during the fetch of the group data")
 			log.Printf("[%s] [AcceptJoinRequest] There is an error during the fetch of the group data : %v", r.RemoteAddr, err)
 			return
 		}
 
-		if group.GroupName == "" {
-			nw.Error("The current user isn't the leader of this goup")
-			log.Printf("[%s] [AcceptJoinRequest] The current user isn't the leader of this goup", r.RemoteAddr)
+		if !isGroupModerator(db, group.Id, datas.UserId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [AcceptJoinRequest] The current user isn't the leader or a moderator of this goup", r.RemoteAddr)
+			return
+		}
+
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [AcceptJoinRequest] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		banned, err := isGroupBanned(db, group.Id, datas.JoinUserId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [AcceptJoinRequest] Error during the fetch of the bans : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if banned {
+			nw.Error("This user is banned from the group")
+			log.Printf("[%s] [AcceptJoinRequest] The user %s is banned from the group %s", r.RemoteAddr, datas.JoinUserId, group.Id)
 			return
 		}
 
@@ -960,22 +1071,14 @@ func AcceptJoinRequest(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		group.SplitMembers()
-		group.SplitMemberIds = append(group.SplitMemberIds, datas.JoinUserId)
-		group.JoinMembers()
-
-		if err = group.UpdateDb(db, map[string]any{"MemberIds": group.MemberIds}, map[string]any{"Id": group.Id}); err != nil {
+		// The member is added and the request deleted in the same transaction.
+		member := model.GroupMember{GroupId: group.Id, UserId: datas.JoinUserId}
+		if err = member.InsertFromRequest(db, "JoinGroupRequest", "UserId"); err != nil {
 			nw.Error("There is an error during the update of the group data")
 			log.Printf("[%s] [AcceptJoinRequest] There is an error during the update of the group data : %s", r.RemoteAddr, err)
 			return
 		}
 
-		if err = model.RemoveFromDB("JoinGroupRequest", db, map[string]any{"UserId": datas.JoinUserId, "GroupId": datas.GroupId}); err != nil {
-			nw.Error("There is an error during the delete of the request")
-			log.Printf("[%s] [AcceptJoinRequest] There is an error during the delete of the request : %s", r.RemoteAddr, err)
-			return
-		}
-
 		model.ConnectedWebSocket.Mu.Lock()
 		_, isOk := model.ConnectedWebSocket.Conn[datas.JoinUserId]
 		if isOk {
@@ -1043,19 +1146,43 @@ func InviteGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		group.SplitMembers()
-		if slices.Contains(group.SplitMemberIds, datas.ReceiverId) {
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [InviteGroup] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		if IsGroupMember(group.Id, datas.ReceiverId, db) {
 			nw.Error("This user is already in the group")
 			log.Printf("[%s] [InviteGroup] This user is already in the group : %v", r.RemoteAddr, err)
 			return
 		}
 
+		banned, err := isGroupBanned(db, group.Id, datas.ReceiverId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [InviteGroup] Error during the fetch of the bans : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if banned {
+			nw.Error("This user is banned from the group")
+			log.Printf("[%s] [InviteGroup] The user %s is banned from the group %s", r.RemoteAddr, datas.ReceiverId, group.Id)
+			return
+		}
+
 		if err = utils.IfExistsInDB("UserInfo", db, map[string]any{"Id": datas.ReceiverId}); err != nil {
 			nw.Error("There is no user with this id")
 			log.Printf("[%s] [InviteGroup] There is no user with the id %s : %v", r.RemoteAddr, datas.ReceiverId, err)
 			return
 		}
 
+		if isBlocked(db, datas.SenderId, datas.ReceiverId) {
+			nw.Error("You can't invite this user")
+			log.Printf("[%s] [InviteGroup] There is a block between the users %s and %s", r.RemoteAddr, datas.SenderId, datas.ReceiverId)
+			return
+		}
+
 		if err = utils.IfNotExistsInDB("InviteGroupRequest", db, map[string]any{"GroupId": datas.GroupId, "ReceiverId": datas.ReceiverId}); err != nil {
 			nw.Error("This user has already receive an invitation")
 			log.Printf("[%s] [InviteGroup] This user has already receive an invitation %s : %v", r.RemoteAddr, datas.ReceiverId, err)
@@ -1244,8 +1371,7 @@ func GetInvitationUserInGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		group.SplitMembers()
-		if !slices.Contains(group.SplitMemberIds, datas.UserId) {
+		if !IsGroupMember(group.Id, datas.UserId, db) {
 			nw.Error("You are not in this group")
 			log.Printf("[%s] [GetInvitationUserInGroup] You are not in this group", r.RemoteAddr)
 			return
@@ -1354,53 +1480,51 @@ func AcceptInvitationGroup(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [AcceptInvitationGroup] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
 		if err = utils.IfExistsInDB("InviteGroupRequest", db, map[string]any{"GroupId": datas.GroupId, "ReceiverId": datas.ReceiverId}); err != nil {
 			nw.Error("This user hasn't received any invitation for this group")
 			log.Printf("[%s] [AcceptInvitationGroup] This user hasn't received any invitation for this group %s : %v", r.RemoteAddr, datas.ReceiverId, err)
 			return
 		}
 
-		group.SplitMembers()
-		group.SplitMemberIds = append(group.SplitMemberIds, datas.ReceiverId)
-		group.JoinMembers()
+		banned, err := isGroupBanned(db, group.Id, datas.ReceiverId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [AcceptInvitationGroup] Error during the fetch of the bans : %v", r.RemoteAddr, err)
+			return
+		}
 
-		if err = group.UpdateDb(db, map[string]any{"MemberIds": group.MemberIds}, map[string]any{"Id": group.Id}); err != nil {
+		if banned {
+			nw.Error("You are banned from this group")
+			log.Printf("[%s] [AcceptInvitationGroup] The user %s is banned from the group %s", r.RemoteAddr, datas.ReceiverId, group.Id)
+			return
+		}
+
+		// The member is added and the invitation deleted in the same transaction.
+		member := model.GroupMember{GroupId: group.Id, UserId: datas.ReceiverId}
+		if err = member.InsertFromRequest(db, "InviteGroupRequest", "ReceiverId"); err != nil {
 			nw.Error("There is an error during the update of the group's data")
 			log.Printf("[%s] [AcceptInvitationGroup] There is an error during the update of the group's data : %s", r.RemoteAddr, err)
 			return
 		}
 
-		if err = model.RemoveFromDB("InviteGroupRequest", db, map[string]any{"GroupId": datas.GroupId, "ReceiverId": datas.ReceiverId}); err != nil {
-			nw.Error("There is an error during the delete of the invitation")
-			log.Printf("[%s] [AcceptInvitationGroup] There is an error during the delete of the invitation : %s", r.RemoteAddr, err)
+		// The group is fetched again to send the members list with the new member.
+		if err = group.SelectFromDb(db, map[string]any{"Id": group.Id}); err != nil {
+			nw.Error("There is a problem during the fetch of the DB")
+			log.Printf("[%s] [AcceptInvitationGroup] There is a problem during the fetch of the DB : %v", r.RemoteAddr, err)
 			return
 		}
 
-		model.ConnectedWebSocket.Mu.Lock()
-		for i := range group.SplitMemberIds {
-			_, isOk := model.ConnectedWebSocket.Conn[group.SplitMemberIds[i]]
-			if isOk {
-				var WebsocketMessage struct {
-					Type        string
-					GroupId     string
-					Description string
-					Value       model.Group
-				}
-
-				WebsocketMessage.Type = "AcceptInviteGroup"
-				WebsocketMessage.GroupId = group.Id
-				WebsocketMessage.Description = "A invite request has been accepted"
-				WebsocketMessage.Value = group
-
-				if err = model.ConnectedWebSocket.Conn[group.SplitMemberIds[i]].WriteJSON(WebsocketMessage); err != nil {
-
-					nw.Error("Error during the communication with the websocket")
-					log.Printf("[%s] [AcceptInvitationGroup] Error during the communication with the websocket : %s", r.RemoteAddr, err)
-					return
-				}
-			}
+		if err = notifyInvitationAccepted(group); err != nil {
+			nw.Error("Error during the communication with the websocket")
+			log.Printf("[%s] [AcceptInvitationGroup] %v", r.RemoteAddr, err)
+			return
 		}
-		model.ConnectedWebSocket.Mu.Unlock()
 
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(map[string]any{
@@ -1412,3 +1536,136 @@ func AcceptInvitationGroup(db *sql.DB) http.HandlerFunc {
 		}
 	}
 }
+
+/*
+This function takes 3 arguments:
+  - a string containing the id of the group
+  - a string containing the id of the user
+  - a pointer to an SQL database object
+
+The purpose of this function is to check if the user is the leader or a member of the group.
+
+The membership is read from the "GroupMember" table, where the leader is also a member.
+
+The function returns true if the user is in the group, false otherwise (or if the query fails).
+*/
+func IsGroupMember(groupId, userId string, db *sql.DB) bool {
+	return utils.IfExistsInDB("GroupMember", db, map[string]any{"GroupId": groupId, "UserId": userId}) == nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Group the user asked to join
+  - a string containing the id of the user who asked to join
+
+The purpose of this function is to send a notification and, if connected, a JoinGroup websocket message
+with the join request to the leader of the group.
+
+The function returns an error if the user or the request can't be fetched, or if the notification can't be sent.
+*/
+func notifyJoinRequest(db *sql.DB, group model.Group, userId string) error {
+	notifId, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("there is a problem with the generation of the uuid : %v", err)
+	}
+
+	var userData model.Register
+	if err = userData.SelectFromDb(db, map[string]any{"Id": userId}); err != nil {
+		return fmt.Errorf("there is a problem during the fetching of the user : %v", err)
+	}
+
+	var userDataName string
+	if userData.Username == "" {
+		userDataName = userData.FirstName + " " + userData.LastName
+	} else {
+		userDataName = userData.Username
+	}
+
+	notification := model.Notification{
+		Id:          notifId.String(),
+		UserId:      group.LeaderId,
+		Status:      "Group",
+		Description: fmt.Sprintf("An join request as been send to join the group \"%s\" by %s", group.GroupName, userDataName),
+		GroupId:     group.Id,
+		OtherUserId: "",
+	}
+
+	if err = notification.InsertIntoDb(db); err != nil {
+		return fmt.Errorf("there is a probleme during the sending of a notification : %v", err)
+	}
+
+	var requests model.JoinGroupRequests
+	if err = requests.SelectFromDb(db, map[string]any{"UserId": userId, "GroupId": group.Id}); err != nil || len(requests) == 0 {
+		return fmt.Errorf("error during the fetch of the request : %v", err)
+	}
+
+	model.ConnectedWebSocket.Mu.Lock()
+	defer model.ConnectedWebSocket.Mu.Unlock()
+
+	conn, isOk := model.ConnectedWebSocket.Conn[group.LeaderId]
+	if !isOk {
+		return nil
+	}
+
+	var WebsocketMessage struct {
+		Type        string
+		GroupId     string
+		Description string
+		Value       model.Group
+		JoinRequest model.JoinGroupRequest
+	}
+
+	WebsocketMessage.Type = "JoinGroup"
+	WebsocketMessage.GroupId = group.Id
+	WebsocketMessage.Description = "A join request has been send to your group"
+	WebsocketMessage.Value = group
+	WebsocketMessage.JoinRequest = requests[0]
+
+	if err = conn.WriteJSON(WebsocketMessage); err != nil {
+		return fmt.Errorf("error during the communication with the websocket : %v", err)
+	}
+
+	return nil
+}
+
+/*
+This function takes 1 argument:
+  - the Group joined, fetched again to contain its new member
+
+The purpose of this function is to send an AcceptInviteGroup websocket message with the group to each connected member,
+when a user joins the group with an invitation or an invite link.
+
+The function returns an error if the communication with a websocket fails.
+*/
+func notifyInvitationAccepted(group model.Group) error {
+	group.SplitMembers()
+
+	model.ConnectedWebSocket.Mu.Lock()
+	defer model.ConnectedWebSocket.Mu.Unlock()
+
+	for _, memberId := range group.SplitMemberIds {
+		conn, isOk := model.ConnectedWebSocket.Conn[memberId]
+		if !isOk {
+			continue
+		}
+
+		var WebsocketMessage struct {
+			Type        string
+			GroupId     string
+			Description string
+			Value       model.Group
+		}
+
+		WebsocketMessage.Type = "AcceptInviteGroup"
+		WebsocketMessage.GroupId = group.Id
+		WebsocketMessage.Description = "A invite request has been accepted"
+		WebsocketMessage.Value = group
+
+		if err := conn.WriteJSON(WebsocketMessage); err != nil {
+			return fmt.Errorf("error during the communication with the websocket : %v", err)
+		}
+	}
+
+	return nil
+}
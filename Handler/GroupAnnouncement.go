@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - the Post to publish
+
+The purpose of this function is to check that an announcement can be published:
+it must be a post of a group, written by the leader or a moderator of the group.
+
+The function returns an error if the announcement can't be published, nil otherwise (or if the post isn't an announcement).
+*/
+func checkAnnouncement(db *sql.DB, post model.Post) error {
+	if !post.IsAnnouncement {
+		return nil
+	}
+
+	if post.IsGroup == "" {
+		return errors.New("an announcement must be published in a group")
+	}
+
+	if !isGroupModerator(db, post.IsGroup, post.AuthorId) {
+		return errors.New("only the leader and the moderators can publish an announcement")
+	}
+
+	return nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Posts of a group
+  - a string containing the id of the reader
+
+The purpose of this function is to put the announcements at the top of the posts of a group, keeping the order of the other posts,
+and to mark the announcements the reader has already acknowledged.
+
+The function returns an error if the acknowledgements can't be fetched.
+*/
+func sortAnnouncements(db *sql.DB, posts model.Posts, userId string) error {
+	var acks model.AnnouncementAcks
+	if err := acks.SelectFromDb(db, map[string]any{"UserId": userId}); err != nil {
+		return err
+	}
+
+	for i := range posts {
+		if !posts[i].IsAnnouncement {
+			continue
+		}
+
+		posts[i].Acknowledged = slices.ContainsFunc(acks, func(ack model.AnnouncementAck) bool {
+			return ack.PostId == posts[i].Id
+		})
+	}
+
+	slices.SortStableFunc(posts, func(a, b model.Post) int {
+		switch {
+		case a.IsAnnouncement == b.IsAnnouncement:
+			return 0
+		case a.IsAnnouncement:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	return nil
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the acknowledgement of an announcement by a member of its group.
+An announcement is acknowledged only once.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func AcknowledgeAnnouncement(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			PostId string `json:"PostId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [AcknowledgeAnnouncement] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [AcknowledgeAnnouncement] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": datas.PostId}); err != nil || !post.IsAnnouncement {
+			nw.Error("There is no announcement with this id")
+			log.Printf("[%s] [AcknowledgeAnnouncement] There is no announcement with the id %s : %v", r.RemoteAddr, datas.PostId, err)
+			return
+		}
+
+		if !IsGroupMember(post.IsGroup, userId, db) {
+			nw.Error("Only the members of the group can acknowledge an announcement")
+			log.Printf("[%s] [AcknowledgeAnnouncement] The user %s isn't a member of the group %s", r.RemoteAddr, userId, post.IsGroup)
+			return
+		}
+
+		if isGroupArchived(db, post.IsGroup) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [AcknowledgeAnnouncement] The group %s is archived", r.RemoteAddr, post.IsGroup)
+			return
+		}
+
+		if err = utils.IfNotExistsInDB("AnnouncementAck", db, map[string]any{"PostId": post.Id, "UserId": userId}); err != nil {
+			nw.Error("You have already acknowledged this announcement")
+			log.Printf("[%s] [AcknowledgeAnnouncement] The user %s has already acknowledged the announcement %s", r.RemoteAddr, userId, post.Id)
+			return
+		}
+
+		ack := model.AnnouncementAck{
+			PostId:       post.Id,
+			UserId:       userId,
+			CreationDate: time.Now().UTC().Format(scheduleDateFormat),
+		}
+
+		if err = ack.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB")
+			log.Printf("[%s] [AcknowledgeAnnouncement] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Announcement acknowledged successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [AcknowledgeAnnouncement] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the follow-up of an announcement by the leader and the moderators of its group:
+the acknowledgements already received, and the members who haven't acknowledged it yet (its author excepted).
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetAnnouncementAcks(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			PostId string `json:"PostId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetAnnouncementAcks] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetAnnouncementAcks] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": datas.PostId}); err != nil || !post.IsAnnouncement {
+			nw.Error("There is no announcement with this id")
+			log.Printf("[%s] [GetAnnouncementAcks] There is no announcement with the id %s : %v", r.RemoteAddr, datas.PostId, err)
+			return
+		}
+
+		if !isGroupModerator(db, post.IsGroup, userId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [GetAnnouncementAcks] The user %s isn't the leader or a moderator of the group %s", r.RemoteAddr, userId, post.IsGroup)
+			return
+		}
+
+		var acks model.AnnouncementAcks
+		if err = acks.SelectFromDb(db, map[string]any{"PostId": post.Id}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetAnnouncementAcks] Error during the fetch of the acknowledgements : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var members model.GroupMembers
+		if err = members.SelectFromDb(db, map[string]any{"GroupId": post.IsGroup}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetAnnouncementAcks] Error during the fetch of the members : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// The members who left the group keep their acknowledgement, but only the current members are waited for.
+		pending := model.GroupMembers{}
+		for _, member := range members {
+			acknowledged := slices.ContainsFunc(acks, func(ack model.AnnouncementAck) bool {
+				return ack.UserId == member.UserId
+			})
+
+			if member.UserId != post.AuthorId && !acknowledged {
+				pending = append(pending, member)
+			}
+		}
+
+		// Set the response header to indicate JSON content and respond with the acknowledgements.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success":      true,
+			"Message":      "Announcement acknowledgements getted successfully",
+			"Acknowledged": acks,
+			"Pending":      pending,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetAnnouncementAcks] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
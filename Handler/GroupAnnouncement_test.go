@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestGroupAnnouncement(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "firstId", "secondId"} {
+		CreateTestUser(t, db, id)
+	}
+	leaderJwt := utils.GenerateJWT("leaderId")
+	firstJwt := utils.GenerateJWT("firstId")
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | firstId | secondId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	post := model.Post{Id: "postId", AuthorId: "firstId", Text: "text", CreationDate: "now", Status: "public", IsGroup: group.Id}
+	if err = post.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, CreatePost(db), map[string]any{"AuthorId": firstJwt, "Text": "announcement", "CreationDate": "now", "Status": "public", "IsGroup": group.Id, "IsAnnouncement": true}); success {
+		t.Fatal("A member can't publish an announcement")
+	}
+
+	if success, _ := TryRequest(t, CreatePost(db), map[string]any{"AuthorId": leaderJwt, "Text": "announcement", "CreationDate": "now", "Status": "public", "IsAnnouncement": true}); success {
+		t.Fatal("An announcement must be published in a group")
+	}
+
+	success, rr := TryRequest(t, CreatePost(db), map[string]any{"AuthorId": leaderJwt, "Text": "announcement", "CreationDate": "now", "Status": "public", "IsGroup": group.Id, "IsAnnouncement": true})
+	if !success {
+		t.Fatalf("The leader should publish an announcement : %s", rr.Body.String())
+	}
+
+	var created struct {
+		IdPost string
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if success, rr := TryRequest(t, AcknowledgeAnnouncement(db), map[string]any{"UserId": firstJwt, "PostId": post.Id}); success {
+		t.Fatalf("A post which isn't an announcement can't be acknowledged : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, AcknowledgeAnnouncement(db), map[string]any{"UserId": firstJwt, "PostId": created.IdPost}); !success {
+		t.Fatalf("A member should acknowledge the announcement : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, AcknowledgeAnnouncement(db), map[string]any{"UserId": firstJwt, "PostId": created.IdPost}); success {
+		t.Fatal("An announcement is acknowledged only once")
+	}
+
+	// The announcement is at the top of the posts, acknowledged by the reader
+	_, rr = TryRequest(t, GetGroupsPosts(db), map[string]any{"UserId": firstJwt, "GroupId": group.Id})
+	var postsValue struct {
+		Posts model.Posts
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &postsValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(postsValue.Posts) != 2 || postsValue.Posts[0].Id != created.IdPost || !postsValue.Posts[0].Acknowledged {
+		t.Fatalf("The announcement should be at the top of the posts : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, GetAnnouncementAcks(db), map[string]any{"UserId": firstJwt, "PostId": created.IdPost}); success {
+		t.Fatal("A member can't see the acknowledgements")
+	}
+
+	_, rr = TryRequest(t, GetAnnouncementAcks(db), map[string]any{"UserId": leaderJwt, "PostId": created.IdPost})
+	var acksValue struct {
+		Acknowledged model.AnnouncementAcks
+		Pending      model.GroupMembers
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &acksValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(acksValue.Acknowledged) != 1 || acksValue.Acknowledged[0].UserId != "firstId" || len(acksValue.Pending) != 1 || acksValue.Pending[0].UserId != "secondId" {
+		t.Fatalf("Only the second member should be waited for : %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// An archived group can only be deleted once it has been archived for this duration.
+const groupArchiveRetention = 30 * 24 * time.Hour
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the group
+
+The purpose of this function is to check if a group is archived, an archived group is read-only:
+nothing can be posted, written or joined in it until its leader unarchives it.
+
+The function returns true if the group is archived, false otherwise (or if the group doesn't exist).
+*/
+func isGroupArchived(db *sql.DB, groupId string) bool {
+	var group model.Group
+	if err := group.SelectFromDb(db, map[string]any{"Id": groupId}); err != nil {
+		return false
+	}
+
+	return group.ArchivedAt != ""
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the archiving of a group by its leader. The content of the group is kept
+and stays visible to its members, but the group becomes read-only and is hidden from the other users.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func ArchiveGroup(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [ArchiveGroup] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [ArchiveGroup] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [ArchiveGroup] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if group.LeaderId != userId {
+			nw.Error("Only the leader can archive the group")
+			log.Printf("[%s] [ArchiveGroup] The user %s isn't the leader of the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if group.ArchivedAt != "" {
+			nw.Error("The group is already archived")
+			log.Printf("[%s] [ArchiveGroup] The group %s is already archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		archivedAt := time.Now().UTC().Format(scheduleDateFormat)
+
+		if err = group.UpdateDb(db, map[string]any{"ArchivedAt": archivedAt}, map[string]any{"Id": group.Id}); err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [ArchiveGroup] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success":    true,
+			"Message":    "Group archived successfully",
+			"ArchivedAt": archivedAt,
+		})
+		if err != nil {
+			log.Printf("[%s] [ArchiveGroup] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the unarchiving of a group by its leader, the group becomes active again.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UnarchiveGroup(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [UnarchiveGroup] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UnarchiveGroup] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [UnarchiveGroup] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if group.LeaderId != userId {
+			nw.Error("Only the leader can unarchive the group")
+			log.Printf("[%s] [UnarchiveGroup] The user %s isn't the leader of the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if group.ArchivedAt == "" {
+			nw.Error("The group isn't archived")
+			log.Printf("[%s] [UnarchiveGroup] The group %s isn't archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		// A group left by all its members stays archived until its deletion.
+		if !IsGroupMember(group.Id, userId, db) {
+			nw.Error("A group without members can't be unarchived")
+			log.Printf("[%s] [UnarchiveGroup] The group %s has no members", r.RemoteAddr, group.Id)
+			return
+		}
+
+		if err = group.UpdateDb(db, map[string]any{"ArchivedAt": ""}, map[string]any{"Id": group.Id}); err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [UnarchiveGroup] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group unarchived successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [UnarchiveGroup] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"strings"
+	"testing"
+)
+
+func TestGroupArchive(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "memberId", "outsiderId"} {
+		CreateTestUser(t, db, id)
+	}
+	leaderJwt := utils.GenerateJWT("leaderId")
+	memberJwt := utils.GenerateJWT("memberId")
+	outsiderJwt := utils.GenerateJWT("outsiderId")
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | memberId", GroupName: "group", CreationDate: "now", Visibility: groupVisibilityPublic}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, DeleteGroup(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id}); success {
+		t.Fatal("An active group can't be deleted")
+	}
+
+	if success, _ := TryRequest(t, ArchiveGroup(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id}); success {
+		t.Fatal("Only the leader can archive the group")
+	}
+
+	if success, rr := TryRequest(t, ArchiveGroup(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id}); !success {
+		t.Fatalf("The leader should archive the group : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, ArchiveGroup(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id}); success {
+		t.Fatal("The group is already archived")
+	}
+
+	// The archived group is read-only
+	if success, rr := TryRequest(t, CreatePost(db), map[string]any{"AuthorId": memberJwt, "Text": "post", "CreationDate": "now", "Status": "public", "IsGroup": group.Id}); success || !strings.Contains(rr.Body.String(), "archived") {
+		t.Fatalf("A post can't be created in an archived group : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, AddMessage(db), map[string]any{"SenderId": memberJwt, "GroupId": group.Id, "Message": "message"}); success || !strings.Contains(rr.Body.String(), "archived") {
+		t.Fatalf("A message can't be sent in an archived group : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, CreateEvent(db), map[string]any{"OrganisatorId": leaderJwt, "GroupId": group.Id, "Title": "title", "Description": "description", "DateOfTheEvent": "2030-01-01"}); success || !strings.Contains(rr.Body.String(), "archived") {
+		t.Fatalf("An event can't be created in an archived group : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, JoinGroup(db), map[string]any{"UserId": outsiderJwt, "GroupId": group.Id}); success || IsGroupMember(group.Id, "outsiderId", db) {
+		t.Fatal("An archived group can't be joined")
+	}
+
+	// The archived group stays visible to its members only
+	if success, rr := TryRequest(t, GetGroup(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id}); !success {
+		t.Fatalf("A member should still see the archived group : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, GetGroup(db), map[string]any{"UserId": outsiderJwt, "GroupId": group.Id}); success {
+		t.Fatal("A non-member can't see an archived group")
+	}
+
+	if success, _ := TryRequest(t, DeleteGroup(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id}); success {
+		t.Fatal("The group can't be deleted before the end of the retention period")
+	}
+
+	if success, _ := TryRequest(t, UnarchiveGroup(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id}); success {
+		t.Fatal("Only the leader can unarchive the group")
+	}
+
+	if success, rr := TryRequest(t, UnarchiveGroup(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id}); !success {
+		t.Fatalf("The leader should unarchive the group : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, CreatePost(db), map[string]any{"AuthorId": memberJwt, "Text": "post", "CreationDate": "now", "Status": "public", "IsGroup": group.Id}); !success {
+		t.Fatalf("A post should be created once the group is unarchived : %s", rr.Body.String())
+	}
+
+	// Once the retention period is over, the archived group can be deleted
+	if err = group.UpdateDb(db, map[string]any{"ArchivedAt": "2000-01-01 00:00"}, map[string]any{"Id": group.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, DeleteGroup(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id}); !success {
+		t.Fatalf("The leader should delete the group after the retention period : %s", rr.Body.String())
+	}
+
+	if utils.IfNotExistsInDB("Groups", db, map[string]any{"Id": group.Id}) != nil {
+		t.Fatal("The group should be removed")
+	}
+}
+
+func TestArchivedGroupWrites(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "memberId"} {
+		CreateTestUser(t, db, id)
+	}
+	leaderJwt := utils.GenerateJWT("leaderId")
+	memberJwt := utils.GenerateJWT("memberId")
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | memberId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	// The content of the group is created before its archiving
+	for _, post := range []model.Post{
+		{Id: "announcementId", AuthorId: "leaderId", Text: "announcement", CreationDate: "now", Status: "public", IsGroup: group.Id, IsAnnouncement: true},
+		{Id: "pinnedPostId", AuthorId: "memberId", Text: "text", CreationDate: "now", Status: "public", IsGroup: group.Id},
+	} {
+		if err = post.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	poll := model.Poll{Id: "pollId", PostId: "announcementId", Options: model.PollOptions{{Id: "first", Text: "first"}, {Id: "second", Position: 1, Text: "second"}}}
+	if err = poll.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = (&model.PinnedPost{PostId: "pinnedPostId", OwnerId: group.Id}).InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	event := model.Event{Id: "eventId", GroupId: group.Id, OrganisatorId: "leaderId", Title: "event", Description: "description", DateOfTheEvent: "2030-01-01"}
+	if err = event.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	file := model.GroupFile{Id: "fileId", GroupId: group.Id, UploaderId: "memberId", Name: "slides.pdf", MimeType: "application/pdf", Size: 15, CreationDate: "now"}
+	if err = file.InsertIntoDb(db, groupFilesQuota); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, ArchiveGroup(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id}); !success {
+		t.Fatalf("The leader should archive the group : %s", rr.Body.String())
+	}
+
+	// Every write on the archived group is refused
+	for _, write := range []struct {
+		name    string
+		handler func(*sql.DB) http.HandlerFunc
+		body    map[string]any
+	}{
+		{"UpdateGroup", UpdateGroup, map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "GroupName": "renamed"}},
+		{"SetGroupRules", SetGroupRules, map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "Rules": "Be kind"}},
+		{"SetGroupRole", SetGroupRole, map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "MemberId": "memberId", "Role": "moderator"}},
+		{"TransferGroupLeadership", TransferGroupLeadership, map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "MemberId": "memberId"}},
+		{"JoinEvent", JoinEvent, map[string]any{"UserId": memberJwt, "EventId": event.Id}},
+		{"DeclineEvent", DeclineEvent, map[string]any{"UserId": memberJwt, "EventId": event.Id}},
+		{"VotePoll", VotePoll, map[string]any{"UserId": memberJwt, "PostId": "announcementId", "OptionIds": []string{"first"}}},
+		{"PinPost", PinPost, map[string]any{"UserId": leaderJwt, "PostId": "announcementId"}},
+		{"UnpinPost", UnpinPost, map[string]any{"UserId": leaderJwt, "PostId": "pinnedPostId"}},
+		{"ReorderPinnedPosts", ReorderPinnedPosts, map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "PostIds": []string{"pinnedPostId"}}},
+		{"DeleteGroupFile", DeleteGroupFile, map[string]any{"UserId": memberJwt, "FileId": file.Id}},
+		{"AcknowledgeAnnouncement", AcknowledgeAnnouncement, map[string]any{"UserId": memberJwt, "PostId": "announcementId"}},
+	} {
+		if success, rr := TryRequest(t, write.handler(db), write.body); success || !strings.Contains(rr.Body.String(), "archived") {
+			t.Fatalf("%s should be refused in an archived group : %s", write.name, rr.Body.String())
+		}
+	}
+
+	if err = group.SelectFromDb(db, map[string]any{"Id": group.Id}); err != nil || group.GroupName != "group" || group.LeaderId != "leaderId" {
+		t.Fatalf("The archived group shouldn't change : %+v %v", group, err)
+	}
+}
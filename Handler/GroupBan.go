@@ -0,0 +1,394 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+
+	"github.com/gofrs/uuid"
+)
+
+// The maximum quantity of characters of the reason of a ban.
+const maxBanReasonLength = 500
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the removal of a member from a group by the leader or a moderator.
+The removed user can ask to join the group again, and receives a notification.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func RemoveGroupMember(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId   string `json:"UserId"`
+			GroupId  string `json:"GroupId"`
+			MemberId string `json:"MemberId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [RemoveGroupMember] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [RemoveGroupMember] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [RemoveGroupMember] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if err = canSanctionMember(db, group, userId, datas.MemberId); err != nil {
+			nw.Error("You can't remove this user from the group")
+			log.Printf("[%s] [RemoveGroupMember] The user %s can't remove %s : %v", r.RemoteAddr, userId, datas.MemberId, err)
+			return
+		}
+
+		// The leader can't be removed, so the group is never archived or given to someone else here.
+		member := model.GroupMember{GroupId: group.Id, UserId: datas.MemberId}
+		if _, _, err = member.Leave(db, time.Now().UTC().Format(scheduleDateFormat)); err != nil {
+			nw.Error("This user isn't a member of the group")
+			log.Printf("[%s] [RemoveGroupMember] %v", r.RemoteAddr, err)
+			return
+		}
+
+		description := fmt.Sprintf("You have been removed from the group %s", group.GroupName)
+		if err = notifySanctionedMember(db, group, datas.MemberId, "RemoveGroupMember", description); err != nil {
+			nw.Error("There is a probleme during the sending of a notification")
+			log.Printf("[%s] [RemoveGroupMember] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Member removed successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [RemoveGroupMember] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the ban of a user from a group by the leader or a moderator.
+The user is removed from the group with their pending requests, can't join the group again until the ban expires
+(never without an expiration date), and receives a notification with the reason.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func BanGroupMember(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId   string `json:"UserId"`
+			GroupId  string `json:"GroupId"`
+			MemberId string `json:"MemberId"`
+			Reason   string `json:"Reason"`
+			// The end of the ban in the "2006-01-02 15:04" format (UTC), empty for a permanent ban.
+			ExpirationDate string `json:"ExpirationDate"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [BanGroupMember] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [BanGroupMember] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if utf8.RuneCountInString(datas.Reason) > maxBanReasonLength {
+			nw.Error("The reason is too long")
+			log.Printf("[%s] [BanGroupMember] The reason is too long", r.RemoteAddr)
+			return
+		}
+
+		now := time.Now().UTC()
+		if datas.ExpirationDate != "" {
+			expirationDate, err := time.Parse(scheduleDateFormat, datas.ExpirationDate)
+			if err != nil || !expirationDate.After(now) {
+				nw.Error("Invalid expiration date")
+				log.Printf("[%s] [BanGroupMember] Invalid expiration date %s : %v", r.RemoteAddr, datas.ExpirationDate, err)
+				return
+			}
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [BanGroupMember] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if err = utils.IfExistsInDB("UserInfo", db, map[string]any{"Id": datas.MemberId}); err != nil {
+			nw.Error("There is no user with this id")
+			log.Printf("[%s] [BanGroupMember] There is no user with the id %s : %v", r.RemoteAddr, datas.MemberId, err)
+			return
+		}
+
+		if err = canSanctionMember(db, group, userId, datas.MemberId); err != nil {
+			nw.Error("You can't ban this user from the group")
+			log.Printf("[%s] [BanGroupMember] The user %s can't ban %s : %v", r.RemoteAddr, userId, datas.MemberId, err)
+			return
+		}
+
+		ban := model.GroupBan{
+			GroupId:        group.Id,
+			UserId:         datas.MemberId,
+			BannedBy:       userId,
+			Reason:         datas.Reason,
+			CreationDate:   now.Format(scheduleDateFormat),
+			ExpirationDate: datas.ExpirationDate,
+		}
+
+		if err = ban.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB")
+			log.Printf("[%s] [BanGroupMember] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		description := fmt.Sprintf("You have been banned from the group %s", group.GroupName)
+		if ban.ExpirationDate != "" {
+			description += " until " + ban.ExpirationDate
+		}
+		if ban.Reason != "" {
+			description += " : " + ban.Reason
+		}
+
+		if err = notifySanctionedMember(db, group, ban.UserId, "BanGroupMember", description); err != nil {
+			nw.Error("There is a probleme during the sending of a notification")
+			log.Printf("[%s] [BanGroupMember] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Member banned successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [BanGroupMember] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the bans of a group still in force, for its leader and its moderators.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetGroupBans(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetGroupBans] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetGroupBans] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if !isGroupModerator(db, datas.GroupId, userId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [GetGroupBans] The user %s isn't the leader or a moderator of the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
+		var bans model.GroupBans
+		if err = bans.SelectFromDb(db, map[string]any{"GroupId": datas.GroupId}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetGroupBans] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		now := time.Now().UTC().Format(scheduleDateFormat)
+		activeBans := model.GroupBans{}
+		for _, ban := range bans {
+			if ban.IsActive(now) {
+				activeBans = append(activeBans, ban)
+			}
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group bans getted successfully",
+			"Value":   activeBans,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetGroupBans] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 4 arguments:
+  - a pointer to an SQL database object
+  - the Group of the sanction
+  - a string containing the id of the user who gives the sanction
+  - a string containing the id of the user who receives the sanction
+
+The purpose of this function is to check if a user can remove or ban another user from a group:
+the leader can sanction anyone but themself, the moderators can only sanction the users who aren't moderators.
+
+The function returns an error explaining why the sanction isn't allowed, nil otherwise.
+*/
+func canSanctionMember(db *sql.DB, group model.Group, userId, memberId string) error {
+	if memberId == "" || memberId == userId {
+		return errors.New("invalid member")
+	}
+
+	if memberId == group.LeaderId {
+		return errors.New("the leader can't be sanctioned")
+	}
+
+	if !isGroupModerator(db, group.Id, userId) {
+		return errors.New("the user isn't the leader or a moderator of the group")
+	}
+
+	if userId != group.LeaderId && isGroupModerator(db, group.Id, memberId) {
+		return errors.New("only the leader can sanction a moderator")
+	}
+
+	return nil
+}
+
+/*
+This function takes 5 arguments:
+  - a pointer to an SQL database object
+  - the Group of the sanction
+  - a string containing the id of the sanctioned user
+  - a string containing the type of the websocket message ("RemoveGroupMember" or "BanGroupMember")
+  - a string containing the description of the notification
+
+The purpose of this function is to send a notification and, if connected, a websocket message to a user removed or banned from a group.
+
+The function returns an error if the notification or the websocket message can't be sent.
+*/
+func notifySanctionedMember(db *sql.DB, group model.Group, memberId, messageType, description string) error {
+	notifId, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("error during the generation of the uuid : %v", err)
+	}
+
+	notification := model.Notification{
+		Id:          notifId.String(),
+		UserId:      memberId,
+		Status:      "Group",
+		Description: description,
+		GroupId:     group.Id,
+		OtherUserId: "",
+	}
+
+	if err = notification.InsertIntoDb(db); err != nil {
+		return fmt.Errorf("error during the sending of a notification : %v", err)
+	}
+
+	model.ConnectedWebSocket.Mu.Lock()
+	defer model.ConnectedWebSocket.Mu.Unlock()
+
+	if conn, isOk := model.ConnectedWebSocket.Conn[memberId]; isOk {
+		var WebsocketMessage struct {
+			Type        string
+			GroupId     string
+			Description string
+		}
+
+		WebsocketMessage.Type = messageType
+		WebsocketMessage.GroupId = group.Id
+		WebsocketMessage.Description = description
+
+		if err = conn.WriteJSON(WebsocketMessage); err != nil {
+			return fmt.Errorf("error during the communication with the websocket : %v", err)
+		}
+	}
+
+	return nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the group
+  - a string containing the id of the user
+
+The purpose of this function is to check if the user is banned from the group at the moment.
+
+The function returns true if a ban of the user hasn't expired yet, and an error if the bans can't be fetched (the callers refuse the request then).
+*/
+func isGroupBanned(db *sql.DB, groupId, userId string) (bool, error) {
+	var bans model.GroupBans
+	if err := bans.SelectFromDb(db, map[string]any{"GroupId": groupId, "UserId": userId}); err != nil {
+		return false, err
+	}
+
+	now := time.Now().UTC().Format(scheduleDateFormat)
+	for _, ban := range bans {
+		if ban.IsActive(now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
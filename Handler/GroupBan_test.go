@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRemoveGroupMember(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "moderatorId", "memberId"} {
+		CreateTestUser(t, db, id)
+	}
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | moderatorId | memberId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var member model.GroupMember
+	if err = member.UpdateDb(db, map[string]any{"Role": "moderator"}, map[string]any{"GroupId": group.Id, "UserId": "moderatorId"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, RemoveGroupMember(db), map[string]any{"UserId": utils.GenerateJWT("memberId"), "GroupId": group.Id, "MemberId": "moderatorId"}); success {
+		t.Fatal("A member can't remove another member")
+	}
+
+	if success, _ := TryRequest(t, RemoveGroupMember(db), map[string]any{"UserId": utils.GenerateJWT("moderatorId"), "GroupId": group.Id, "MemberId": "leaderId"}); success {
+		t.Fatal("The leader can't be removed")
+	}
+
+	if success, rr := TryRequest(t, RemoveGroupMember(db), map[string]any{"UserId": utils.GenerateJWT("moderatorId"), "GroupId": group.Id, "MemberId": "memberId"}); !success {
+		t.Fatalf("The moderator should remove the member : %s", rr.Body.String())
+	}
+
+	if IsGroupMember(group.Id, "memberId", db) {
+		t.Fatal("The member should be removed from the group")
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "UserId": "memberId"}); err != nil || len(notifications) != 1 {
+		t.Fatalf("The removed member should be notified : %+v %v", notifications, err)
+	}
+
+	// A removed member can ask to join again
+	if success, rr := TryRequest(t, JoinGroup(db), map[string]any{"UserId": utils.GenerateJWT("memberId"), "GroupId": group.Id}); !success {
+		t.Fatalf("The removed member should be able to ask to join again : %s", rr.Body.String())
+	}
+}
+
+func TestBanGroupMember(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "memberId", "otherId"} {
+		CreateTestUser(t, db, id)
+	}
+	leaderJwt := utils.GenerateJWT("leaderId")
+	memberJwt := utils.GenerateJWT("memberId")
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | memberId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ban := map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "MemberId": "memberId", "Reason": "spam", "ExpirationDate": "2000-01-01 00:00"}
+	if success, _ := TryRequest(t, BanGroupMember(db), ban); success {
+		t.Fatal("A ban can't expire in the past")
+	}
+
+	ban["ExpirationDate"] = ""
+	if success, rr := TryRequest(t, BanGroupMember(db), ban); !success {
+		t.Fatalf("The leader should ban the member : %s", rr.Body.String())
+	}
+
+	if banned, err := isGroupBanned(db, group.Id, "memberId"); err != nil || !banned || IsGroupMember(group.Id, "memberId", db) {
+		t.Fatal("The member should be removed and banned")
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "UserId": "memberId"}); err != nil || len(notifications) != 1 {
+		t.Fatalf("The banned member should be notified : %+v %v", notifications, err)
+	}
+
+	// The banned user can't come back, see the posts or use the chat of the group
+	if success, _ := TryRequest(t, JoinGroup(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id}); success {
+		t.Fatal("A banned user can't ask to join the group")
+	}
+
+	if success, _ := TryRequest(t, GetGroupsPosts(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id}); success {
+		t.Fatal("A banned user can't see the posts of the group")
+	}
+
+	if success, rr := TryRequest(t, GetMessage(db), map[string]any{"SenderId": memberJwt, "GroupId": group.Id}); success || !strings.Contains(rr.Body.String(), "banned") {
+		t.Fatal("A banned user can't see the chat of the group")
+	}
+
+	if success, rr := TryRequest(t, AddMessage(db), map[string]any{"SenderId": memberJwt, "GroupId": group.Id, "Message": "message"}); success || !strings.Contains(rr.Body.String(), "banned") {
+		t.Fatal("A banned user can't write in the chat of the group")
+	}
+
+	// An invitation sent before the ban can't be accepted
+	if err = (&model.InviteGroupRequest{SenderId: "leaderId", GroupId: group.Id, ReceiverId: "otherId"}).InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	expiration := time.Now().UTC().Add(time.Hour).Format(scheduleDateFormat)
+	if success, rr := TryRequest(t, BanGroupMember(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "MemberId": "otherId", "ExpirationDate": expiration}); !success {
+		t.Fatalf("The leader should ban a user outside of the group : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, AcceptInvitationGroup(db), map[string]any{"ReceiverId": utils.GenerateJWT("otherId"), "GroupId": group.Id}); success {
+		t.Fatal("A banned user can't accept an invitation")
+	}
+
+	var bodyValue struct {
+		Value model.GroupBans
+	}
+
+	if success, _ := TryRequest(t, GetGroupBans(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id}); success {
+		t.Fatal("Only the leader and the moderators can see the bans")
+	}
+
+	_, rr := TryRequest(t, GetGroupBans(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(bodyValue.Value) != 2 {
+		t.Fatalf("The bans of the group are not the good ones : %s", rr.Body.String())
+	}
+
+	// An expired ban no longer applies
+	if err = model.UpdateDb("GroupBan", db, map[string]any{"ExpirationDate": "2000-01-01 00:00"}, map[string]any{"UserId": "otherId"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if banned, err := isGroupBanned(db, group.Id, "otherId"); err != nil || banned {
+		t.Fatal("The ban should be expired")
+	}
+}
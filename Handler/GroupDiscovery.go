@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// The limits of the discovery of the groups.
+const (
+	maxGroupCategories      = 5
+	maxGroupCategoryLength  = 50
+	maxSuggestedGroups      = 10
+	defaultGroupSearchLimit = 20
+	maxGroupSearchLimit     = 100
+	// The activity of a group counts the posts and the comments of the last days.
+	groupActivityDays = 30
+)
+
+/*
+This function takes 1 argument:
+  - a slice of strings containing the categories sent by a user
+
+The purpose of this function is to clean the categories of a group: they are trimmed and lowercased,
+the empty ones and the duplicates are removed, and they are sorted by name.
+
+The function returns 2 values:
+  - the cleaned categories
+  - an error if there are too many categories or if one of them is too long
+*/
+func normalizeGroupCategories(categories []string) ([]string, error) {
+	normalized := []string{}
+	for _, category := range categories {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if category == "" || slices.Contains(normalized, category) {
+			continue
+		}
+
+		if utf8.RuneCountInString(category) > maxGroupCategoryLength {
+			return nil, fmt.Errorf("a category can't exceed %d characters", maxGroupCategoryLength)
+		}
+
+		normalized = append(normalized, category)
+	}
+
+	if len(normalized) > maxGroupCategories {
+		return nil, fmt.Errorf("a group can't have more than %d categories", maxGroupCategories)
+	}
+
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the search of groups by words of their name or their description,
+optionally in a category, sorted by number of members ("members"), by activity ("activity") or by name.
+The visibility of the groups is respected: the secret groups are hidden and the private groups are reduced for the non-members.
+Offset and Limit are used for the pagination.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func SearchGroups(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			model.GroupSearch
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [SearchGroups] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [SearchGroups] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if datas.OrderBy != "" && datas.OrderBy != "members" && datas.OrderBy != "activity" {
+			nw.Error("Invalid order")
+			log.Printf("[%s] [SearchGroups] Invalid order : %s", r.RemoteAddr, datas.OrderBy)
+			return
+		}
+
+		if datas.Offset < 0 || datas.Limit < 0 {
+			nw.Error("Invalid pagination")
+			log.Printf("[%s] [SearchGroups] Invalid pagination : offset %d and limit %d", r.RemoteAddr, datas.Offset, datas.Limit)
+			return
+		}
+
+		if datas.Limit == 0 {
+			datas.Limit = defaultGroupSearchLimit
+		}
+		datas.Limit = min(datas.Limit, maxGroupSearchLimit)
+
+		// The category is searched as it is saved.
+		datas.Category = strings.ToLower(strings.TrimSpace(datas.Category))
+
+		since := time.Now().UTC().AddDate(0, 0, -groupActivityDays).Format("2006-01-02")
+
+		var groups model.Groups
+		if err = groups.Search(db, datas.GroupSearch, userId, since); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [SearchGroups] Error during the search of the groups : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with the groups found.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Groups searched successfully",
+			"Groups":  visibleGroups(db, groups, userId),
+		})
+		if err != nil {
+			log.Printf("[%s] [SearchGroups] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the suggestion of groups to a user: the groups joined by the users they follow,
+the groups joined by the most followed users first. The groups the user can't see or is banned from aren't suggested.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetSuggestedGroups(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetSuggestedGroups] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetSuggestedGroups] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var groups model.Groups
+		if err = groups.SelectSuggested(db, userId); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetSuggestedGroups] Error during the fetch of the suggestions : %v", r.RemoteAddr, err)
+			return
+		}
+
+		suggestions := visibleGroups(db, groups, userId)
+		if len(suggestions) > maxSuggestedGroups {
+			suggestions = suggestions[:maxSuggestedGroups]
+		}
+
+		// Set the response header to indicate JSON content and respond with the suggested groups.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Suggested groups getted successfully",
+			"Groups":  suggestions,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetSuggestedGroups] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
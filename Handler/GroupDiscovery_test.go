@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+	"time"
+)
+
+func TestSearchGroups(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "userId", "firstId", "secondId"} {
+		CreateTestUser(t, db, id)
+	}
+	userJwt := utils.GenerateJWT("userId")
+
+	if success, _ := TryRequest(t, CreateGroup(db), map[string]any{"LeaderId": utils.GenerateJWT("leaderId"), "GroupName": "too many", "CreationDate": "now", "Categories": []string{"a", "b", "c", "d", "e", "f"}}); success {
+		t.Fatal("A group can't have more than 5 categories")
+	}
+
+	groups := []model.Group{
+		{Id: "hikingId", LeaderId: "leaderId", MemberIds: "leaderId | firstId | secondId", GroupName: "Hiking club", GroupDescription: "Walks in the mountains", Visibility: groupVisibilityPublic, Categories: []string{"sport"}},
+		{Id: "climbingId", LeaderId: "leaderId", GroupName: "Climbing", GroupDescription: "Mountains and walls", Visibility: groupVisibilityPrivate, Categories: []string{"sport"}},
+		{Id: "secretId", LeaderId: "leaderId", MemberIds: "leaderId | firstId | secondId", GroupName: "Secret mountains", Visibility: groupVisibilitySecret, Categories: []string{"sport"}},
+		{Id: "cookingId", LeaderId: "leaderId", MemberIds: "leaderId | firstId", GroupName: "Cooking", GroupDescription: "100% mountain cheese", Visibility: groupVisibilityPublic, Categories: []string{"food"}},
+	}
+	for _, group := range groups {
+		group.CreationDate = "now"
+		if err = group.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The recent posts of the climbing group make it the most active
+	today := time.Now().UTC().Format("2006-01-02")
+	for _, id := range []string{"firstPostId", "secondPostId"} {
+		post := model.Post{Id: id, AuthorId: "leaderId", Text: "text", CreationDate: today, Status: "public", IsGroup: "climbingId"}
+		if err = post.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	search := func(datas map[string]any) model.Groups {
+		datas["UserId"] = userJwt
+		success, rr := TryRequest(t, SearchGroups(db), datas)
+		if !success {
+			t.Fatalf("The search should succeed : %s", rr.Body.String())
+		}
+
+		var bodyValue struct {
+			Groups model.Groups
+		}
+		if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+			t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+		}
+
+		return bodyValue.Groups
+	}
+
+	found := search(map[string]any{"Query": "MOUNTAIN", "OrderBy": "members"})
+	if len(found) != 3 || found[0].Id != "hikingId" || found[1].Id != "cookingId" || found[2].Id != "climbingId" {
+		t.Fatalf("The secret group should be hidden and the groups sorted by members : %+v", found)
+	}
+
+	if found[2].LeaderId != "" || len(found[2].Categories) != 1 {
+		t.Fatalf("The private group should be reduced to its preview : %+v", found[2])
+	}
+
+	found = search(map[string]any{"Query": "mountain", "Category": " Sport ", "OrderBy": "activity"})
+	if len(found) != 2 || found[0].Id != "climbingId" {
+		t.Fatalf("The groups should be filtered by category and sorted by activity : %+v", found)
+	}
+
+	if found = search(map[string]any{"Query": "100%"}); len(found) != 1 || found[0].Id != "cookingId" {
+		t.Fatalf("The wildcards should be searched as text : %+v", found)
+	}
+
+	// The hidden secret group, as big as the hiking club, doesn't take a place in the pages
+	if found = search(map[string]any{"Query": "mountain", "OrderBy": "members", "Offset": 1, "Limit": 1}); len(found) != 1 || found[0].Id != "cookingId" {
+		t.Fatalf("The second page should contain the next visible group : %+v", found)
+	}
+
+	if success, _ := TryRequest(t, SearchGroups(db), map[string]any{"UserId": userJwt, "Offset": -1}); success {
+		t.Fatal("The offset can't be negative")
+	}
+
+	if success, _ := TryRequest(t, SearchGroups(db), map[string]any{"UserId": userJwt, "OrderBy": "date"}); success {
+		t.Fatal("The order must be members or activity")
+	}
+
+	// The groups of the followed users are suggested, those joined by the most followed users first
+	for i, followedId := range []string{"firstId", "secondId"} {
+		follower := model.Follower{Id: followedId + "Follow", FollowerId: "userId", FollowedId: followedId}
+		if err = follower.InsertIntoDb(db); err != nil {
+			t.Fatalf("Follower %d : %v", i, err)
+		}
+	}
+
+	var bodyValue struct {
+		Groups model.Groups
+	}
+
+	_, rr := TryRequest(t, GetSuggestedGroups(db), map[string]any{"UserId": userJwt})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(bodyValue.Groups) != 2 || bodyValue.Groups[0].Id != "hikingId" || bodyValue.Groups[1].Id != "cookingId" {
+		t.Fatalf("The groups of the followed users should be suggested : %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,563 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+
+	"github.com/gofrs/uuid"
+)
+
+// The limits of the library of a group, the names follow the size of their column in the "GroupFile" table.
+const (
+	maxGroupFileSize       = 10 << 20
+	groupFilesQuota        = 200 << 20
+	maxGroupFolderLength   = 200
+	maxGroupFileNameLength = 255
+)
+
+// The store of the content of the files of the groups, the tests replace it by a store in a temporary directory.
+var groupMediaStore utils.MediaStore = utils.NewDiskMediaStore("./Database/Media")
+
+/*
+This function takes 1 argument:
+  - a string containing the folder sent by a user
+
+The purpose of this function is to clean the path of a folder of the library: the parts are trimmed,
+the empty parts are removed and the parts "." and ".." are refused. An empty folder is the root of the library.
+
+The function returns 2 values:
+  - the cleaned folder
+  - an error if the folder is invalid or too long
+*/
+func normalizeGroupFolder(folder string) (string, error) {
+	parts := []string{}
+	for _, part := range strings.Split(folder, "/") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+
+		if part == "." || part == ".." || strings.Contains(part, `\`) {
+			return "", errors.New("invalid folder")
+		}
+
+		parts = append(parts, part)
+	}
+
+	folder = strings.Join(parts, "/")
+	if utf8.RuneCountInString(folder) > maxGroupFolderLength {
+		return "", fmt.Errorf("a folder can't exceed %d characters", maxGroupFolderLength)
+	}
+
+	return folder, nil
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the name of a file sent by a user
+
+The purpose of this function is to check the name of a file of the library: it is trimmed,
+and it can't be empty, contain a separator of path or a control character.
+
+The function returns 2 values:
+  - the cleaned name
+  - an error if the name is invalid or too long
+*/
+func checkGroupFileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) || strings.IndexFunc(name, unicode.IsControl) != -1 {
+		return "", errors.New("invalid file name")
+	}
+
+	if utf8.RuneCountInString(name) > maxGroupFileNameLength {
+		return "", fmt.Errorf("the name of a file can't exceed %d characters", maxGroupFileNameLength)
+	}
+
+	return name, nil
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the upload of a file in the library of a group by one of its members.
+The content is sent in base64 (a data URL is accepted too), it is kept in the media store and its description in the DB.
+The files of a group can't exceed the quota of the group. The other members of the group are notified of the new file.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UploadGroupFile(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// The content is encoded in base64, so it is a third bigger than the file.
+		r.Body = http.MaxBytesReader(w, r.Body, maxGroupFileSize/3*4+4096)
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+			Folder  string `json:"Folder"`
+			Name    string `json:"Name"`
+			Content string `json:"Content"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body or too big file")
+			log.Printf("[%s] [UploadGroupFile] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UploadGroupFile] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [UploadGroupFile] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if !IsGroupMember(group.Id, userId, db) {
+			nw.Error("Only the members can access the files of the group")
+			log.Printf("[%s] [UploadGroupFile] The user %s isn't a member of the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [UploadGroupFile] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		if datas.Folder, err = normalizeGroupFolder(datas.Folder); err != nil {
+			nw.Error(err.Error())
+			log.Printf("[%s] [UploadGroupFile] Invalid folder : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if datas.Name, err = checkGroupFileName(datas.Name); err != nil {
+			nw.Error(err.Error())
+			log.Printf("[%s] [UploadGroupFile] Invalid name : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// The header of a data URL is ignored, the type of the file is found from its name and its content.
+		if strings.HasPrefix(datas.Content, "data:") {
+			_, datas.Content, _ = strings.Cut(datas.Content, ",")
+		}
+
+		content, err := base64.StdEncoding.DecodeString(datas.Content)
+		if err != nil || len(content) == 0 || len(content) > maxGroupFileSize {
+			nw.Error(fmt.Sprintf("The file must be encoded in base64, not empty and under %d MB", maxGroupFileSize>>20))
+			log.Printf("[%s] [UploadGroupFile] Invalid content of %d bytes : %v", r.RemoteAddr, len(content), err)
+			return
+		}
+
+		if err = utils.IfNotExistsInDB("GroupFile", db, map[string]any{"GroupId": group.Id, "Folder": datas.Folder, "Name": datas.Name}); err != nil {
+			nw.Error("There is already a file with this name in this folder")
+			log.Printf("[%s] [UploadGroupFile] %v", r.RemoteAddr, err)
+			return
+		}
+
+		mimeType := mime.TypeByExtension(filepath.Ext(datas.Name))
+		if mimeType == "" {
+			mimeType = http.DetectContentType(content)
+		}
+
+		fileId, err := uuid.NewV7()
+		if err != nil {
+			nw.Error("There is a problem with the generation of the uuid")
+			log.Printf("[%s] [UploadGroupFile] There is a problem with the generation of the uuid : %s", r.RemoteAddr, err)
+			return
+		}
+
+		file := model.GroupFile{
+			Id:           fileId.String(),
+			GroupId:      group.Id,
+			UploaderId:   userId,
+			Folder:       datas.Folder,
+			Name:         datas.Name,
+			MimeType:     mimeType,
+			Size:         int64(len(content)),
+			CreationDate: time.Now().UTC().Format(scheduleDateFormat),
+		}
+
+		// The file is saved in the DB first, so the space is reserved in the quota before the content is written.
+		if err = file.InsertIntoDb(db, groupFilesQuota); errors.Is(err, model.ErrGroupQuotaExceeded) {
+			nw.Error(fmt.Sprintf("The files of the group can't exceed %d MB", groupFilesQuota>>20))
+			log.Printf("[%s] [UploadGroupFile] The quota of the group %s is exceeded", r.RemoteAddr, group.Id)
+			return
+		} else if err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB")
+			log.Printf("[%s] [UploadGroupFile] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		if err = groupMediaStore.Save(file.Id, content); err != nil {
+			// A file mustn't stay in the library without its content.
+			file.DeleteFromDb(db, map[string]any{"Id": file.Id})
+
+			nw.Error("Internal Error: There is a problem during the save of the file")
+			log.Printf("[%s] [UploadGroupFile] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		if err = notifyGroupFile(db, group, file); err != nil {
+			nw.Error("There is a probleme during the sending of the notifications")
+			log.Printf("[%s] [UploadGroupFile] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with the description of the file.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "File uploaded successfully",
+			"File":    file,
+		})
+		if err != nil {
+			log.Printf("[%s] [UploadGroupFile] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the listing of a folder of the library of a group by one of its members:
+the files of the folder, and the names of its subfolders. The space used by the files of the group is sent with its quota.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetGroupFiles(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+			Folder  string `json:"Folder"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetGroupFiles] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetGroupFiles] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if !IsGroupMember(datas.GroupId, userId, db) {
+			nw.Error("Only the members can access the files of the group")
+			log.Printf("[%s] [GetGroupFiles] The user %s isn't a member of the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
+		if datas.Folder, err = normalizeGroupFolder(datas.Folder); err != nil {
+			nw.Error(err.Error())
+			log.Printf("[%s] [GetGroupFiles] Invalid folder : %v", r.RemoteAddr, err)
+			return
+		}
+
+		files := model.GroupFiles{}
+		if err = files.SelectFromDb(db, map[string]any{"GroupId": datas.GroupId, "Folder": datas.Folder}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetGroupFiles] Error during the fetch of the files : %v", r.RemoteAddr, err)
+			return
+		}
+
+		allFolders, used, err := files.SelectFolders(db, datas.GroupId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetGroupFiles] Error during the fetch of the folders : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Only the first level under the folder is listed.
+		folders := []string{}
+		for _, folder := range allFolders {
+			if datas.Folder != "" {
+				var found bool
+				if folder, found = strings.CutPrefix(folder, datas.Folder+"/"); !found {
+					continue
+				}
+			}
+
+			if folder, _, _ = strings.Cut(folder, "/"); !slices.Contains(folders, folder) {
+				folders = append(folders, folder)
+			}
+		}
+
+		// Set the response header to indicate JSON content and respond with the content of the folder.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group files getted successfully",
+
+			"Folder":    datas.Folder,
+			"Folders":   folders,
+			"Files":     files,
+			"UsedSpace": used,
+			"Quota":     groupFilesQuota,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetGroupFiles] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the download of a file of the library of a group by one of its members.
+The content of the file is sent as it is, as an attachment, instead of a JSON response.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func DownloadGroupFile(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			FileId string `json:"FileId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [DownloadGroupFile] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [DownloadGroupFile] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// The existence of the file isn't revealed to the users outside of the group.
+		var file model.GroupFile
+		if err = file.SelectFromDb(db, map[string]any{"Id": datas.FileId}); err != nil || !IsGroupMember(file.GroupId, userId, db) {
+			nw.Error("There is no file with this id")
+			log.Printf("[%s] [DownloadGroupFile] The user %s can't download the file %s : %v", r.RemoteAddr, userId, datas.FileId, err)
+			return
+		}
+
+		content, err := groupMediaStore.Load(file.Id)
+		if err != nil {
+			nw.Error("Internal Error: There is a problem during the read of the file")
+			log.Printf("[%s] [DownloadGroupFile] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// The file is always downloaded, never shown by the browser in the page of the site.
+		w.Header().Set("Content-Type", file.MimeType)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		if _, err = w.Write(content); err != nil {
+			log.Printf("[%s] [DownloadGroupFile] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the removal of a file of the library of a group,
+by the member who uploaded it or by the leader and the moderators of the group.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func DeleteGroupFile(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			FileId string `json:"FileId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [DeleteGroupFile] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [DeleteGroupFile] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var file model.GroupFile
+		if err = file.SelectFromDb(db, map[string]any{"Id": datas.FileId}); err != nil || !IsGroupMember(file.GroupId, userId, db) {
+			nw.Error("There is no file with this id")
+			log.Printf("[%s] [DeleteGroupFile] The user %s can't see the file %s : %v", r.RemoteAddr, userId, datas.FileId, err)
+			return
+		}
+
+		if file.UploaderId != userId && !isGroupModerator(db, file.GroupId, userId) {
+			nw.Error("Only the uploader, the leader and the moderators can remove this file")
+			log.Printf("[%s] [DeleteGroupFile] The user %s can't remove the file %s", r.RemoteAddr, userId, file.Id)
+			return
+		}
+
+		if isGroupArchived(db, file.GroupId) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [DeleteGroupFile] The group %s is archived", r.RemoteAddr, file.GroupId)
+			return
+		}
+
+		if err = file.DeleteFromDb(db, map[string]any{"Id": file.Id}); err != nil {
+			nw.Error("Internal Error: There is a problem during the remove in the DB")
+			log.Printf("[%s] [DeleteGroupFile] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// The file is already out of the library, a content left in the store is only logged.
+		if err = groupMediaStore.Delete(file.Id); err != nil {
+			log.Printf("[%s] [DeleteGroupFile] Error during the remove of the content of the file %s : %v", r.RemoteAddr, file.Id, err)
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "File deleted successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [DeleteGroupFile] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Group of the library
+  - the GroupFile which has just been uploaded
+
+The purpose of this function is to send a notification and, if connected, a GroupFile websocket message
+with the description of the file to each member of the group, except the one who uploaded it.
+
+The function returns an error if the uploader or the members can't be fetched, or if a notification can't be sent.
+*/
+func notifyGroupFile(db *sql.DB, group model.Group, file model.GroupFile) error {
+	var userData model.Register
+	if err := userData.SelectFromDb(db, map[string]any{"Id": file.UploaderId}); err != nil {
+		return fmt.Errorf("error during the fetching of the user : %v", err)
+	}
+
+	var userDataName string
+	if userData.Username == "" {
+		userDataName = userData.FirstName + " " + userData.LastName
+	} else {
+		userDataName = userData.Username
+	}
+
+	description := fmt.Sprintf("%s has shared the file %s in the group %s", userDataName, file.Name, group.GroupName)
+
+	var members model.GroupMembers
+	if err := members.SelectFromDb(db, map[string]any{"GroupId": group.Id}); err != nil {
+		return fmt.Errorf("error during the fetching of the members : %v", err)
+	}
+
+	for _, member := range members {
+		if member.UserId == file.UploaderId {
+			continue
+		}
+
+		notifId, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("error during the generation of the uuid : %v", err)
+		}
+
+		notification := model.Notification{
+			Id:          notifId.String(),
+			UserId:      member.UserId,
+			Status:      "Group",
+			Description: description,
+			GroupId:     group.Id,
+			OtherUserId: file.UploaderId,
+		}
+
+		if err = notification.InsertIntoDb(db); err != nil {
+			return fmt.Errorf("error during the sending of a notification : %v", err)
+		}
+
+		model.ConnectedWebSocket.Mu.Lock()
+		if conn, isOk := model.ConnectedWebSocket.Conn[member.UserId]; isOk {
+			var WebsocketMessage struct {
+				Type        string
+				GroupId     string
+				Description string
+				Value       model.GroupFile
+			}
+
+			WebsocketMessage.Type = "GroupFile"
+			WebsocketMessage.GroupId = group.Id
+			WebsocketMessage.Description = description
+			WebsocketMessage.Value = file
+
+			err = conn.WriteJSON(WebsocketMessage)
+		}
+		model.ConnectedWebSocket.Mu.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("error during the communication with the websocket : %v", err)
+		}
+	}
+
+	return nil
+}
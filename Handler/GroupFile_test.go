@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestGroupFiles(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	defaultStore := groupMediaStore
+	groupMediaStore = utils.NewDiskMediaStore(t.TempDir())
+	defer func() { groupMediaStore = defaultStore }()
+
+	for _, id := range []string{"leaderId", "memberId", "otherId", "outsiderId"} {
+		CreateTestUser(t, db, id)
+	}
+	leaderJwt := utils.GenerateJWT("leaderId")
+	memberJwt := utils.GenerateJWT("memberId")
+	otherJwt := utils.GenerateJWT("otherId")
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | memberId | otherId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	content := base64.StdEncoding.EncodeToString([]byte("%PDF-1.4 slides"))
+
+	if success, _ := TryRequest(t, UploadGroupFile(db), map[string]any{"UserId": utils.GenerateJWT("outsiderId"), "GroupId": group.Id, "Name": "slides.pdf", "Content": content}); success {
+		t.Fatal("Only the members can upload a file")
+	}
+
+	for _, upload := range []map[string]any{
+		{"Name": "../slides.pdf", "Content": content},
+		{"Name": "slides.pdf", "Folder": "courses/../..", "Content": content},
+		{"Name": "slides.pdf", "Content": "not base64"},
+		{"Name": "slides.pdf", "Content": ""},
+	} {
+		upload["UserId"] = memberJwt
+		upload["GroupId"] = group.Id
+		if success, _ := TryRequest(t, UploadGroupFile(db), upload); success {
+			t.Fatalf("The upload should be refused : %v", upload)
+		}
+	}
+
+	success, rr := TryRequest(t, UploadGroupFile(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id, "Folder": " courses / week 1 ", "Name": "slides.pdf", "Content": "data:application/pdf;base64," + content})
+	if !success {
+		t.Fatalf("A member should upload a file : %s", rr.Body.String())
+	}
+
+	var uploaded struct {
+		File model.GroupFile
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &uploaded); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if uploaded.File.Folder != "courses/week 1" || uploaded.File.MimeType != "application/pdf" || uploaded.File.Size != 15 {
+		t.Fatalf("The file isn't saved as expected : %+v", uploaded.File)
+	}
+
+	if success, _ := TryRequest(t, UploadGroupFile(db), map[string]any{"UserId": otherJwt, "GroupId": group.Id, "Folder": "courses/week 1", "Name": "slides.pdf", "Content": content}); success {
+		t.Fatal("Two files can't have the same name in a folder")
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "OtherUserId": "memberId"}); err != nil || len(notifications) != 2 {
+		t.Fatalf("The other members should be notified : %+v %v", notifications, err)
+	}
+
+	// The root of the library only shows the first folder
+	_, rr = TryRequest(t, GetGroupFiles(db), map[string]any{"UserId": otherJwt, "GroupId": group.Id})
+	var listValue struct {
+		Folders   []string
+		Files     model.GroupFiles
+		UsedSpace int64
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &listValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(listValue.Folders) != 1 || listValue.Folders[0] != "courses" || len(listValue.Files) != 0 || listValue.UsedSpace != 15 {
+		t.Fatalf("The root should contain the folder courses : %s", rr.Body.String())
+	}
+
+	_, rr = TryRequest(t, GetGroupFiles(db), map[string]any{"UserId": otherJwt, "GroupId": group.Id, "Folder": "courses/week 1"})
+	if err = json.Unmarshal(rr.Body.Bytes(), &listValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(listValue.Folders) != 0 || len(listValue.Files) != 1 || listValue.Files[0].Id != uploaded.File.Id {
+		t.Fatalf("The folder should contain the file : %s", rr.Body.String())
+	}
+
+	download := func(userJwt string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]any{"UserId": userJwt, "FileId": uploaded.File.Id})
+		req, err := http.NewRequest("POST", "/", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := httptest.NewRecorder()
+		DownloadGroupFile(db).ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr = download(utils.GenerateJWT("outsiderId")); bytes.Contains(rr.Body.Bytes(), []byte("%PDF")) {
+		t.Fatal("Only the members can download a file")
+	}
+
+	if rr = download(otherJwt); rr.Body.String() != "%PDF-1.4 slides" || rr.Header().Get("Content-Type") != "application/pdf" {
+		t.Fatalf("A member should download the file : %s %v", rr.Body.String(), rr.Header())
+	}
+
+	if success, _ := TryRequest(t, DeleteGroupFile(db), map[string]any{"UserId": otherJwt, "FileId": uploaded.File.Id}); success {
+		t.Fatal("A member can't remove the file of another member")
+	}
+
+	if success, rr := TryRequest(t, DeleteGroupFile(db), map[string]any{"UserId": leaderJwt, "FileId": uploaded.File.Id}); !success {
+		t.Fatalf("The leader should remove the file : %s", rr.Body.String())
+	}
+
+	if _, err = groupMediaStore.Load(uploaded.File.Id); err == nil {
+		t.Fatal("The content of the file should be removed")
+	}
+
+	// The quota is checked with the size of the file
+	file := model.GroupFile{Id: "bigId", GroupId: group.Id, UploaderId: "memberId", Name: "big.bin", MimeType: "application/octet-stream", Size: groupFilesQuota, CreationDate: "now"}
+	if err = file.InsertIntoDb(db, groupFilesQuota); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, UploadGroupFile(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id, "Name": "slides.pdf", "Content": content}); success {
+		t.Fatal("The quota of the group can't be exceeded")
+	}
+}
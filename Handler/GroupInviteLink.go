@@ -0,0 +1,399 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+
+	"github.com/gofrs/uuid"
+)
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the creation of a shareable invite link by the leader or a moderator of a group.
+The link can expire at a date ("2006-01-02 15:04", UTC), be limited to a number of uses (0 for no limit),
+and let the users join without the approval of a moderator.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func CreateGroupInviteLink(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId         string `json:"UserId"`
+			GroupId        string `json:"GroupId"`
+			ExpirationDate string `json:"ExpirationDate"`
+			MaxUses        int    `json:"MaxUses"`
+			SkipApproval   bool   `json:"SkipApproval"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [CreateGroupInviteLink] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [CreateGroupInviteLink] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if !isGroupModerator(db, datas.GroupId, userId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [CreateGroupInviteLink] The user %s isn't the leader or a moderator of the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
+		if isGroupArchived(db, datas.GroupId) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [CreateGroupInviteLink] The group %s is archived", r.RemoteAddr, datas.GroupId)
+			return
+		}
+
+		if datas.MaxUses < 0 {
+			nw.Error("Invalid maximum number of uses")
+			log.Printf("[%s] [CreateGroupInviteLink] Invalid maximum number of uses : %d", r.RemoteAddr, datas.MaxUses)
+			return
+		}
+
+		now := time.Now().UTC()
+		if datas.ExpirationDate != "" {
+			expirationDate, err := time.Parse(scheduleDateFormat, datas.ExpirationDate)
+			if err != nil || !expirationDate.After(now) {
+				nw.Error("Invalid expiration date")
+				log.Printf("[%s] [CreateGroupInviteLink] Invalid expiration date %s : %v", r.RemoteAddr, datas.ExpirationDate, err)
+				return
+			}
+		}
+
+		// The token is random to prevent guessing the links of a group.
+		token, err := uuid.NewV4()
+		if err != nil {
+			nw.Error("There is a problem with the generation of the uuid")
+			log.Printf("[%s] [CreateGroupInviteLink] There is a problem with the generation of the uuid : %s", r.RemoteAddr, err)
+			return
+		}
+
+		link := model.GroupInviteLink{
+			Token:          token.String(),
+			GroupId:        datas.GroupId,
+			CreatorId:      userId,
+			CreationDate:   now.Format(scheduleDateFormat),
+			ExpirationDate: datas.ExpirationDate,
+			MaxUses:        datas.MaxUses,
+			SkipApproval:   datas.SkipApproval,
+		}
+
+		if err = link.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB")
+			log.Printf("[%s] [CreateGroupInviteLink] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with the new link.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Invite link created successfully",
+			"Value":   link,
+		})
+		if err != nil {
+			log.Printf("[%s] [CreateGroupInviteLink] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the listing of the invite links of a group which can still be used.
+Only the leader and the moderators of the group can see the links.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetGroupInviteLinks(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetGroupInviteLinks] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetGroupInviteLinks] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if !isGroupModerator(db, datas.GroupId, userId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [GetGroupInviteLinks] The user %s isn't the leader or a moderator of the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
+		var links model.GroupInviteLinks
+		if err = links.SelectFromDb(db, map[string]any{"GroupId": datas.GroupId}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetGroupInviteLinks] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		now := time.Now().UTC().Format(scheduleDateFormat)
+		activeLinks := model.GroupInviteLinks{}
+		for _, link := range links {
+			if link.IsActive(now) {
+				activeLinks = append(activeLinks, link)
+			}
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Invite links getted successfully",
+			"Value":   activeLinks,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetGroupInviteLinks] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the revocation of an invite link by the leader or a moderator of its group.
+A revoked link is deleted and can't be used anymore.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func RevokeGroupInviteLink(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			Token  string `json:"Token"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [RevokeGroupInviteLink] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [RevokeGroupInviteLink] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var links model.GroupInviteLinks
+		if err = links.SelectFromDb(db, map[string]any{"Token": datas.Token}); err != nil || len(links) != 1 {
+			nw.Error("There is no invite link with this token")
+			log.Printf("[%s] [RevokeGroupInviteLink] There is no invite link with the token %s : %v", r.RemoteAddr, datas.Token, err)
+			return
+		}
+
+		if !isGroupModerator(db, links[0].GroupId, userId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [RevokeGroupInviteLink] The user %s isn't the leader or a moderator of the group %s", r.RemoteAddr, userId, links[0].GroupId)
+			return
+		}
+
+		if err = links[0].DeleteFromDb(db, map[string]any{"Token": datas.Token}); err != nil {
+			nw.Error("Internal Error: There is a problem during the remove in the DB")
+			log.Printf("[%s] [RevokeGroupInviteLink] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Invite link revoked successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [RevokeGroupInviteLink] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the use of an invite link by a user.
+With a link skipping the approval, the user joins the group and the members receive the same websocket message
+as when an invitation is accepted, otherwise a join request is sent to the group like with JoinGroup,
+with the answers to the questions of the group.
+The link works for every visibility of group, but not for the users banned from it.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func JoinGroupByLink(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string                 `json:"UserId"`
+			Token   string                 `json:"Token"`
+			Answers model.JoinGroupAnswers `json:"Answers"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [JoinGroupByLink] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [JoinGroupByLink] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var links model.GroupInviteLinks
+		if err = links.SelectFromDb(db, map[string]any{"Token": datas.Token}); err != nil || len(links) != 1 {
+			nw.Error("Invalid invite link")
+			log.Printf("[%s] [JoinGroupByLink] There is no invite link with the token %s : %v", r.RemoteAddr, datas.Token, err)
+			return
+		}
+		link := links[0]
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": link.GroupId}); err != nil {
+			nw.Error("There is a problem during the fetch of the DB")
+			log.Printf("[%s] [JoinGroupByLink] There is a problem during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if IsGroupMember(group.Id, userId, db) {
+			nw.Error("You are already in the group")
+			log.Printf("[%s] [JoinGroupByLink] The user %s is already in the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		banned, err := isGroupBanned(db, group.Id, userId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [JoinGroupByLink] Error during the fetch of the bans : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if banned {
+			nw.Error("You are banned from this group")
+			log.Printf("[%s] [JoinGroupByLink] The user %s is banned from the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [JoinGroupByLink] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		if !link.SkipApproval && utils.IfNotExistsInDB("JoinGroupRequest", db, map[string]any{"UserId": userId, "GroupId": group.Id}) != nil {
+			nw.Error("You are already send a request to join this group")
+			log.Printf("[%s] [JoinGroupByLink] The user %s already sent a request to join the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		// Without SkipApproval, the request goes to the moderators with the answers to the questions of the group.
+		var answers model.JoinGroupAnswers
+		if !link.SkipApproval {
+			if err = group.SelectQuestions(db); err != nil {
+				nw.Error("There is a problem during the fetch of the DB")
+				log.Printf("[%s] [JoinGroupByLink] %v", r.RemoteAddr, err)
+				return
+			}
+
+			if answers, err = prepareJoinAnswers(group, datas.Answers); err != nil {
+				nw.Error(err.Error())
+				log.Printf("[%s] [JoinGroupByLink] Invalid answers : %v", r.RemoteAddr, err)
+				return
+			}
+		}
+
+		if err = link.Use(db, userId, time.Now().UTC().Format(scheduleDateFormat), answers); err != nil {
+			nw.Error("This invite link has expired or has been fully used")
+			log.Printf("[%s] [JoinGroupByLink] The link %s can't be used : %v", r.RemoteAddr, link.Token, err)
+			return
+		}
+
+		message := "Join Request successfully send"
+		if link.SkipApproval {
+			// The group is fetched again to send the members list with the new member.
+			if err = group.SelectFromDb(db, map[string]any{"Id": group.Id}); err != nil {
+				nw.Error("There is a problem during the fetch of the DB")
+				log.Printf("[%s] [JoinGroupByLink] There is a problem during the fetch of the DB : %v", r.RemoteAddr, err)
+				return
+			}
+
+			if err = notifyInvitationAccepted(group); err != nil {
+				nw.Error("Error during the communication with the websocket")
+				log.Printf("[%s] [JoinGroupByLink] %v", r.RemoteAddr, err)
+				return
+			}
+
+			message = "Group joined successfully"
+		} else if err = notifyJoinRequest(db, group, userId); err != nil {
+			nw.Error("There is a probleme during the sending of a notification")
+			log.Printf("[%s] [JoinGroupByLink] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": message,
+			"GroupId": group.Id,
+		})
+		if err != nil {
+			log.Printf("[%s] [JoinGroupByLink] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
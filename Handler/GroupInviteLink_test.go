@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestGroupInviteLinks(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "memberId", "firstId", "secondId", "thirdId"} {
+		CreateTestUser(t, db, id)
+	}
+	leaderJwt := utils.GenerateJWT("leaderId")
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | memberId", GroupName: "group", CreationDate: "now", Visibility: groupVisibilitySecret}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, SetGroupRules(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "Questions": []string{"Why?"}}); !success {
+		t.Fatalf("The leader should set the questions of the group : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, CreateGroupInviteLink(db), map[string]any{"UserId": utils.GenerateJWT("memberId"), "GroupId": group.Id}); success {
+		t.Fatal("A member can't create an invite link")
+	}
+
+	if success, _ := TryRequest(t, CreateGroupInviteLink(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "ExpirationDate": "2000-01-01 00:00"}); success {
+		t.Fatal("An invite link can't expire in the past")
+	}
+
+	var bodyValue struct {
+		Value model.GroupInviteLink
+	}
+
+	// A link skipping the approval, usable once
+	_, rr := TryRequest(t, CreateGroupInviteLink(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "MaxUses": 1, "SkipApproval": true})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil || bodyValue.Value.Token == "" {
+		t.Fatalf("The invite link should be created : %s", rr.Body.String())
+	}
+	directLink := bodyValue.Value
+
+	if success, rr := TryRequest(t, JoinGroupByLink(db), map[string]any{"UserId": utils.GenerateJWT("firstId"), "Token": directLink.Token}); !success || !IsGroupMember(group.Id, "firstId", db) {
+		t.Fatalf("The user should join the group with the link : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, JoinGroupByLink(db), map[string]any{"UserId": utils.GenerateJWT("secondId"), "Token": directLink.Token}); success {
+		t.Fatal("The link can't be used more than its maximum number of uses")
+	}
+
+	// A link needing the approval sends a join request
+	_, rr = TryRequest(t, CreateGroupInviteLink(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+	requestLink := bodyValue.Value
+
+	if success, _ := TryRequest(t, JoinGroupByLink(db), map[string]any{"UserId": utils.GenerateJWT("secondId"), "Token": requestLink.Token}); success {
+		t.Fatal("The questions of the group must be answered to ask to join with the link")
+	}
+
+	answers := []map[string]any{{"Answer": " To cook "}}
+	if success, rr := TryRequest(t, JoinGroupByLink(db), map[string]any{"UserId": utils.GenerateJWT("secondId"), "Token": requestLink.Token, "Answers": answers}); !success || IsGroupMember(group.Id, "secondId", db) {
+		t.Fatalf("The user should ask to join the group with the link : %s", rr.Body.String())
+	}
+
+	if utils.IfExistsInDB("JoinGroupRequest", db, map[string]any{"UserId": "secondId", "GroupId": group.Id}) != nil {
+		t.Fatal("The join request should be saved")
+	}
+
+	var savedAnswers model.JoinGroupAnswers
+	if err = savedAnswers.SelectFromDb(db, map[string]any{"GroupId": group.Id, "UserId": "secondId"}); err != nil || len(savedAnswers) != 1 || savedAnswers[0].Question != "Why?" || savedAnswers[0].Answer != "To cook" {
+		t.Fatalf("The answers should be saved with the join request : %+v %v", savedAnswers, err)
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "UserId": "leaderId"}); err != nil || len(notifications) != 1 {
+		t.Fatalf("The leader should be notified of the join request : %+v %v", notifications, err)
+	}
+
+	// Only the active links are listed, and a revoked link can't be used
+	var links struct {
+		Value model.GroupInviteLinks
+	}
+
+	_, rr = TryRequest(t, GetGroupInviteLinks(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id})
+	if err = json.Unmarshal(rr.Body.Bytes(), &links); err != nil || len(links.Value) != 1 || links.Value[0].Token != requestLink.Token {
+		t.Fatalf("Only the active link should be listed : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, RevokeGroupInviteLink(db), map[string]any{"UserId": leaderJwt, "Token": requestLink.Token}); !success {
+		t.Fatalf("The leader should revoke the link : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, JoinGroupByLink(db), map[string]any{"UserId": utils.GenerateJWT("thirdId"), "Token": requestLink.Token}); success {
+		t.Fatal("A revoked link can't be used")
+	}
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestGroupMembership(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	CreateTestUser(t, db, "leaderId")
+	CreateTestUser(t, db, "memberId")
+	leaderJwt := utils.GenerateJWT("leaderId")
+	memberJwt := utils.GenerateJWT("memberId")
+
+	success, rr := TryRequest(t, CreateGroup(db), map[string]any{"LeaderId": leaderJwt, "GroupName": "group", "CreationDate": "now", "MemberIds": "memberId"})
+	if !success {
+		t.Fatalf("The group should be created : %s", rr.Body.String())
+	}
+
+	var created struct {
+		GroupId string
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	// Only the leader is in a new group, whatever the request contains
+	if !IsGroupMember(created.GroupId, "leaderId", db) || IsGroupMember(created.GroupId, "memberId", db) {
+		t.Fatal("Only the leader should be a member of the new group")
+	}
+
+	if success, rr := TryRequest(t, JoinGroup(db), map[string]any{"UserId": memberJwt, "GroupId": created.GroupId}); !success {
+		t.Fatalf("The join request should be sent : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, AcceptJoinRequest(db), map[string]any{"UserId": leaderJwt, "GroupId": created.GroupId, "JoinUserId": "memberId"}); !success {
+		t.Fatalf("The join request should be accepted : %s", rr.Body.String())
+	}
+
+	if utils.IfNotExistsInDB("JoinGroupRequest", db, map[string]any{"UserId": "memberId", "GroupId": created.GroupId}) != nil {
+		t.Fatal("The join request should be deleted with the membership")
+	}
+
+	// The responses still contain the members and the groups joined as strings
+	var group model.Group
+	if err = group.SelectFromDb(db, map[string]any{"Id": created.GroupId}); err != nil || group.MemberIds != "leaderId | memberId" {
+		t.Fatalf("The members of the group are not the good ones : %+v %v", group, err)
+	}
+
+	var user model.Register
+	if err = user.SelectFromDb(db, map[string]any{"Id": "memberId"}); err != nil || user.GroupsJoined != created.GroupId {
+		t.Fatalf("The groups joined of the user are not the good ones : %+v %v", user, err)
+	}
+
+	body, err := json.Marshal(memberJwt)
+	if err != nil {
+		t.Fatalf("Erreur lors de la sérialisation du corps de la requête : %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "/getAllUsers", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr = httptest.NewRecorder()
+	GetAllUsers(db).ServeHTTP(rr, req)
+
+	var usersValue struct {
+		Users model.Users
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &usersValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	for _, user := range usersValue.Users {
+		if user.GroupsJoined != created.GroupId {
+			t.Fatalf("The groups joined of the users are not the good ones : %s", rr.Body.String())
+		}
+	}
+
+	if len(usersValue.Users) != 2 {
+		t.Fatalf("All the users should be sent : %s", rr.Body.String())
+	}
+
+	// The leadership goes to the oldest member when the leader leaves
+	if success, rr := TryRequest(t, LeaveGroup(db), map[string]any{"UserId": leaderJwt, "GroupId": created.GroupId}); !success {
+		t.Fatalf("The leader should leave the group : %s", rr.Body.String())
+	}
+
+	var members model.GroupMembers
+	if err = members.SelectFromDb(db, map[string]any{"GroupId": created.GroupId}); err != nil || len(members) != 1 || members[0].UserId != "memberId" || members[0].Role != "leader" {
+		t.Fatalf("The member should be the new leader : %+v %v", members, err)
+	}
+
+	if !isGroupLeader(db, created.GroupId, "memberId") {
+		t.Fatal("The leader of the group should be updated")
+	}
+
+	// The group is archived with its last member, its content is only removed by a deletion after the retention period
+	if success, rr := TryRequest(t, LeaveGroup(db), map[string]any{"UserId": memberJwt, "GroupId": created.GroupId}); !success {
+		t.Fatalf("The last member should leave the group : %s", rr.Body.String())
+	}
+
+	if !isGroupArchived(db, created.GroupId) {
+		t.Fatal("The group should be archived without members")
+	}
+
+	if success, _ := TryRequest(t, UnarchiveGroup(db), map[string]any{"UserId": memberJwt, "GroupId": created.GroupId}); success {
+		t.Fatal("A group without members can't be unarchived")
+	}
+
+	if success, _ := TryRequest(t, DeleteGroup(db), map[string]any{"UserId": memberJwt, "GroupId": created.GroupId}); success {
+		t.Fatal("The group can't be deleted before the end of the retention period")
+	}
+}
@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+
+	"github.com/gofrs/uuid"
+)
+
+// The roles of the members of a group, saved in the "GroupMember" table.
+const (
+	groupRoleLeader    = "leader"
+	groupRoleModerator = "moderator"
+	groupRoleMember    = "member"
+)
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the promotion of a member to moderator and the demotion of a moderator to member.
+Only the leader of the group can change the roles, and every member of the group is notified of the change.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func SetGroupRole(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId   string `json:"UserId"`
+			GroupId  string `json:"GroupId"`
+			MemberId string `json:"MemberId"`
+			// "moderator" to promote the member, "member" to demote them.
+			Role string `json:"Role"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [SetGroupRole] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [SetGroupRole] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if datas.Role != groupRoleModerator && datas.Role != groupRoleMember {
+			nw.Error("Invalid role")
+			log.Printf("[%s] [SetGroupRole] Invalid role : %s", r.RemoteAddr, datas.Role)
+			return
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [SetGroupRole] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if group.LeaderId != userId {
+			nw.Error("Only the leader can change the roles of the group")
+			log.Printf("[%s] [SetGroupRole] The user %s isn't the leader of the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [SetGroupRole] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		if datas.MemberId == group.LeaderId {
+			nw.Error("The role of the leader can't be changed")
+			log.Printf("[%s] [SetGroupRole] The role of the leader can't be changed", r.RemoteAddr)
+			return
+		}
+
+		if !IsGroupMember(group.Id, datas.MemberId, db) {
+			nw.Error("This user isn't a member of the group")
+			log.Printf("[%s] [SetGroupRole] The user %s isn't a member of the group %s", r.RemoteAddr, datas.MemberId, group.Id)
+			return
+		}
+
+		var member model.GroupMember
+		if err = member.UpdateDb(db, map[string]any{"Role": datas.Role}, map[string]any{"GroupId": group.Id, "UserId": datas.MemberId}); err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [SetGroupRole] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		if err = notifyGroupRoleChange(db, group, datas.MemberId, datas.Role); err != nil {
+			nw.Error("There is a probleme during the sending of a notification")
+			log.Printf("[%s] [SetGroupRole] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group role updated successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [SetGroupRole] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the transfer of the leadership of a group by its leader to another member.
+The previous leader stays in the group as a moderator, and every member of the group is notified.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func TransferGroupLeadership(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId   string `json:"UserId"`
+			GroupId  string `json:"GroupId"`
+			MemberId string `json:"MemberId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [TransferGroupLeadership] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [TransferGroupLeadership] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [TransferGroupLeadership] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if group.LeaderId != userId {
+			nw.Error("Only the leader can transfer the leadership of the group")
+			log.Printf("[%s] [TransferGroupLeadership] The user %s isn't the leader of the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [TransferGroupLeadership] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		if datas.MemberId == userId {
+			nw.Error("You are already the leader of the group")
+			log.Printf("[%s] [TransferGroupLeadership] The user %s is already the leader of the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if err = group.TransferLeadership(db, datas.MemberId); err != nil {
+			nw.Error("The leadership can't be transferred to this user")
+			log.Printf("[%s] [TransferGroupLeadership] Error during the transfer to %s : %v", r.RemoteAddr, datas.MemberId, err)
+			return
+		}
+
+		if err = notifyGroupRoleChange(db, group, datas.MemberId, groupRoleLeader); err != nil {
+			nw.Error("There is a probleme during the sending of a notification")
+			log.Printf("[%s] [TransferGroupLeadership] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group leadership transferred successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [TransferGroupLeadership] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 4 arguments:
+  - a pointer to an SQL database object
+  - the Group in which the role has changed
+  - a string containing the id of the member whose role has changed
+  - a string containing the new role of the member
+
+The purpose of this function is to send a notification and, if connected, a GroupRole websocket message
+to each member of the group when a member is promoted, demoted or becomes the leader.
+
+The function returns an error if the member can't be fetched, or if a notification can't be sent.
+*/
+func notifyGroupRoleChange(db *sql.DB, group model.Group, memberId, role string) error {
+	var userData model.Register
+	if err := userData.SelectFromDb(db, map[string]any{"Id": memberId}); err != nil {
+		return fmt.Errorf("error during the fetching of the user : %v", err)
+	}
+
+	var userDataName string
+	if userData.Username == "" {
+		userDataName = userData.FirstName + " " + userData.LastName
+	} else {
+		userDataName = userData.Username
+	}
+
+	var description string
+	switch role {
+	case groupRoleLeader:
+		description = fmt.Sprintf("%s is now the leader of the group %s", userDataName, group.GroupName)
+	case groupRoleModerator:
+		description = fmt.Sprintf("%s is now a moderator of the group %s", userDataName, group.GroupName)
+	default:
+		description = fmt.Sprintf("%s is no longer a moderator of the group %s", userDataName, group.GroupName)
+	}
+
+	var members model.GroupMembers
+	if err := members.SelectFromDb(db, map[string]any{"GroupId": group.Id}); err != nil {
+		return fmt.Errorf("error during the fetching of the members : %v", err)
+	}
+
+	for _, member := range members {
+		notifId, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("error during the generation of the uuid : %v", err)
+		}
+
+		notification := model.Notification{
+			Id:          notifId.String(),
+			UserId:      member.UserId,
+			Status:      "Group",
+			Description: description,
+			GroupId:     group.Id,
+			OtherUserId: memberId,
+		}
+
+		if err = notification.InsertIntoDb(db); err != nil {
+			return fmt.Errorf("error during the sending of a notification : %v", err)
+		}
+
+		model.ConnectedWebSocket.Mu.Lock()
+		if conn, isOk := model.ConnectedWebSocket.Conn[member.UserId]; isOk {
+			var WebsocketMessage struct {
+				Type        string
+				GroupId     string
+				UserId      string
+				Role        string
+				Description string
+			}
+
+			WebsocketMessage.Type = "GroupRole"
+			WebsocketMessage.GroupId = group.Id
+			WebsocketMessage.UserId = memberId
+			WebsocketMessage.Role = role
+			WebsocketMessage.Description = description
+
+			err = conn.WriteJSON(WebsocketMessage)
+		}
+		model.ConnectedWebSocket.Mu.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("error during the communication with the websocket : %v", err)
+		}
+	}
+
+	return nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the group
+  - a string containing the id of the user
+
+The purpose of this function is to check if the user moderates the group: the leader and the moderators can
+manage the join requests and remove the posts and the comments of the group.
+
+The function returns true if the user is the leader or a moderator of the group.
+*/
+func isGroupModerator(db *sql.DB, groupId, userId string) bool {
+	var members model.GroupMembers
+	if err := members.SelectFromDb(db, map[string]any{"GroupId": groupId, "UserId": userId}); err != nil || len(members) != 1 {
+		return false
+	}
+
+	return members[0].Role == groupRoleLeader || members[0].Role == groupRoleModerator
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestGroupRoles(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	CreateTestUser(t, db, "leaderId")
+	CreateTestUser(t, db, "moderatorId")
+	CreateTestUser(t, db, "memberId")
+	CreateTestUser(t, db, "joinId")
+	CreateTestUser(t, db, "outsiderId")
+	leaderJwt := utils.GenerateJWT("leaderId")
+	moderatorJwt := utils.GenerateJWT("moderatorId")
+	memberJwt := utils.GenerateJWT("memberId")
+
+	group := model.Group{
+		Id:           "groupId",
+		LeaderId:     "leaderId",
+		MemberIds:    "leaderId | moderatorId | memberId",
+		GroupName:    "group",
+		CreationDate: "now",
+	}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	promote := map[string]any{"UserId": memberJwt, "GroupId": group.Id, "MemberId": "moderatorId", "Role": "moderator"}
+	if success, _ := TryRequest(t, SetGroupRole(db), promote); success {
+		t.Fatal("Only the leader can promote a member")
+	}
+
+	promote["UserId"] = leaderJwt
+	if success, rr := TryRequest(t, SetGroupRole(db), promote); !success {
+		t.Fatalf("The leader should promote the member : %s", rr.Body.String())
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "UserId": "memberId", "OtherUserId": "moderatorId"}); err != nil || len(notifications) != 1 {
+		t.Fatalf("The members should be notified of the new moderator : %+v %v", notifications, err)
+	}
+
+	// The moderator manages the join requests
+	if err = (&model.JoinGroupRequest{UserId: "joinId", GroupId: group.Id}).InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, AcceptJoinRequest(db), map[string]any{"UserId": memberJwt, "GroupId": group.Id, "JoinUserId": "joinId"}); success {
+		t.Fatal("A member can't accept a join request")
+	}
+
+	if success, rr := TryRequest(t, AcceptJoinRequest(db), map[string]any{"UserId": moderatorJwt, "GroupId": group.Id, "JoinUserId": "joinId"}); !success {
+		t.Fatalf("The moderator should accept the join request : %s", rr.Body.String())
+	}
+
+	if !IsGroupMember(group.Id, "joinId", db) {
+		t.Fatal("The user should be in the group")
+	}
+
+	// The moderator removes the content of the group
+	post := model.Post{Id: "postId", AuthorId: "memberId", Text: "text", CreationDate: "now", Status: "public", IsGroup: group.Id}
+	if err = post.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	comment := model.Comment{Id: "commentId", AuthorId: "memberId", Text: "comment", CreationDate: "now", PostId: post.Id}
+	if err = comment.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, DeletePost(db), map[string]any{"UserId": utils.GenerateJWT("joinId"), "PostId": post.Id}); success {
+		t.Fatal("A member can't remove the post of another member")
+	}
+
+	if success, rr := TryRequest(t, DeleteComment(db), map[string]any{"UserId": moderatorJwt, "CommentId": comment.Id}); !success {
+		t.Fatalf("The moderator should remove the comment : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, DeletePost(db), map[string]any{"UserId": moderatorJwt, "PostId": post.Id}); !success {
+		t.Fatalf("The moderator should remove the post : %s", rr.Body.String())
+	}
+
+	if utils.IfNotExistsInDB("Post", db, map[string]any{"Id": post.Id}) != nil {
+		t.Fatal("The post should be removed")
+	}
+
+	// Every member creates events, the moderator too, the users outside of the group can't
+	event := map[string]any{"OrganisatorId": utils.GenerateJWT("outsiderId"), "GroupId": group.Id, "Title": "title", "Description": "description", "DateOfTheEvent": "2030-01-01"}
+	if success, _ := TryRequest(t, CreateEvent(db), event); success {
+		t.Fatal("A user outside of the group can't create an event")
+	}
+
+	for _, organisatorJwt := range []string{memberJwt, moderatorJwt} {
+		event["OrganisatorId"] = organisatorJwt
+		if success, rr := TryRequest(t, CreateEvent(db), event); !success {
+			t.Fatalf("The members should create events : %s", rr.Body.String())
+		}
+	}
+
+	// Once demoted, the moderator loses the rights
+	promote["Role"] = "member"
+	if success, rr := TryRequest(t, SetGroupRole(db), promote); !success {
+		t.Fatalf("The leader should demote the moderator : %s", rr.Body.String())
+	}
+
+	if isGroupModerator(db, group.Id, "moderatorId") {
+		t.Fatal("The user shouldn't be a moderator anymore")
+	}
+
+	promote["MemberId"] = "leaderId"
+	if success, _ := TryRequest(t, SetGroupRole(db), promote); success {
+		t.Fatal("The role of the leader can't be changed")
+	}
+}
+
+func TestGroupLeadership(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "memberId", "moderatorId", "outsiderId"} {
+		CreateTestUser(t, db, id)
+	}
+	leaderJwt := utils.GenerateJWT("leaderId")
+
+	group := model.Group{
+		Id:           "groupId",
+		LeaderId:     "leaderId",
+		MemberIds:    "leaderId | memberId | moderatorId",
+		GroupName:    "group",
+		CreationDate: "now",
+	}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	transfer := map[string]any{"UserId": utils.GenerateJWT("memberId"), "GroupId": group.Id, "MemberId": "memberId"}
+	if success, _ := TryRequest(t, TransferGroupLeadership(db), transfer); success {
+		t.Fatal("Only the leader can transfer the leadership")
+	}
+
+	transfer["UserId"] = leaderJwt
+	transfer["MemberId"] = "outsiderId"
+	if success, _ := TryRequest(t, TransferGroupLeadership(db), transfer); success {
+		t.Fatal("The leadership can't be given to a user outside of the group")
+	}
+
+	transfer["MemberId"] = "memberId"
+	if success, rr := TryRequest(t, TransferGroupLeadership(db), transfer); !success {
+		t.Fatalf("The leadership should be transferred : %s", rr.Body.String())
+	}
+
+	if !isGroupLeader(db, group.Id, "memberId") || !isGroupModerator(db, group.Id, "leaderId") {
+		t.Fatal("The member should be the leader and the previous leader a moderator")
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "UserId": "moderatorId", "OtherUserId": "memberId"}); err != nil || len(notifications) != 1 {
+		t.Fatalf("The members should be notified of the new leader : %+v %v", notifications, err)
+	}
+
+	// Without a transfer, the oldest moderator is preferred to the older members
+	var member model.GroupMember
+	if err = member.UpdateDb(db, map[string]any{"Role": "member"}, map[string]any{"GroupId": group.Id, "UserId": "leaderId"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = member.UpdateDb(db, map[string]any{"Role": "moderator"}, map[string]any{"GroupId": group.Id, "UserId": "moderatorId"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, LeaveGroup(db), map[string]any{"UserId": utils.GenerateJWT("memberId"), "GroupId": group.Id}); !success {
+		t.Fatalf("The leader should leave the group : %s", rr.Body.String())
+	}
+
+	if !isGroupLeader(db, group.Id, "moderatorId") {
+		t.Fatal("The moderator should be the new leader")
+	}
+
+	if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "UserId": "leaderId", "OtherUserId": "moderatorId"}); err != nil || len(notifications) != 1 {
+		t.Fatalf("The members should be notified of the automatic transfer : %+v %v", notifications, err)
+	}
+}
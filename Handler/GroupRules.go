@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// The limits of the rules of a group, the texts follow the size of their column in the "GroupQuestion" and "JoinGroupAnswer" tables.
+const (
+	maxGroupRulesLength    = 5000
+	maxGroupQuestions      = 5
+	maxGroupQuestionLength = 300
+	maxGroupAnswerLength   = 1000
+)
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the update of the rules of a group and of the questions asked to the users who want to join it.
+Only the leader of the group can change them. The questions replace the previous ones, an empty list removes them.
+The pending join requests keep the answers to the questions they were sent with.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func SetGroupRules(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId    string   `json:"UserId"`
+			GroupId   string   `json:"GroupId"`
+			Rules     string   `json:"Rules"`
+			Questions []string `json:"Questions"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [SetGroupRules] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [SetGroupRules] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [SetGroupRules] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if group.LeaderId != userId {
+			nw.Error("Only the leader can change the rules of the group")
+			log.Printf("[%s] [SetGroupRules] The user %s isn't the leader of the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [SetGroupRules] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		rules := strings.TrimSpace(datas.Rules)
+		if utf8.RuneCountInString(rules) > maxGroupRulesLength {
+			nw.Error(fmt.Sprintf("The rules of the group can't exceed %d characters", maxGroupRulesLength))
+			log.Printf("[%s] [SetGroupRules] The rules of the group are too long", r.RemoteAddr)
+			return
+		}
+
+		questions := []string{}
+		for _, question := range datas.Questions {
+			if question = strings.TrimSpace(question); question == "" {
+				continue
+			}
+
+			if utf8.RuneCountInString(question) > maxGroupQuestionLength {
+				nw.Error(fmt.Sprintf("A question can't exceed %d characters", maxGroupQuestionLength))
+				log.Printf("[%s] [SetGroupRules] A question is too long", r.RemoteAddr)
+				return
+			}
+
+			questions = append(questions, question)
+		}
+
+		if len(questions) > maxGroupQuestions {
+			nw.Error(fmt.Sprintf("A group can't have more than %d questions", maxGroupQuestions))
+			log.Printf("[%s] [SetGroupRules] Too many questions : %d", r.RemoteAddr, len(questions))
+			return
+		}
+
+		if err = group.UpdateRules(db, rules, questions); err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [SetGroupRules] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with the new rules.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success":   true,
+			"Message":   "Group rules updated successfully",
+			"Rules":     group.Rules,
+			"Questions": group.Questions,
+		})
+		if err != nil {
+			log.Printf("[%s] [SetGroupRules] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 2 arguments:
+  - the Group the user wants to join, with its Questions
+  - a JoinGroupAnswers array containing the answers sent by the user, in the order of the questions
+
+The purpose of this function is to check that every question of the group has a non-empty answer,
+and to attach each answer to the question it replies to, so the moderators read them as they were asked.
+
+The function returns 2 values:
+  - the cleaned answers
+  - an error if an answer is missing or too long
+*/
+func prepareJoinAnswers(group model.Group, answers model.JoinGroupAnswers) (model.JoinGroupAnswers, error) {
+	if len(answers) != len(group.Questions) {
+		return nil, errors.New("every question of the group must be answered")
+	}
+
+	prepared := model.JoinGroupAnswers{}
+	for position, question := range group.Questions {
+		answer := strings.TrimSpace(answers[position].Answer)
+		if answer == "" {
+			return nil, errors.New("every question of the group must be answered")
+		}
+
+		if utf8.RuneCountInString(answer) > maxGroupAnswerLength {
+			return nil, fmt.Errorf("an answer can't exceed %d characters", maxGroupAnswerLength)
+		}
+
+		prepared = append(prepared, model.JoinGroupAnswer{
+			GroupId:  group.Id,
+			Position: position,
+			Question: question,
+			Answer:   answer,
+		})
+	}
+
+	return prepared, nil
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestGroupRules(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "moderatorId", "userId"} {
+		CreateTestUser(t, db, id)
+	}
+	leaderJwt := utils.GenerateJWT("leaderId")
+	userJwt := utils.GenerateJWT("userId")
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | moderatorId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var member model.GroupMember
+	if err = member.UpdateDb(db, map[string]any{"Role": "moderator"}, map[string]any{"GroupId": group.Id, "UserId": "moderatorId"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, SetGroupRules(db), map[string]any{"UserId": utils.GenerateJWT("moderatorId"), "GroupId": group.Id, "Rules": "Be kind"}); success {
+		t.Fatal("Only the leader can change the rules")
+	}
+
+	if success, _ := TryRequest(t, SetGroupRules(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "Questions": []string{"1", "2", "3", "4", "5", "6"}}); success {
+		t.Fatalf("A group can't have more than %d questions", maxGroupQuestions)
+	}
+
+	if success, rr := TryRequest(t, SetGroupRules(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "Rules": " Be kind ", "Questions": []string{" Why? ", "", "Where?"}}); !success {
+		t.Fatalf("The leader should change the rules : %s", rr.Body.String())
+	}
+
+	// The rules and the questions are shown to the users outside of the group
+	_, rr := TryRequest(t, GetGroup(db), map[string]any{"UserId": userJwt, "GroupId": group.Id})
+	var groupValue struct {
+		Group model.Group
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &groupValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if groupValue.Group.Rules != "Be kind" || len(groupValue.Group.Questions) != 2 || groupValue.Group.Questions[0] != "Why?" {
+		t.Fatalf("The rules and the questions should be shown : %s", rr.Body.String())
+	}
+
+	for _, answers := range [][]map[string]any{
+		nil,
+		{{"Answer": "Because"}},
+		{{"Answer": "Because"}, {"Answer": " "}},
+	} {
+		if success, _ := TryRequest(t, JoinGroup(db), map[string]any{"UserId": userJwt, "GroupId": group.Id, "Answers": answers}); success {
+			t.Fatalf("Every question should be answered : %v", answers)
+		}
+	}
+
+	if success, rr := TryRequest(t, JoinGroup(db), map[string]any{"UserId": userJwt, "GroupId": group.Id, "Answers": []map[string]any{{"Answer": "Because"}, {"Answer": "Here"}}}); !success {
+		t.Fatalf("The join request should be sent : %s", rr.Body.String())
+	}
+
+	// The questions can change, the request keeps the questions it answered
+	if success, rr := TryRequest(t, SetGroupRules(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "Rules": "Be kind"}); !success {
+		t.Fatalf("The leader should remove the questions : %s", rr.Body.String())
+	}
+
+	_, rr = TryRequest(t, GetJoinRequest(db), map[string]any{"UserId": utils.GenerateJWT("moderatorId"), "GroupId": group.Id})
+	var requestsValue struct {
+		Value model.JoinGroupRequests
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &requestsValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(requestsValue.Value) != 1 || len(requestsValue.Value[0].Answers) != 2 {
+		t.Fatalf("The moderators should see the answers : %s", rr.Body.String())
+	}
+
+	if answer := requestsValue.Value[0].Answers[1]; answer.Question != "Where?" || answer.Answer != "Here" {
+		t.Fatalf("The answers should follow the questions : %+v", answer)
+	}
+
+	if success, rr := TryRequest(t, AcceptJoinRequest(db), map[string]any{"UserId": leaderJwt, "GroupId": group.Id, "JoinUserId": "userId"}); !success {
+		t.Fatalf("The request should be accepted : %s", rr.Body.String())
+	}
+
+	var answers model.JoinGroupAnswers
+	if err = answers.SelectFromDb(db, map[string]any{"GroupId": group.Id}); err != nil || len(answers) != 0 {
+		t.Fatalf("The answers should be deleted with the request : %+v %v", answers, err)
+	}
+}
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+
+	"github.com/gofrs/uuid"
+)
+
+// The limits of the profile of a group, the texts follow the size of their column in the "Groups" table.
+const (
+	maxGroupNameLength        = 200
+	maxGroupDescriptionLength = 500
+	maxGroupImageSize         = 400000
+)
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the update of the profile of a group: its name, description, picture, banner and categories.
+The leader and the moderators can update the profile, but only the leader can change the visibility of the group.
+An empty field keeps the current value, except the description and the categories which are removed when they are sent empty.
+Every member of the group is notified of the change.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UpdateGroup(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId    string `json:"UserId"`
+			GroupId   string `json:"GroupId"`
+			GroupName string `json:"GroupName"`
+			// nil keeps the description, an empty string removes it.
+			GroupDescription *string `json:"GroupDescription"`
+			GroupPicture     string  `json:"GroupPicture"`
+			Banner           string  `json:"Banner"`
+			Visibility       string  `json:"Visibility"`
+			// nil keeps the categories, an empty list removes them.
+			Categories []string `json:"Categories"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [UpdateGroup] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UpdateGroup] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var group model.Group
+		if err = group.SelectFromDb(db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [UpdateGroup] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if !isGroupModerator(db, group.Id, userId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [UpdateGroup] The user %s isn't the leader or a moderator of the group %s", r.RemoteAddr, userId, group.Id)
+			return
+		}
+
+		if isGroupArchived(db, group.Id) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [UpdateGroup] The group %s is archived", r.RemoteAddr, group.Id)
+			return
+		}
+
+		// The columns to update, only the fields that changed are kept.
+		update := map[string]any{}
+
+		if name := strings.TrimSpace(datas.GroupName); name != "" && name != group.GroupName {
+			if utf8.RuneCountInString(name) > maxGroupNameLength {
+				nw.Error(fmt.Sprintf("The name of the group can't exceed %d characters", maxGroupNameLength))
+				log.Printf("[%s] [UpdateGroup] The name of the group is too long", r.RemoteAddr)
+				return
+			}
+
+			if err = utils.IfNotExistsInDB("Groups", db, map[string]any{"GroupName": name}); err != nil {
+				nw.Error("There is already a group with the name : " + name)
+				log.Printf("[%s] [UpdateGroup] %s", r.RemoteAddr, err)
+				return
+			}
+
+			update["GroupName"] = name
+		}
+
+		if datas.GroupDescription != nil && *datas.GroupDescription != group.GroupDescription {
+			if utf8.RuneCountInString(*datas.GroupDescription) > maxGroupDescriptionLength {
+				nw.Error(fmt.Sprintf("The description of the group can't exceed %d characters", maxGroupDescriptionLength))
+				log.Printf("[%s] [UpdateGroup] The description of the group is too long", r.RemoteAddr)
+				return
+			}
+
+			update["GroupDescription"] = *datas.GroupDescription
+		}
+
+		for column, image := range map[string]string{"GroupPicture": datas.GroupPicture, "Banner": datas.Banner} {
+			if image == "" {
+				continue
+			}
+
+			if !strings.HasPrefix(image, "data:image/") || len(image) > maxGroupImageSize {
+				nw.Error("Invalid or too big image")
+				log.Printf("[%s] [UpdateGroup] Invalid or too big image for the %s", r.RemoteAddr, column)
+				return
+			}
+
+			update[column] = image
+		}
+
+		if datas.Visibility != "" && datas.Visibility != group.Visibility {
+			if !isValidGroupVisibility(datas.Visibility) {
+				nw.Error("Invalid visibility")
+				log.Printf("[%s] [UpdateGroup] Invalid visibility : %s", r.RemoteAddr, datas.Visibility)
+				return
+			}
+
+			if group.LeaderId != userId {
+				nw.Error("Only the leader can change the visibility of the group")
+				log.Printf("[%s] [UpdateGroup] The user %s isn't the leader of the group %s", r.RemoteAddr, userId, group.Id)
+				return
+			}
+
+			update["Visibility"] = datas.Visibility
+		}
+
+		updateCategories := false
+		if datas.Categories != nil {
+			if datas.Categories, err = normalizeGroupCategories(datas.Categories); err != nil {
+				nw.Error(err.Error())
+				log.Printf("[%s] [UpdateGroup] Invalid categories : %v", r.RemoteAddr, err)
+				return
+			}
+
+			if err = group.SelectCategories(db); err != nil {
+				nw.Error("There is a problem during the fetch of the DB")
+				log.Printf("[%s] [UpdateGroup] %v", r.RemoteAddr, err)
+				return
+			}
+
+			updateCategories = !slices.Equal(datas.Categories, group.Categories)
+		}
+
+		if len(update) == 0 && !updateCategories {
+			nw.Error("There is nothing to update")
+			log.Printf("[%s] [UpdateGroup] There is nothing to update", r.RemoteAddr)
+			return
+		}
+
+		if len(update) > 0 {
+			if err = group.UpdateDb(db, update, map[string]any{"Id": group.Id}); err != nil {
+				nw.Error("Internal Error: There is a problem during the update of the DB")
+				log.Printf("[%s] [UpdateGroup] %s", r.RemoteAddr, err.Error())
+				return
+			}
+		}
+
+		if updateCategories {
+			if err = group.UpdateCategories(db, datas.Categories); err != nil {
+				nw.Error("Internal Error: There is a problem during the update of the DB")
+				log.Printf("[%s] [UpdateGroup] %s", r.RemoteAddr, err.Error())
+				return
+			}
+		}
+
+		if err = group.SelectFromDb(db, map[string]any{"Id": group.Id}); err != nil {
+			nw.Error("There is a problem during the fetch of the DB")
+			log.Printf("[%s] [UpdateGroup] %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = group.SelectCategories(db); err != nil {
+			nw.Error("There is a problem during the fetch of the DB")
+			log.Printf("[%s] [UpdateGroup] %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = notifyGroupUpdate(db, group, userId); err != nil {
+			nw.Error("There is a probleme during the sending of a notification")
+			log.Printf("[%s] [UpdateGroup] %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with the updated group.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group updated successfully",
+			"Group":   group,
+		})
+		if err != nil {
+			log.Printf("[%s] [UpdateGroup] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Group after its update
+  - a string containing the id of the user who updated the group
+
+The purpose of this function is to send a notification and, if connected, an UpdateGroup websocket message
+with the new profile of the group to each member of the group, except the one who updated it.
+
+The function returns an error if the editor or the members can't be fetched, or if a notification can't be sent.
+*/
+func notifyGroupUpdate(db *sql.DB, group model.Group, editorId string) error {
+	var userData model.Register
+	if err := userData.SelectFromDb(db, map[string]any{"Id": editorId}); err != nil {
+		return fmt.Errorf("error during the fetching of the user : %v", err)
+	}
+
+	var userDataName string
+	if userData.Username == "" {
+		userDataName = userData.FirstName + " " + userData.LastName
+	} else {
+		userDataName = userData.Username
+	}
+
+	description := fmt.Sprintf("The group %s has been updated by %s", group.GroupName, userDataName)
+
+	var members model.GroupMembers
+	if err := members.SelectFromDb(db, map[string]any{"GroupId": group.Id}); err != nil {
+		return fmt.Errorf("error during the fetching of the members : %v", err)
+	}
+
+	for _, member := range members {
+		if member.UserId == editorId {
+			continue
+		}
+
+		notifId, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("error during the generation of the uuid : %v", err)
+		}
+
+		notification := model.Notification{
+			Id:          notifId.String(),
+			UserId:      member.UserId,
+			Status:      "Group",
+			Description: description,
+			GroupId:     group.Id,
+			OtherUserId: editorId,
+		}
+
+		if err = notification.InsertIntoDb(db); err != nil {
+			return fmt.Errorf("error during the sending of a notification : %v", err)
+		}
+
+		model.ConnectedWebSocket.Mu.Lock()
+		if conn, isOk := model.ConnectedWebSocket.Conn[member.UserId]; isOk {
+			var WebsocketMessage struct {
+				Type        string
+				GroupId     string
+				Description string
+				Value       model.Group
+			}
+
+			WebsocketMessage.Type = "UpdateGroup"
+			WebsocketMessage.GroupId = group.Id
+			WebsocketMessage.Description = description
+			WebsocketMessage.Value = group
+
+			err = conn.WriteJSON(WebsocketMessage)
+		}
+		model.ConnectedWebSocket.Mu.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("error during the communication with the websocket : %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"strings"
+	"testing"
+)
+
+func TestUpdateGroup(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "moderatorId", "memberId"} {
+		CreateTestUser(t, db, id)
+	}
+	moderatorJwt := utils.GenerateJWT("moderatorId")
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | moderatorId | memberId", GroupName: "group", GroupDescription: "description", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	other := model.Group{Id: "otherId", LeaderId: "leaderId", GroupName: "other", CreationDate: "now"}
+	if err = other.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var member model.GroupMember
+	if err = member.UpdateDb(db, map[string]any{"Role": "moderator"}, map[string]any{"GroupId": group.Id, "UserId": "moderatorId"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, UpdateGroup(db), map[string]any{"UserId": utils.GenerateJWT("memberId"), "GroupId": group.Id, "GroupName": "renamed"}); success {
+		t.Fatal("A member can't update the group")
+	}
+
+	for _, update := range []map[string]any{
+		{"GroupName": other.GroupName},
+		{"GroupName": strings.Repeat("a", maxGroupNameLength+1)},
+		{"GroupPicture": "not an image"},
+		{"Visibility": groupVisibilityPublic},
+		{},
+	} {
+		update["UserId"] = moderatorJwt
+		update["GroupId"] = group.Id
+		if success, _ := TryRequest(t, UpdateGroup(db), update); success {
+			t.Fatalf("The update should be refused : %v", update)
+		}
+	}
+
+	if success, rr := TryRequest(t, UpdateGroup(db), map[string]any{"UserId": moderatorJwt, "GroupId": group.Id, "GroupName": " renamed ", "GroupDescription": "", "Banner": "data:image/png;base64,AAAA"}); !success {
+		t.Fatalf("The moderator should update the group : %s", rr.Body.String())
+	}
+
+	if err = group.SelectFromDb(db, map[string]any{"Id": group.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	if group.GroupName != "renamed" || group.GroupDescription != "" || group.Visibility != groupVisibilityPrivate {
+		t.Fatalf("The group isn't updated as expected : %+v", group)
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"GroupId": group.Id, "OtherUserId": "moderatorId"}); err != nil || len(notifications) != 2 {
+		t.Fatalf("The other members should be notified : %+v %v", notifications, err)
+	}
+
+	if success, rr := TryRequest(t, UpdateGroup(db), map[string]any{"UserId": utils.GenerateJWT("leaderId"), "GroupId": group.Id, "Visibility": groupVisibilityPublic}); !success {
+		t.Fatalf("The leader should change the visibility : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, UpdateGroup(db), map[string]any{"UserId": moderatorJwt, "GroupId": group.Id, "Categories": []string{" Music", "music", "art"}}); !success {
+		t.Fatalf("The moderator should update the categories : %s", rr.Body.String())
+	}
+
+	if err = group.SelectCategories(db); err != nil || len(group.Categories) != 2 || group.Categories[0] != "art" || group.Categories[1] != "music" {
+		t.Fatalf("The categories should be cleaned : %v %v", group.Categories, err)
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// The quantity of most active members in the statistics of a group.
+const maxGroupActiveMembers = 10
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the statistics of a group by its leader and its moderators:
+the growth of the members, the posts, the comments and the chat messages of each day of the period,
+the most active members of the period and the attendance of the events of the group.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetGroupStats(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+			Days    int    `json:"Days"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetGroupStats] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetGroupStats] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = utils.IfExistsInDB("Groups", db, map[string]any{"Id": datas.GroupId}); err != nil {
+			nw.Error("There is no group with this id")
+			log.Printf("[%s] [GetGroupStats] There is no group with the id %s : %v", r.RemoteAddr, datas.GroupId, err)
+			return
+		}
+
+		if !isGroupModerator(db, datas.GroupId, userId) {
+			nw.Error("The current user isn't the leader or a moderator of this goup")
+			log.Printf("[%s] [GetGroupStats] The user %s isn't the leader or a moderator of the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
+		if datas.Days <= 0 {
+			datas.Days = defaultStatsDays
+		}
+		datas.Days = min(datas.Days, maxStatsDays)
+
+		// The days of the period, from the oldest to today.
+		today := time.Now().UTC()
+		days := make([]string, datas.Days)
+		for i := range days {
+			days[i] = today.AddDate(0, 0, i-datas.Days+1).Format(statsDayFormat)
+		}
+
+		stats := model.GroupStats{GroupId: datas.GroupId}
+		if err = stats.SelectFromDb(db, days, maxGroupActiveMembers); err != nil {
+			nw.Error("Error during the fetch of the statistics")
+			log.Printf("[%s] [GetGroupStats] Error during the fetch of the statistics : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with the statistics.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group statistics getted successfully",
+
+			"Value": stats,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetGroupStats] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+	"time"
+)
+
+func TestGetGroupStats(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "moderatorId", "memberId"} {
+		CreateTestUser(t, db, id)
+	}
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | moderatorId | memberId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	// The leader joined before the period, the other members today
+	var member model.GroupMember
+	if err = member.UpdateDb(db, map[string]any{"JoinedAt": "2000-01-01T00:00:00Z"}, map[string]any{"GroupId": group.Id, "UserId": "leaderId"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = member.UpdateDb(db, map[string]any{"Role": "moderator"}, map[string]any{"GroupId": group.Id, "UserId": "moderatorId"}); err != nil {
+		t.Fatal(err)
+	}
+
+	today := time.Now().UTC().Format(statsDayFormat)
+	for _, id := range []string{"firstPostId", "secondPostId"} {
+		post := model.Post{Id: id, AuthorId: "memberId", Text: "text", CreationDate: today, Status: "public", IsGroup: group.Id}
+		if err = post.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	comment := model.Comment{Id: "commentId", AuthorId: "leaderId", Text: "text", CreationDate: today, PostId: "firstPostId"}
+	if err = comment.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	for id, date := range map[string]string{"messageId": today, "otherMessageId": "yesterday"} {
+		message := model.Message{Id: id, SenderId: "memberId", CreationDate: date, Message: "hello", GroupId: group.Id}
+		if err = message.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	event := model.Event{Id: "eventId", GroupId: group.Id, OrganisatorId: "leaderId", Title: "event", Description: "description", DateOfTheEvent: today}
+	if err = event.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+	for _, userId := range []string{"leaderId", "memberId"} {
+		join := model.JoinEvent{UserId: userId, EventId: event.Id}
+		if err = join.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+	decline := model.DeclineEvent{UserId: "moderatorId", EventId: event.Id}
+	if err = decline.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, GetGroupStats(db), map[string]any{"UserId": utils.GenerateJWT("memberId"), "GroupId": group.Id}); success {
+		t.Fatal("A member can't see the statistics of the group")
+	}
+
+	success, rr := TryRequest(t, GetGroupStats(db), map[string]any{"UserId": utils.GenerateJWT("moderatorId"), "GroupId": group.Id, "Days": 7})
+	if !success {
+		t.Fatalf("A moderator should see the statistics of the group : %s", rr.Body.String())
+	}
+
+	var bodyValue struct {
+		Value model.GroupStats
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+	stats := bodyValue.Value
+
+	if len(stats.Daily) != 7 || stats.Daily[0].Members != 1 || stats.Daily[6].Members != 3 || stats.Daily[6].NewMembers != 2 {
+		t.Fatalf("The growth of the members is wrong : %+v", stats.Daily)
+	}
+
+	if stats.Members != 3 || stats.Posts != 2 || stats.Comments != 1 || stats.Messages != 1 || stats.Daily[6].Posts != 2 {
+		t.Fatalf("The activity of the group is wrong : %+v", stats)
+	}
+
+	if len(stats.ActiveMembers) != 2 || stats.ActiveMembers[0].UserId != "memberId" || stats.ActiveMembers[0].Total != 3 || stats.ActiveMembers[0].Messages != 1 {
+		t.Fatalf("The most active members are wrong : %+v", stats.ActiveMembers)
+	}
+
+	if len(stats.Events) != 1 || stats.Events[0].Going != 2 || stats.Events[0].NotGoing != 1 || stats.AttendanceRate < 0.66 || stats.AttendanceRate > 0.67 {
+		t.Fatalf("The attendance of the events is wrong : %+v %v", stats.Events, stats.AttendanceRate)
+	}
+}
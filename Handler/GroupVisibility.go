@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"database/sql"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// The visibilities of a group, saved in the "Visibility" column of the "Groups" table.
+const (
+	// Everybody sees the group and its content, and joins it instantly.
+	groupVisibilityPublic = "public"
+	// Everybody sees the name and the description of the group, and joins it by request.
+	groupVisibilityPrivate = "private"
+	// Only the members and the invited users see the group, and it is joined by invitation.
+	groupVisibilitySecret = "secret"
+)
+
+/*
+This function takes 1 argument:
+  - a string containing the visibility to check
+
+The purpose of this function is to check if the visibility sent by a user is one of the visibilities of a group.
+
+The function returns true if the visibility is valid, false otherwise.
+*/
+func isValidGroupVisibility(visibility string) bool {
+	return visibility == groupVisibilityPublic || visibility == groupVisibilityPrivate || visibility == groupVisibilitySecret
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Group to check
+  - a string containing the id of the user
+
+The purpose of this function is to check if the user knows the existence of the group:
+a secret group is only visible for its members and for the users invited in it.
+A banned user doesn't see the group anymore, whatever its visibility, and an archived group is only visible for its members.
+
+The function returns true if the group can be shown to the user, false otherwise.
+*/
+func canSeeGroup(db *sql.DB, group model.Group, userId string) bool {
+	if IsGroupMember(group.Id, userId, db) {
+		return true
+	}
+
+	// The group is hidden when the bans can't be fetched.
+	if banned, err := isGroupBanned(db, group.Id, userId); err != nil || banned || group.ArchivedAt != "" {
+		return false
+	}
+
+	if group.Visibility != groupVisibilitySecret {
+		return true
+	}
+
+	return utils.IfExistsInDB("InviteGroupRequest", db, map[string]any{"GroupId": group.Id, "ReceiverId": userId}) == nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the group
+  - a string containing the id of the user
+
+The purpose of this function is to check if the user can read the content of the group (posts, events and chat):
+the members always can, the other users only in a public group which isn't archived and if they are not banned from it.
+
+The function returns true if the user can read the content of the group, false otherwise (or if the group doesn't exist).
+*/
+func canSeeGroupContent(db *sql.DB, groupId, userId string) bool {
+	if IsGroupMember(groupId, userId, db) {
+		return true
+	}
+
+	var group model.Group
+	if err := group.SelectFromDb(db, map[string]any{"Id": groupId}); err != nil {
+		return false
+	}
+
+	// The content is hidden when the bans can't be fetched.
+	banned, err := isGroupBanned(db, group.Id, userId)
+	return err == nil && !banned && group.Visibility == groupVisibilityPublic && group.ArchivedAt == ""
+}
+
+/*
+This function takes 1 argument:
+  - the Group to reduce
+
+The purpose of this function is to keep only what a non-member can see of a private group:
+its name and its description, with the id, the visibility and the categories to find it,
+and its rules and questions to ask to join it.
+
+The function returns the reduced Group.
+*/
+func groupPreview(group model.Group) model.Group {
+	return model.Group{
+		Id:               group.Id,
+		GroupName:        group.GroupName,
+		GroupDescription: group.GroupDescription,
+		Visibility:       group.Visibility,
+		Categories:       group.Categories,
+		Rules:            group.Rules,
+		Questions:        group.Questions,
+	}
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Groups to filter
+  - a string containing the id of the user
+
+The purpose of this function is to apply the visibility of the groups to a list sent to the user:
+the groups the user can't see are removed, and the private and secret groups the user isn't in are reduced to their preview.
+
+The function returns the Groups the user can see, in the same order.
+*/
+func visibleGroups(db *sql.DB, groups model.Groups, userId string) model.Groups {
+	visible := model.Groups{}
+	for _, group := range groups {
+		if !canSeeGroup(db, group, userId) {
+			continue
+		}
+
+		if group.Visibility != groupVisibilityPublic && !IsGroupMember(group.Id, userId, db) {
+			group = groupPreview(group)
+		}
+
+		visible = append(visible, group)
+	}
+
+	return visible
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"strings"
+	"testing"
+)
+
+func TestGroupVisibility(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "userId", "invitedId"} {
+		CreateTestUser(t, db, id)
+	}
+	userJwt := utils.GenerateJWT("userId")
+
+	for _, visibility := range []string{groupVisibilityPublic, groupVisibilityPrivate, groupVisibilitySecret} {
+		group := model.Group{Id: visibility, LeaderId: "leaderId", GroupName: visibility, GroupDescription: "description", CreationDate: "now", Visibility: visibility}
+		if err = group.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if success, _ := TryRequest(t, CreateGroup(db), map[string]any{"LeaderId": utils.GenerateJWT("leaderId"), "GroupName": "other", "CreationDate": "now", "Visibility": "hidden"}); success {
+		t.Fatal("The visibility of a group must be public, private or secret")
+	}
+
+	// The secret group is hidden and the private group is reduced in the list of the groups
+	body, err := json.Marshal(userJwt)
+	if err != nil {
+		t.Fatalf("Erreur lors de la sérialisation du corps de la requête : %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "/getAllGroups", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	GetAllGroups(db).ServeHTTP(rr, req)
+
+	var allGroups struct {
+		Groups model.Groups
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &allGroups); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(allGroups.Groups) != 2 {
+		t.Fatalf("The secret group shouldn't be listed : %s", rr.Body.String())
+	}
+
+	for _, group := range allGroups.Groups {
+		if group.Id == groupVisibilityPrivate && (group.LeaderId != "" || group.GroupDescription != "description") {
+			t.Fatalf("Only the name and the description of the private group should be sent : %+v", group)
+		}
+	}
+
+	if success, _ := TryRequest(t, GetGroup(db), map[string]any{"UserId": userJwt, "GroupId": groupVisibilitySecret}); success {
+		t.Fatal("A secret group can't be seen by a non-member")
+	}
+
+	// The content of a group is only readable by the non-members in a public group
+	for _, handler := range []http.HandlerFunc{GetGroupsPosts(db), GetAllGroupEvents(db)} {
+		if success, rr := TryRequest(t, handler, map[string]any{"UserId": userJwt, "GroupId": groupVisibilityPublic}); !success {
+			t.Fatalf("The content of a public group should be visible : %s", rr.Body.String())
+		}
+
+		if success, _ := TryRequest(t, handler, map[string]any{"UserId": userJwt, "GroupId": groupVisibilityPrivate}); success {
+			t.Fatal("The content of a private group can't be seen by a non-member")
+		}
+	}
+
+	if success, rr := TryRequest(t, GetMessage(db), map[string]any{"SenderId": userJwt, "GroupId": groupVisibilityPrivate}); success || !strings.Contains(rr.Body.String(), "can't see") {
+		t.Fatal("The chat of a private group can't be seen by a non-member")
+	}
+
+	if success, rr := TryRequest(t, AddMessage(db), map[string]any{"SenderId": userJwt, "GroupId": groupVisibilityPublic, "Message": "message"}); success || !strings.Contains(rr.Body.String(), "members") {
+		t.Fatal("Only the members can write in the chat of a group")
+	}
+
+	// A public group is joined instantly, a private group by request and a secret group by invitation
+	if success, rr := TryRequest(t, JoinGroup(db), map[string]any{"UserId": userJwt, "GroupId": groupVisibilityPublic}); !success || !IsGroupMember(groupVisibilityPublic, "userId", db) {
+		t.Fatalf("The user should join the public group : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, JoinGroup(db), map[string]any{"UserId": userJwt, "GroupId": groupVisibilityPrivate}); !success || IsGroupMember(groupVisibilityPrivate, "userId", db) {
+		t.Fatalf("The user should ask to join the private group : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, JoinGroup(db), map[string]any{"UserId": userJwt, "GroupId": groupVisibilitySecret}); success {
+		t.Fatal("A secret group can't be joined without invitation")
+	}
+
+	if err = (&model.InviteGroupRequest{SenderId: "leaderId", GroupId: groupVisibilitySecret, ReceiverId: "invitedId"}).InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, GetGroup(db), map[string]any{"UserId": utils.GenerateJWT("invitedId"), "GroupId": groupVisibilitySecret}); !success {
+		t.Fatalf("An invited user should see the secret group : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, AcceptInvitationGroup(db), map[string]any{"ReceiverId": utils.GenerateJWT("invitedId"), "GroupId": groupVisibilitySecret}); !success {
+		t.Fatalf("An invited user should join the secret group : %s", rr.Body.String())
+	}
+}
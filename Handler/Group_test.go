@@ -620,6 +620,12 @@ func TestDeleteGroup(t *testing.T) {
 		return
 	}
 
+	// The group can only be deleted once it has been archived for the retention period
+	if err = group.UpdateDb(db, map[string]any{"ArchivedAt": "2000-01-01 00:00"}, map[string]any{"Id": group.Id}); err != nil {
+		t.Fatal(err)
+		return
+	}
+
 	user := map[string]any{
 		"UserId":  JWT,
 		"groupId": group.Id,
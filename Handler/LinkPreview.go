@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// The fetcher of the link previews. The private and loopback addresses are blocked,
+// the tests replace it by a fetcher allowed to reach their local server.
+var linkPreviewFetcher utils.LinkPreviewFetcher = utils.NewLinkPreviewFetcher(false)
+
+// The maximum duration of a whole preview job, every redirect included (each request has its own shorter timeout in utils).
+const linkPreviewJobTimeout = 10 * time.Second
+
+/*
+This function takes 4 arguments:
+  - a pointer to an SQL database object
+  - a string containing the type of the content ("Post" or "Message")
+  - a string containing the id of the content
+  - a string containing the text of the content
+
+The purpose of this function is to fetch and save the preview card of the first link of a new post or message.
+The fetch is done in the background, so the request isn't slowed down, and the errors are only logged.
+*/
+func StartLinkPreview(db *sql.DB, sourceType, sourceId, text string) {
+	if utils.FindFirstURL(text) == "" {
+		return
+	}
+
+	fetcher := linkPreviewFetcher
+	go func() {
+		if err := saveLinkPreview(db, fetcher, sourceType, sourceId, text); err != nil {
+			log.Printf("[StartLinkPreview] Error during the preview of the link of the %s %s : %v", sourceType, sourceId, err)
+		}
+	}()
+}
+
+/*
+This function takes 5 arguments:
+  - a pointer to an SQL database object
+  - the LinkPreviewFetcher used to fetch the page
+  - a string containing the type of the content ("Post" or "Message")
+  - a string containing the id of the content
+  - a string containing the text of the content
+
+The purpose of this function is to fetch the preview card of the first link of the text and to save it.
+
+The function returns an error if the fetch or the insertion fails.
+*/
+func saveLinkPreview(db *sql.DB, fetcher utils.LinkPreviewFetcher, sourceType, sourceId, text string) error {
+	link := utils.FindFirstURL(text)
+	if link == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), linkPreviewJobTimeout)
+	defer cancel()
+
+	preview, err := fetcher.Fetch(ctx, link)
+	if err != nil {
+		return err
+	}
+
+	preview.SourceType = sourceType
+	preview.SourceId = sourceId
+	preview.Url = link
+	preview.FetchDate = time.Now().UTC().Format(time.RFC3339)
+
+	return preview.InsertIntoDb(db)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - the Posts to send
+
+The purpose of this function is to add their preview card to the posts whose link has been fetched.
+*/
+func attachPostLinkPreviews(db *sql.DB, posts model.Posts) {
+	for i := range posts {
+		var preview model.LinkPreview
+		if err := preview.SelectFromDb(db, map[string]any{"SourceType": "Post", "SourceId": posts[i].Id}); err == nil {
+			posts[i].LinkPreview = &preview
+		}
+	}
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - the Messages to send
+
+The purpose of this function is to add their preview card to the messages whose link has been fetched.
+*/
+func attachMessageLinkPreviews(db *sql.DB, messages model.Messages) {
+	for i := range messages {
+		var preview model.LinkPreview
+		if err := preview.SelectFromDb(db, map[string]any{"SourceType": "Message", "SourceId": messages[i].Id}); err == nil {
+			messages[i].LinkPreview = &preview
+		}
+	}
+}
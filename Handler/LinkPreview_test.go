@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+	"time"
+)
+
+func TestLinkPreview(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<meta property="og:title" content="Preview title"><meta property="og:image" content="https://example.com/image.png">`))
+	}))
+	defer server.Close()
+
+	// The local server is only reachable with a fetcher allowing the private addresses
+	defaultFetcher := linkPreviewFetcher
+	linkPreviewFetcher = utils.NewLinkPreviewFetcher(true)
+	defer func() { linkPreviewFetcher = defaultFetcher }()
+
+	post := CreateReactionTarget(t, db)
+	jwt := utils.GenerateJWT(post.AuthorId)
+
+	success, rr := TryRequest(t, CreatePost(db), map[string]any{"AuthorId": jwt, "Text": "look at " + server.URL + "/page !", "CreationDate": "now", "Status": "public"})
+	if !success {
+		t.Fatalf("The post should be created : %s", rr.Body.String())
+	}
+
+	var created struct {
+		IdPost string
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	// The preview is fetched in the background
+	var preview model.LinkPreview
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(50 * time.Millisecond) {
+		if err = preview.SelectFromDb(db, map[string]any{"SourceType": "Post", "SourceId": created.IdPost}); err == nil {
+			break
+		}
+	}
+
+	if err != nil || preview.Title != "Preview title" || preview.Url != server.URL+"/page" {
+		t.Fatalf("The preview of the link should be saved : %+v %v", preview, err)
+	}
+
+	var bodyValue struct {
+		Posts model.Posts
+	}
+
+	_, rr = TryRequest(t, GetPost(db), map[string]any{"AuthorId": jwt})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	for _, sent := range bodyValue.Posts {
+		if (sent.Id == created.IdPost) != (sent.LinkPreview != nil) {
+			t.Fatalf("Only the post with a link should have a preview : %s", rr.Body.String())
+		}
+	}
+
+	// The preview is also sent with the bookmarked post
+	if success, rr := TryRequest(t, BookmarkPost(db), map[string]any{"UserId": jwt, "PostId": created.IdPost}); !success {
+		t.Fatalf("The post should be bookmarked : %s", rr.Body.String())
+	}
+
+	_, rr = TryRequest(t, GetBookmarks(db), map[string]any{"UserId": jwt})
+	var bookmarksValue struct {
+		Value model.Bookmarks
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &bookmarksValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(bookmarksValue.Value) != 1 || bookmarksValue.Value[0].Post.LinkPreview == nil || bookmarksValue.Value[0].Post.LinkPreview.Title != "Preview title" {
+		t.Fatalf("The preview should be attached to the bookmarked post : %s", rr.Body.String())
+	}
+
+	// The default fetcher refuses the local server
+	if err = saveLinkPreview(db, defaultFetcher, "Post", post.Id, server.URL); err == nil {
+		t.Fatal("A loopback address shouldn't be fetched by the default fetcher")
+	}
+}
@@ -0,0 +1,448 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+/*
+This function takes 1 argument:
+  - a string containing the end of a mute sent by a user
+
+The purpose of this function is to check the end of a mute, it must be in the "2006-01-02 15:04" format (UTC)
+and in the future. An empty end is a mute without end.
+
+The function returns true if the end is valid, false otherwise.
+*/
+func isValidMuteExpiration(expirationDate string) bool {
+	if expirationDate == "" {
+		return true
+	}
+
+	date, err := time.Parse(scheduleDateFormat, expirationDate)
+	return err == nil && date.After(time.Now().UTC())
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the mute of a user by the current user, until an optional expiration date.
+The follow is kept, but the posts of the muted user are hidden from the feed of the current user and their notifications are suppressed.
+The mute is silent: the muted user isn't notified. Muting a user again replaces the expiration date.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func MuteUser(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId      string `json:"UserId"`
+			MutedUserId string `json:"MutedUserId"`
+			// The end of the mute in the "2006-01-02 15:04" format (UTC), empty for a mute without end.
+			ExpirationDate string `json:"ExpirationDate"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [MuteUser] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [MuteUser] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if datas.MutedUserId == "" || datas.MutedUserId == userId {
+			nw.Error("You can't mute yourself")
+			log.Printf("[%s] [MuteUser] The user %s tried to mute themself", r.RemoteAddr, userId)
+			return
+		}
+
+		if !isValidMuteExpiration(datas.ExpirationDate) {
+			nw.Error("Invalid expiration date")
+			log.Printf("[%s] [MuteUser] Invalid expiration date %s", r.RemoteAddr, datas.ExpirationDate)
+			return
+		}
+
+		if err = utils.IfExistsInDB("UserInfo", db, map[string]any{"Id": datas.MutedUserId}); err != nil {
+			nw.Error("There is no user with this id")
+			log.Printf("[%s] [MuteUser] There is no user with the id %s : %v", r.RemoteAddr, datas.MutedUserId, err)
+			return
+		}
+
+		mute := model.UserMute{
+			UserId:         userId,
+			MutedUserId:    datas.MutedUserId,
+			CreationDate:   time.Now().UTC().Format(scheduleDateFormat),
+			ExpirationDate: datas.ExpirationDate,
+		}
+
+		if err = mute.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB")
+			log.Printf("[%s] [MuteUser] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "User muted successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [MuteUser] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the removal of the mute of a user by the current user.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UnmuteUser(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId      string `json:"UserId"`
+			MutedUserId string `json:"MutedUserId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [UnmuteUser] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UnmuteUser] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = utils.IfExistsInDB("UserMute", db, map[string]any{"UserId": userId, "MutedUserId": datas.MutedUserId}); err != nil {
+			nw.Error("This user isn't muted")
+			log.Printf("[%s] [UnmuteUser] The user %s didn't mute the user %s : %v", r.RemoteAddr, userId, datas.MutedUserId, err)
+			return
+		}
+
+		var mute model.UserMute
+		if err = mute.DeleteFromDb(db, map[string]any{"UserId": userId, "MutedUserId": datas.MutedUserId}); err != nil {
+			nw.Error("Internal Error: There is a problem during the remove in the DB")
+			log.Printf("[%s] [UnmuteUser] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "User unmuted successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [UnmuteUser] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the mute of a group by one of its members, until an optional expiration date.
+The notifications and the websocket messages of the posts and of the chat of the group are suppressed for the member,
+the announcements are still sent to be acknowledged. Muting a group again replaces the expiration date.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func MuteGroup(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+			// The end of the mute in the "2006-01-02 15:04" format (UTC), empty for a mute without end.
+			ExpirationDate string `json:"ExpirationDate"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [MuteGroup] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [MuteGroup] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if !isValidMuteExpiration(datas.ExpirationDate) {
+			nw.Error("Invalid expiration date")
+			log.Printf("[%s] [MuteGroup] Invalid expiration date %s", r.RemoteAddr, datas.ExpirationDate)
+			return
+		}
+
+		if !IsGroupMember(datas.GroupId, userId, db) {
+			nw.Error("Only the members can mute the group")
+			log.Printf("[%s] [MuteGroup] The user %s isn't a member of the group %s", r.RemoteAddr, userId, datas.GroupId)
+			return
+		}
+
+		mute := model.GroupMute{
+			UserId:         userId,
+			GroupId:        datas.GroupId,
+			CreationDate:   time.Now().UTC().Format(scheduleDateFormat),
+			ExpirationDate: datas.ExpirationDate,
+		}
+
+		if err = mute.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB")
+			log.Printf("[%s] [MuteGroup] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group muted successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [MuteGroup] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the removal of the mute of a group by the current user.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UnmuteGroup(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			GroupId string `json:"GroupId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [UnmuteGroup] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UnmuteGroup] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = utils.IfExistsInDB("GroupMute", db, map[string]any{"UserId": userId, "GroupId": datas.GroupId}); err != nil {
+			nw.Error("This group isn't muted")
+			log.Printf("[%s] [UnmuteGroup] The user %s didn't mute the group %s : %v", r.RemoteAddr, userId, datas.GroupId, err)
+			return
+		}
+
+		var mute model.GroupMute
+		if err = mute.DeleteFromDb(db, map[string]any{"UserId": userId, "GroupId": datas.GroupId}); err != nil {
+			nw.Error("Internal Error: There is a problem during the remove in the DB")
+			log.Printf("[%s] [UnmuteGroup] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Group unmuted successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [UnmuteGroup] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the users and the groups muted by the current user,
+the expired mutes are left out.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetMutes(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetMutes] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetMutes] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var userMutes model.UserMutes
+		if err = userMutes.SelectFromDb(db, map[string]any{"UserId": userId}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetMutes] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var groupMutes model.GroupMutes
+		if err = groupMutes.SelectFromDb(db, map[string]any{"UserId": userId}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetMutes] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		now := time.Now().UTC().Format(scheduleDateFormat)
+		activeUserMutes := model.UserMutes{}
+		for _, mute := range userMutes {
+			if mute.IsActive(now) {
+				activeUserMutes = append(activeUserMutes, mute)
+			}
+		}
+
+		activeGroupMutes := model.GroupMutes{}
+		for _, mute := range groupMutes {
+			if mute.IsActive(now) {
+				activeGroupMutes = append(activeGroupMutes, mute)
+			}
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Mutes getted successfully",
+			"Users":   activeUserMutes,
+			"Groups":  activeGroupMutes,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetMutes] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 4 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the user who would receive a notification
+  - a string containing the id of the user who causes the notification, empty if there is none
+  - a string containing the id of the group of the notification, empty if there is none
+
+The purpose of this function is to check if a notification must be suppressed because the receiver
+muted the user who causes it or the group where it happens, and the mute hasn't expired yet.
+
+The function returns true if the notification must be suppressed, false otherwise.
+*/
+func isMuted(db *sql.DB, userId, otherUserId, groupId string) bool {
+	now := time.Now().UTC().Format(scheduleDateFormat)
+
+	if otherUserId != "" && otherUserId != userId {
+		var mutes model.UserMutes
+		if err := mutes.SelectFromDb(db, map[string]any{"UserId": userId, "MutedUserId": otherUserId}); err == nil && len(mutes) == 1 && mutes[0].IsActive(now) {
+			return true
+		}
+	}
+
+	if groupId != "" {
+		var mutes model.GroupMutes
+		if err := mutes.SelectFromDb(db, map[string]any{"UserId": userId, "GroupId": groupId}); err == nil && len(mutes) == 1 && mutes[0].IsActive(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Posts of the feed to filter
+  - a string containing the id of the user who reads the feed
+
+The purpose of this function is to remove from the feed of a user the posts written by the users they muted.
+
+The function returns the remaining Posts, in the same order.
+*/
+func removeMutedPosts(db *sql.DB, posts model.Posts, userId string) model.Posts {
+	var mutes model.UserMutes
+	if err := mutes.SelectFromDb(db, map[string]any{"UserId": userId}); err != nil || len(mutes) == 0 {
+		return posts
+	}
+
+	now := time.Now().UTC().Format(scheduleDateFormat)
+	muted := map[string]bool{}
+	for _, mute := range mutes {
+		if mute.IsActive(now) {
+			muted[mute.MutedUserId] = true
+		}
+	}
+
+	return slices.DeleteFunc(posts, func(post model.Post) bool {
+		return muted[post.AuthorId]
+	})
+}
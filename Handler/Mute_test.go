@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"encoding/json"
+	"slices"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+	"time"
+)
+
+func TestMute(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"viewerId", "mutedId", "leaderId", "outsiderId"} {
+		CreateTestUser(t, db, id)
+	}
+	viewerJwt := utils.GenerateJWT("viewerId")
+	mutedJwt := utils.GenerateJWT("mutedId")
+	leaderJwt := utils.GenerateJWT("leaderId")
+
+	follow := model.Follower{Id: "followId", FollowerId: "viewerId", FollowedId: "mutedId"}
+	if err = follow.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, post := range []model.Post{
+		{Id: "mutedPostId", AuthorId: "mutedId", Text: "text", CreationDate: "now", Status: "public"},
+		{Id: "viewerPostId", AuthorId: "viewerId", Text: "text", CreationDate: "now", Status: "public"},
+	} {
+		if err = post.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | viewerId | mutedId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	countNotifications := func() int {
+		var notifications model.Notifications
+		if err := notifications.SelectFromDb(db, map[string]any{"UserId": "viewerId"}); err != nil {
+			t.Fatal(err)
+		}
+		return len(notifications)
+	}
+
+	getFeed := func() model.Posts {
+		_, rr := TryRequest(t, GetPost(db), map[string]any{"AuthorId": viewerJwt})
+		var postsValue struct {
+			Posts model.Posts
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &postsValue); err != nil {
+			t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+		}
+		return postsValue.Posts
+	}
+
+	for _, mute := range []map[string]any{
+		{"MutedUserId": "viewerId"},
+		{"MutedUserId": "unknownId"},
+		{"MutedUserId": "mutedId", "ExpirationDate": "2000-01-01 00:00"},
+		{"MutedUserId": "mutedId", "ExpirationDate": "tomorrow"},
+	} {
+		mute["UserId"] = viewerJwt
+		if success, _ := TryRequest(t, MuteUser(db), mute); success {
+			t.Fatalf("The mute should be refused : %v", mute)
+		}
+	}
+
+	if success, rr := TryRequest(t, MuteUser(db), map[string]any{"UserId": viewerJwt, "MutedUserId": "mutedId"}); !success {
+		t.Fatalf("The user should be muted : %s", rr.Body.String())
+	}
+
+	// The follow is kept, the muted user isn't told
+	if utils.IfExistsInDB("Follower", db, map[string]any{"Id": follow.Id}) != nil {
+		t.Fatal("The follow should be kept")
+	}
+
+	var mutedNotifications model.Notifications
+	if err = mutedNotifications.SelectFromDb(db, map[string]any{"UserId": "mutedId"}); err != nil || len(mutedNotifications) != 0 {
+		t.Fatalf("The muted user shouldn't be notified : %+v %v", mutedNotifications, err)
+	}
+
+	if feed := getFeed(); len(feed) != 1 || feed[0].Id != "viewerPostId" {
+		t.Fatalf("The posts of the muted user should be hidden from the feed : %+v", feed)
+	}
+
+	if success, rr := TryRequest(t, GetPost(db), map[string]any{"AuthorId": viewerJwt, "Id": "mutedPostId"}); !success {
+		t.Fatalf("A post of the muted user asked by its id should be sent : %s", rr.Body.String())
+	}
+
+	// The actions of the muted user don't notify the viewer
+	if success, rr := TryRequest(t, CreateComment(db), map[string]any{"AuthorId": mutedJwt, "PostId": "viewerPostId", "Text": "text", "CreationDate": "now"}); !success {
+		t.Fatalf("The muted user should comment : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, AddMessage(db), map[string]any{"SenderId": mutedJwt, "ReceiverId": "viewerId", "Message": "message", "CreationDate": "now"}); !success {
+		t.Fatalf("The muted user should send a message : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, AddMessage(db), map[string]any{"SenderId": mutedJwt, "GroupId": group.Id, "Message": "message", "CreationDate": "now"}); !success {
+		t.Fatalf("The muted user should write in the chat of the group : %s", rr.Body.String())
+	}
+
+	if count := countNotifications(); count != 0 {
+		t.Fatalf("The viewer shouldn't be notified by the muted user : %d notifications", count)
+	}
+
+	// The group is muted until tomorrow, the announcements are still sent
+	if success, _ := TryRequest(t, MuteGroup(db), map[string]any{"UserId": utils.GenerateJWT("outsiderId"), "GroupId": group.Id}); success {
+		t.Fatal("Only the members can mute the group")
+	}
+
+	tomorrow := time.Now().UTC().Add(24 * time.Hour).Format(scheduleDateFormat)
+	if success, rr := TryRequest(t, MuteGroup(db), map[string]any{"UserId": viewerJwt, "GroupId": group.Id, "ExpirationDate": tomorrow}); !success {
+		t.Fatalf("The member should mute the group : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, CreatePost(db), map[string]any{"AuthorId": leaderJwt, "Text": "post", "CreationDate": "now", "Status": "public", "IsGroup": group.Id}); !success {
+		t.Fatalf("The leader should post in the group : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, AddMessage(db), map[string]any{"SenderId": leaderJwt, "GroupId": group.Id, "Message": "message", "CreationDate": "now"}); !success {
+		t.Fatalf("The leader should write in the chat of the group : %s", rr.Body.String())
+	}
+
+	if count := countNotifications(); count != 0 {
+		t.Fatalf("The viewer shouldn't be notified by the muted group : %d notifications", count)
+	}
+
+	if success, rr := TryRequest(t, CreatePost(db), map[string]any{"AuthorId": leaderJwt, "Text": "announcement", "CreationDate": "now", "Status": "public", "IsGroup": group.Id, "IsAnnouncement": true}); !success {
+		t.Fatalf("The leader should publish an announcement : %s", rr.Body.String())
+	}
+
+	if count := countNotifications(); count != 1 {
+		t.Fatalf("The viewer should be notified of the announcement : %d notifications", count)
+	}
+
+	_, rr := TryRequest(t, GetMutes(db), map[string]any{"UserId": viewerJwt})
+	var mutesValue struct {
+		Users  model.UserMutes
+		Groups model.GroupMutes
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &mutesValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(mutesValue.Users) != 1 || mutesValue.Users[0].MutedUserId != "mutedId" || len(mutesValue.Groups) != 1 || mutesValue.Groups[0].ExpirationDate != tomorrow {
+		t.Fatalf("The mutes should be listed : %s", rr.Body.String())
+	}
+
+	// An expired mute doesn't apply anymore
+	expired := model.UserMute{UserId: "viewerId", MutedUserId: "mutedId", CreationDate: "2000-01-01 00:00", ExpirationDate: "2000-01-02 00:00"}
+	if err = expired.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if feed := getFeed(); !slices.ContainsFunc(feed, func(post model.Post) bool { return post.Id == "mutedPostId" }) {
+		t.Fatalf("The posts should be back in the feed once the mute has expired : %+v", feed)
+	}
+
+	if success, rr := TryRequest(t, UnmuteGroup(db), map[string]any{"UserId": viewerJwt, "GroupId": group.Id}); !success {
+		t.Fatalf("The member should unmute the group : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, UnmuteGroup(db), map[string]any{"UserId": viewerJwt, "GroupId": group.Id}); success {
+		t.Fatal("The group isn't muted anymore")
+	}
+
+	if success, rr := TryRequest(t, UnmuteUser(db), map[string]any{"UserId": viewerJwt, "MutedUserId": "mutedId"}); !success {
+		t.Fatalf("The viewer should unmute the user : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, AddMessage(db), map[string]any{"SenderId": mutedJwt, "ReceiverId": "viewerId", "Message": "message", "CreationDate": "now"}); !success {
+		t.Fatalf("The user should send a message : %s", rr.Body.String())
+	}
+
+	if count := countNotifications(); count != 2 {
+		t.Fatalf("The viewer should be notified once the user is unmuted : %d notifications", count)
+	}
+}
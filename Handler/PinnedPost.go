@@ -0,0 +1,450 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+// The maximum quantity of posts pinned on a profile or in a group.
+const maxPinnedPosts = 3
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the pinning of a post:
+  - a user can pin their own posts on their profile
+  - the leader of a group can pin the posts of the group
+
+The new pin is placed after the existing ones.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func PinPost(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			PostId string `json:"PostId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [PinPost] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [PinPost] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": datas.PostId}); err != nil {
+			nw.Error("There is no post with this id")
+			log.Printf("[%s] [PinPost] There is no post with the id %s : %v", r.RemoteAddr, datas.PostId, err)
+			return
+		}
+
+		ownerId, err := getPinOwner(db, userId, post)
+		if err != nil {
+			nw.Error("You can't pin this post")
+			log.Printf("[%s] [PinPost] The user %s can't pin the post %s : %v", r.RemoteAddr, userId, post.Id, err)
+			return
+		}
+
+		if post.IsGroup != "" && isGroupArchived(db, post.IsGroup) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [PinPost] The group %s is archived", r.RemoteAddr, post.IsGroup)
+			return
+		}
+
+		if err = utils.IfNotExistsInDB("PinnedPost", db, map[string]any{"PostId": post.Id}); err != nil {
+			nw.Error("The post is already pinned")
+			log.Printf("[%s] [PinPost] The post %s is already pinned", r.RemoteAddr, post.Id)
+			return
+		}
+
+		var pins model.PinnedPosts
+		if err = pins.SelectFromDb(db, map[string]any{"OwnerId": ownerId}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [PinPost] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if len(pins) >= maxPinnedPosts {
+			nw.Error("There are already too many pinned posts")
+			log.Printf("[%s] [PinPost] There are already %d posts pinned for %s", r.RemoteAddr, len(pins), ownerId)
+			return
+		}
+
+		pin := model.PinnedPost{
+			PostId:   post.Id,
+			OwnerId:  ownerId,
+			Position: len(pins),
+		}
+		if len(pins) > 0 {
+			pin.Position = pins[len(pins)-1].Position + 1
+		}
+
+		if err = pin.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB: " + err.Error())
+			log.Printf("[%s] [PinPost] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Post pinned successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [PinPost] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the unpinning of a post, with the same rights as for the pinning.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UnpinPost(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			PostId string `json:"PostId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [UnpinPost] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UnpinPost] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": datas.PostId}); err != nil {
+			nw.Error("There is no post with this id")
+			log.Printf("[%s] [UnpinPost] There is no post with the id %s : %v", r.RemoteAddr, datas.PostId, err)
+			return
+		}
+
+		ownerId, err := getPinOwner(db, userId, post)
+		if err != nil {
+			nw.Error("You can't unpin this post")
+			log.Printf("[%s] [UnpinPost] The user %s can't unpin the post %s : %v", r.RemoteAddr, userId, post.Id, err)
+			return
+		}
+
+		if post.IsGroup != "" && isGroupArchived(db, post.IsGroup) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [UnpinPost] The group %s is archived", r.RemoteAddr, post.IsGroup)
+			return
+		}
+
+		var pin model.PinnedPost
+		where := map[string]any{"PostId": post.Id, "OwnerId": ownerId}
+		if err = utils.IfExistsInDB("PinnedPost", db, where); err != nil {
+			nw.Error("The post isn't pinned")
+			log.Printf("[%s] [UnpinPost] The post %s isn't pinned : %v", r.RemoteAddr, post.Id, err)
+			return
+		}
+
+		if err = pin.DeleteFromDb(db, where); err != nil {
+			nw.Error("Internal Error: There is a problem during the delete in the DB")
+			log.Printf("[%s] [UnpinPost] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// The remaining pins are renumbered to keep the positions contiguous.
+		var pins model.PinnedPosts
+		if err = pins.SelectFromDb(db, map[string]any{"OwnerId": ownerId}); err == nil {
+			err = pins.UpdatePositions(db)
+		}
+		if err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [UnpinPost] Error during the update of the positions : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Post unpinned successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [UnpinPost] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the change of the order of the pinned posts.
+The request gives all the pinned posts of the profile of the user, or of the group when a GroupId is given, in the new order.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func ReorderPinnedPosts(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string   `json:"UserId"`
+			GroupId string   `json:"GroupId"`
+			PostIds []string `json:"PostIds"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [ReorderPinnedPosts] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [ReorderPinnedPosts] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		ownerId := userId
+		if datas.GroupId != "" {
+			if !isGroupLeader(db, datas.GroupId, userId) {
+				nw.Error("Only the leader of the group can reorder the pinned posts")
+				log.Printf("[%s] [ReorderPinnedPosts] The user %s isn't the leader of the group %s", r.RemoteAddr, userId, datas.GroupId)
+				return
+			}
+
+			if isGroupArchived(db, datas.GroupId) {
+				nw.Error("This group is archived")
+				log.Printf("[%s] [ReorderPinnedPosts] The group %s is archived", r.RemoteAddr, datas.GroupId)
+				return
+			}
+
+			ownerId = datas.GroupId
+		}
+
+		var pins model.PinnedPosts
+		if err = pins.SelectFromDb(db, map[string]any{"OwnerId": ownerId}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [ReorderPinnedPosts] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// The new order must contain exactly the pinned posts, each one once.
+		var ordered model.PinnedPosts
+		for _, postId := range datas.PostIds {
+			index := slices.IndexFunc(pins, func(pin model.PinnedPost) bool { return pin.PostId == postId })
+			if index == -1 || slices.ContainsFunc(ordered, func(pin model.PinnedPost) bool { return pin.PostId == postId }) {
+				nw.Error("Invalid order")
+				log.Printf("[%s] [ReorderPinnedPosts] The post %s isn't pinned or is given twice", r.RemoteAddr, postId)
+				return
+			}
+
+			ordered = append(ordered, pins[index])
+		}
+
+		if len(ordered) != len(pins) {
+			nw.Error("Invalid order")
+			log.Printf("[%s] [ReorderPinnedPosts] %d posts given for %d pinned posts", r.RemoteAddr, len(ordered), len(pins))
+			return
+		}
+
+		if err = ordered.UpdatePositions(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [ReorderPinnedPosts] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Pinned posts reordered successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [ReorderPinnedPosts] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the posts pinned on the profile of a user (OwnerId is a user id),
+in the order chosen by the user. The posts the requester can't see are not sent.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetPinnedPosts(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId  string `json:"UserId"`
+			OwnerId string `json:"OwnerId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetPinnedPosts] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetPinnedPosts] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Without OwnerId, the requester gets the pinned posts of their own profile.
+		if datas.OwnerId == "" {
+			datas.OwnerId = userId
+		}
+
+		posts, err := getVisiblePinnedPosts(db, datas.OwnerId, userId)
+		if err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetPinnedPosts] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Pinned posts getted successfully",
+
+			"Posts": posts,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetPinnedPosts] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the user who wants to pin the post
+  - a Post object, which is the post to pin
+
+The purpose of this function is to find where the post is pinned and to check the rights of the user:
+a post outside of a group is pinned on the profile of its author, a group post is pinned in its group by the leader.
+
+The function returns the id of the owner of the pin (the user or the group) and an error if the user can't pin the post.
+*/
+func getPinOwner(db *sql.DB, userId string, post model.Post) (string, error) {
+	if post.IsGroup != "" {
+		if !isGroupLeader(db, post.IsGroup, userId) {
+			return "", errors.New("only the leader of the group can pin a group post")
+		}
+
+		return post.IsGroup, nil
+	}
+
+	if post.AuthorId != userId {
+		return "", errors.New("only the author can pin a post on their profile")
+	}
+
+	return userId, nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the group
+  - a string containing the id of the user
+
+The purpose of this function is to check if the user is the leader of the group.
+
+The function returns true if the user is the leader.
+*/
+func isGroupLeader(db *sql.DB, groupId, userId string) bool {
+	var group model.Group
+	if err := group.SelectFromDb(db, map[string]any{"Id": groupId}); err != nil {
+		return false
+	}
+
+	return group.LeaderId == userId
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the owner of the pins (a user or a group)
+  - a string containing the id of the user who wants to see the pinned posts
+
+The purpose of this function is to get the pinned posts of a profile or a group, in the chosen order,
+without the posts the viewer isn't allowed to see.
+
+The function returns the posts and an error if the fetch fails.
+*/
+func getVisiblePinnedPosts(db *sql.DB, ownerId, viewerId string) (model.Posts, error) {
+	var pins model.PinnedPosts
+	if err := pins.SelectFromDb(db, map[string]any{"OwnerId": ownerId}); err != nil {
+		return nil, err
+	}
+
+	posts := model.Posts{}
+	for _, pin := range pins {
+		var post model.Post
+		if err := post.SelectFromDb(db, map[string]any{"Id": pin.PostId}); err != nil {
+			return nil, err
+		}
+
+		if CanSeePost(viewerId, post, db) {
+			posts = append(posts, post)
+		}
+	}
+	attachPostLinkPreviews(db, posts)
+	attachPostPolls(db, posts)
+
+	return posts, nil
+}
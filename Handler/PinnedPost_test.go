@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestPinnedPosts(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+	CreateTestUser(t, db, "otherId")
+	jwt := utils.GenerateJWT(post.AuthorId)
+
+	for _, other := range []model.Post{
+		{Id: "secondId", AuthorId: post.AuthorId, Text: "second", CreationDate: "now", Status: "public"},
+		{Id: "privateId", AuthorId: post.AuthorId, Text: "private", CreationDate: "now", Status: "private"},
+		{Id: "fourthId", AuthorId: post.AuthorId, Text: "fourth", CreationDate: "now", Status: "public"},
+	} {
+		if err = other.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if success, _ := TryRequest(t, PinPost(db), map[string]any{"UserId": utils.GenerateJWT("otherId"), "PostId": post.Id}); success {
+		t.Fatal("A user can't pin the post of another user")
+	}
+
+	for _, postId := range []string{post.Id, "secondId", "privateId"} {
+		if success, rr := TryRequest(t, PinPost(db), map[string]any{"UserId": jwt, "PostId": postId}); !success {
+			t.Fatalf("The post %s should be pinned : %s", postId, rr.Body.String())
+		}
+	}
+
+	if success, _ := TryRequest(t, PinPost(db), map[string]any{"UserId": jwt, "PostId": "fourthId"}); success {
+		t.Fatalf("Only %d posts can be pinned", maxPinnedPosts)
+	}
+
+	if success, _ := TryRequest(t, ReorderPinnedPosts(db), map[string]any{"UserId": jwt, "PostIds": []string{"secondId", post.Id}}); success {
+		t.Fatal("The new order must contain all the pinned posts")
+	}
+
+	if success, rr := TryRequest(t, ReorderPinnedPosts(db), map[string]any{"UserId": jwt, "PostIds": []string{"privateId", "secondId", post.Id}}); !success {
+		t.Fatalf("The pinned posts should be reordered : %s", rr.Body.String())
+	}
+
+	var bodyValue struct {
+		Posts model.Posts
+	}
+
+	// The private post is hidden from a user who doesn't follow the author
+	_, rr := TryRequest(t, GetPinnedPosts(db), map[string]any{"UserId": utils.GenerateJWT("otherId"), "OwnerId": post.AuthorId})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(bodyValue.Posts) != 2 || bodyValue.Posts[0].Id != "secondId" || bodyValue.Posts[1].Id != post.Id {
+		t.Fatalf("The visible pinned posts are not the good ones : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, UnpinPost(db), map[string]any{"UserId": jwt, "PostId": "privateId"}); !success {
+		t.Fatalf("The post should be unpinned : %s", rr.Body.String())
+	}
+
+	var pins model.PinnedPosts
+	if err = pins.SelectFromDb(db, map[string]any{"OwnerId": post.AuthorId}); err != nil || len(pins) != 2 || pins[0].PostId != "secondId" || pins[0].Position != 0 || pins[1].Position != 1 {
+		t.Fatalf("The positions should be renumbered after an unpin : %v %v", pins, err)
+	}
+}
+
+func TestPinGroupPost(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+	CreateTestUser(t, db, "leaderId")
+
+	group := model.Group{
+		Id:           "groupId",
+		LeaderId:     "leaderId",
+		MemberIds:    post.AuthorId,
+		GroupName:    "group",
+		CreationDate: "now",
+	}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	groupPost := model.Post{Id: "groupPostId", AuthorId: post.AuthorId, Text: "group", CreationDate: "now", Status: "public", IsGroup: group.Id}
+	if err = groupPost.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the leader can pin a group post, even for its author
+	if success, _ := TryRequest(t, PinPost(db), map[string]any{"UserId": utils.GenerateJWT(post.AuthorId), "PostId": groupPost.Id}); success {
+		t.Fatal("A member can't pin a group post")
+	}
+
+	if success, rr := TryRequest(t, PinPost(db), map[string]any{"UserId": utils.GenerateJWT(group.LeaderId), "PostId": groupPost.Id}); !success {
+		t.Fatalf("The leader should pin the group post : %s", rr.Body.String())
+	}
+
+	var bodyValue struct {
+		PinnedPosts model.Posts
+	}
+
+	_, rr := TryRequest(t, GetGroupsPosts(db), map[string]any{"UserId": utils.GenerateJWT(post.AuthorId), "GroupId": group.Id})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(bodyValue.PinnedPosts) != 1 || bodyValue.PinnedPosts[0].Id != groupPost.Id {
+		t.Fatalf("The group posts should contain the pinned post : %s", rr.Body.String())
+	}
+}
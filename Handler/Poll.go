@@ -0,0 +1,437 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+
+	"github.com/gofrs/uuid"
+)
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the vote of a user for the poll of a post.
+A user votes only once, with one option for a single choice poll or several options for a multiple choice poll.
+When the post is a group post, the new results are sent to the connected members of the group.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func VotePoll(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId    string   `json:"UserId"`
+			PostId    string   `json:"PostId"`
+			OptionIds []string `json:"OptionIds"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [VotePoll] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [VotePoll] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		post, poll, err := getVisiblePoll(db, datas.PostId, userId)
+		if err != nil {
+			nw.Error("There is no poll for this post")
+			log.Printf("[%s] [VotePoll] There is no poll for the post %s : %v", r.RemoteAddr, datas.PostId, err)
+			return
+		}
+
+		if post.IsGroup != "" && isGroupArchived(db, post.IsGroup) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [VotePoll] The group %s is archived", r.RemoteAddr, post.IsGroup)
+			return
+		}
+
+		if isPollClosed(poll) {
+			nw.Error("The poll is closed")
+			log.Printf("[%s] [VotePoll] The poll %s is closed", r.RemoteAddr, poll.Id)
+			return
+		}
+
+		// We check the chosen options before saving anything.
+		slices.Sort(datas.OptionIds)
+		datas.OptionIds = slices.Compact(datas.OptionIds)
+		if len(datas.OptionIds) == 0 || (!poll.MultipleChoice && len(datas.OptionIds) > 1) {
+			nw.Error("Invalid quantity of options")
+			log.Printf("[%s] [VotePoll] Invalid quantity of options : %d", r.RemoteAddr, len(datas.OptionIds))
+			return
+		}
+
+		var votes model.PollVotes
+		for _, optionId := range datas.OptionIds {
+			if !slices.ContainsFunc(poll.Options, func(option model.PollOption) bool { return option.Id == optionId }) {
+				nw.Error("Invalid option")
+				log.Printf("[%s] [VotePoll] The option %s isn't in the poll %s", r.RemoteAddr, optionId, poll.Id)
+				return
+			}
+
+			votes = append(votes, model.PollVote{PollId: poll.Id, OptionId: optionId, UserId: userId})
+		}
+
+		if err = votes.InsertIntoDb(db); err != nil {
+			nw.Error("You can't vote for this poll")
+			log.Printf("[%s] [VotePoll] Error during the vote of %s : %v", r.RemoteAddr, userId, err)
+			return
+		}
+
+		results, err := getPollResults(db, poll, userId)
+		if err != nil {
+			nw.Error("Error during the fetch of the results")
+			log.Printf("[%s] [VotePoll] Error during the fetch of the results : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if post.IsGroup != "" {
+			sendPollResultsToGroup(db, post, results)
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Vote saved successfully",
+
+			"Value": results,
+		})
+		if err != nil {
+			log.Printf("[%s] [VotePoll] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the results of the poll of a post.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetPollResults(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			PostId string `json:"PostId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetPollResults] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetPollResults] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		_, poll, err := getVisiblePoll(db, datas.PostId, userId)
+		if err != nil {
+			nw.Error("There is no poll for this post")
+			log.Printf("[%s] [GetPollResults] There is no poll for the post %s : %v", r.RemoteAddr, datas.PostId, err)
+			return
+		}
+
+		results, err := getPollResults(db, poll, userId)
+		if err != nil {
+			nw.Error("Error during the fetch of the results")
+			log.Printf("[%s] [GetPollResults] Error during the fetch of the results : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Poll results getted successfully",
+
+			"Value": results,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetPollResults] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to the Poll object sent with a new post
+
+The purpose of this function is to validate a new poll and to prepare it for the insertion:
+  - a poll has between 2 and 10 options, each one with a text of 100 characters maximum
+  - the closing date is optional but must be in the future
+  - the ids and the positions of the options are generated
+
+The function returns an error describing the first invalid field, or nil.
+*/
+func checkPoll(poll *model.Poll) error {
+	if len(poll.Options) < 2 || len(poll.Options) > 10 {
+		return errors.New("a poll must have between 2 and 10 options")
+	}
+
+	if poll.ClosingDate != "" {
+		closingDate, err := time.Parse(scheduleDateFormat, poll.ClosingDate)
+		if err != nil {
+			return errors.New("invalid closing date")
+		}
+
+		if !closingDate.After(time.Now()) {
+			return errors.New("the closing date must be in the future")
+		}
+	}
+
+	for i := range poll.Options {
+		if poll.Options[i].Text == "" || len(poll.Options[i].Text) > 100 {
+			return errors.New("invalid option text")
+		}
+
+		uid, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		poll.Options[i].Id = uid.String()
+		poll.Options[i].Position = i
+	}
+
+	return nil
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - a Post object, which has just been inserted with its checked poll
+
+The purpose of this function is to save the poll of a new post.
+
+The function returns an error if the insertion fails.
+*/
+func createPostPoll(db *sql.DB, post model.Post) error {
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	post.Poll.Id = uid.String()
+	post.Poll.PostId = post.Id
+
+	return post.Poll.InsertIntoDb(db)
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the post
+  - a string containing the id of the user
+
+The purpose of this function is to fetch a post and its poll, if the user is allowed to see the post.
+
+The function returns the post, the poll and an error if the post can't be seen or has no poll.
+*/
+func getVisiblePoll(db *sql.DB, postId, userId string) (model.Post, model.Poll, error) {
+	var post model.Post
+	if err := post.SelectFromDb(db, map[string]any{"Id": postId}); err != nil {
+		return model.Post{}, model.Poll{}, err
+	}
+
+	if !CanSeePost(userId, post, db) {
+		return model.Post{}, model.Poll{}, errors.New("the user can't see the post")
+	}
+
+	var poll model.Poll
+	if err := poll.SelectFromDb(db, map[string]any{"PostId": post.Id}); err != nil {
+		return model.Post{}, model.Poll{}, err
+	}
+
+	return post, poll, nil
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - the Posts to send
+
+The purpose of this function is to add their poll, with its options, to the posts that have one.
+The votes are still asked apart with GetPollResults.
+*/
+func attachPostPolls(db *sql.DB, posts model.Posts) {
+	for i := range posts {
+		var poll model.Poll
+		if err := poll.SelectFromDb(db, map[string]any{"PostId": posts[i].Id}); err == nil {
+			posts[i].Poll = &poll
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a Poll object
+
+The purpose of this function is to check if the closing date of the poll has been reached.
+
+The function returns true if the poll is closed.
+*/
+func isPollClosed(poll model.Poll) bool {
+	if poll.ClosingDate == "" {
+		return false
+	}
+
+	closingDate, err := time.Parse(scheduleDateFormat, poll.ClosingDate)
+	return err == nil && !time.Now().Before(closingDate)
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a Poll object, with its options
+  - a string containing the id of the user who asks for the results (can be empty)
+
+The purpose of this function is to count the votes of each option of the poll.
+The voters are only given when the poll isn't anonymous.
+
+The function returns the results of the poll and an error if the votes can't be fetched.
+*/
+func getPollResults(db *sql.DB, poll model.Poll, userId string) (model.PollResults, error) {
+	var votes model.PollVotes
+	if err := votes.SelectFromDb(db, map[string]any{"PollId": poll.Id}); err != nil {
+		return model.PollResults{}, err
+	}
+
+	results := model.PollResults{
+		Poll:     poll,
+		Closed:   isPollClosed(poll),
+		Counts:   make(map[string]int, len(poll.Options)),
+		Voters:   make(map[string][]string, len(poll.Options)),
+		UserVote: []string{},
+	}
+
+	// Every option is present, even without votes.
+	for _, option := range poll.Options {
+		results.Counts[option.Id] = 0
+		results.Voters[option.Id] = []string{}
+	}
+
+	var voters []string
+	for _, vote := range votes {
+		results.Counts[vote.OptionId]++
+
+		if !poll.Anonymous {
+			results.Voters[vote.OptionId] = append(results.Voters[vote.OptionId], vote.UserId)
+		}
+
+		if vote.UserId == userId {
+			results.UserVote = append(results.UserVote, vote.OptionId)
+		}
+
+		if !slices.Contains(voters, vote.UserId) {
+			voters = append(voters, vote.UserId)
+		}
+	}
+	results.TotalVoters = len(voters)
+
+	return results, nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a Post object, which is the group post of the poll
+  - the new results of the poll
+
+The purpose of this function is to send the new results of a poll to the connected members of the group
+who haven't muted it. The vote is already saved, so the errors are only logged.
+*/
+func sendPollResultsToGroup(db *sql.DB, post model.Post, results model.PollResults) {
+	var group model.Group
+	if err := group.SelectFromDb(db, map[string]any{"Id": post.IsGroup}); err != nil {
+		log.Printf("[sendPollResultsToGroup] Error during the fetching of the group : %v", err)
+		return
+	}
+
+	// The vote of the user who voted isn't sent to the other members.
+	results.UserVote = []string{}
+
+	var WebsocketMessage struct {
+		Type        string
+		GroupId     string
+		PostId      string
+		Description string
+		Value       model.PollResults
+	}
+
+	WebsocketMessage.Type = "PollVote"
+	WebsocketMessage.GroupId = group.Id
+	WebsocketMessage.PostId = post.Id
+	WebsocketMessage.Description = fmt.Sprintf("A new vote has been done for a poll of the group %s", group.GroupName)
+	WebsocketMessage.Value = results
+
+	// The mutes are read before locking the websockets.
+	receiverIds := pollResultsReceivers(db, group)
+
+	model.ConnectedWebSocket.Mu.Lock()
+	defer model.ConnectedWebSocket.Mu.Unlock()
+
+	for _, memberId := range receiverIds {
+		if conn, isOk := model.ConnectedWebSocket.Conn[memberId]; isOk {
+			if err := conn.WriteJSON(WebsocketMessage); err != nil {
+				log.Printf("[sendPollResultsToGroup] Error during the communication with the websocket of %s : %v", memberId, err)
+			}
+		}
+	}
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - a Group object, which is the group of the poll
+
+The purpose of this function is to find the members who receive the live results of the polls of the group,
+the members who muted the group are skipped like for the notifications of the new posts.
+
+The function returns the ids of the members.
+*/
+func pollResultsReceivers(db *sql.DB, group model.Group) []string {
+	group.SplitMembers()
+
+	receiverIds := []string{}
+	for _, memberId := range group.SplitMemberIds {
+		if !isMuted(db, memberId, "", group.Id) {
+			receiverIds = append(receiverIds, memberId)
+		}
+	}
+
+	return receiverIds
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func TestVotePoll(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+	jwt := utils.GenerateJWT(post.AuthorId)
+
+	newPost := map[string]any{
+		"AuthorId":     jwt,
+		"Text":         "Which one ?",
+		"CreationDate": "now",
+		"Status":       "public",
+		"Poll": map[string]any{
+			"Anonymous": true,
+			"Options":   []map[string]any{{"Text": "first"}},
+		},
+	}
+
+	if success, _ := TryRequest(t, CreatePost(db), newPost); success {
+		t.Fatal("A poll with only one option should be refused")
+	}
+
+	newPost["Poll"].(map[string]any)["Options"] = []map[string]any{{"Text": "first"}, {"Text": "second"}}
+
+	success, rr := TryRequest(t, CreatePost(db), newPost)
+	if !success {
+		t.Fatalf("The post with the poll should be created : %s", rr.Body.String())
+	}
+
+	var created struct {
+		IdPost string
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	var poll model.Poll
+	if err = poll.SelectFromDb(db, map[string]any{"PostId": created.IdPost}); err != nil || len(poll.Options) != 2 || poll.Options[0].Text != "first" {
+		t.Fatalf("The poll hasn't been saved : %v %v", poll, err)
+	}
+
+	// The poll is sent with the post
+	_, rr = TryRequest(t, GetPost(db), map[string]any{"AuthorId": jwt, "Id": created.IdPost})
+	var postsValue struct {
+		Posts model.Posts
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &postsValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(postsValue.Posts) != 1 || postsValue.Posts[0].Poll == nil || postsValue.Posts[0].Poll.Id != poll.Id || len(postsValue.Posts[0].Poll.Options) != 2 {
+		t.Fatalf("The poll should be attached to the post : %s", rr.Body.String())
+	}
+
+	// The poll is also sent with the pinned and the bookmarked post
+	if success, rr := TryRequest(t, PinPost(db), map[string]any{"UserId": jwt, "PostId": created.IdPost}); !success {
+		t.Fatalf("The post should be pinned : %s", rr.Body.String())
+	}
+
+	_, rr = TryRequest(t, GetPinnedPosts(db), map[string]any{"UserId": jwt, "OwnerId": post.AuthorId})
+	if err = json.Unmarshal(rr.Body.Bytes(), &postsValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(postsValue.Posts) != 1 || postsValue.Posts[0].Poll == nil || postsValue.Posts[0].Poll.Id != poll.Id {
+		t.Fatalf("The poll should be attached to the pinned post : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, BookmarkPost(db), map[string]any{"UserId": jwt, "PostId": created.IdPost}); !success {
+		t.Fatalf("The post should be bookmarked : %s", rr.Body.String())
+	}
+
+	_, rr = TryRequest(t, GetBookmarks(db), map[string]any{"UserId": jwt})
+	var bookmarksValue struct {
+		Value model.Bookmarks
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &bookmarksValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(bookmarksValue.Value) != 1 || bookmarksValue.Value[0].Post.Poll == nil || bookmarksValue.Value[0].Post.Poll.Id != poll.Id {
+		t.Fatalf("The poll should be attached to the bookmarked post : %s", rr.Body.String())
+	}
+
+	// The poll is a single choice poll
+	if success, _ := TryRequest(t, VotePoll(db), map[string]any{"UserId": jwt, "PostId": created.IdPost, "OptionIds": []string{poll.Options[0].Id, poll.Options[1].Id}}); success {
+		t.Fatal("Only one option can be chosen in a single choice poll")
+	}
+
+	if success, _ := TryRequest(t, VotePoll(db), map[string]any{"UserId": jwt, "PostId": created.IdPost, "OptionIds": []string{"unknown"}}); success {
+		t.Fatal("An option of another poll can't be chosen")
+	}
+
+	if success, rr := TryRequest(t, VotePoll(db), map[string]any{"UserId": jwt, "PostId": created.IdPost, "OptionIds": []string{poll.Options[1].Id}}); !success {
+		t.Fatalf("The vote should be saved : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, VotePoll(db), map[string]any{"UserId": jwt, "PostId": created.IdPost, "OptionIds": []string{poll.Options[0].Id}}); success {
+		t.Fatal("A user can only vote once")
+	}
+
+	var bodyValue struct {
+		Value model.PollResults
+	}
+
+	_, rr = TryRequest(t, GetPollResults(db), map[string]any{"UserId": jwt, "PostId": created.IdPost})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	results := bodyValue.Value
+	if results.TotalVoters != 1 || results.Counts[poll.Options[1].Id] != 1 || results.Counts[poll.Options[0].Id] != 0 || len(results.UserVote) != 1 {
+		t.Fatalf("The results are not the good ones : %s", rr.Body.String())
+	}
+
+	// The poll is anonymous so the voters are hidden
+	if len(results.Voters[poll.Options[1].Id]) != 0 {
+		t.Fatalf("The voters of an anonymous poll shouldn't be sent : %s", rr.Body.String())
+	}
+}
+
+func TestClosedPoll(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	// The poll is inserted directly because a poll can't be created already closed
+	poll := model.Poll{
+		Id:             "pollId",
+		PostId:         post.Id,
+		MultipleChoice: true,
+		ClosingDate:    "2000-01-01 10:00",
+		Options:        model.PollOptions{{Id: "first", Text: "first"}, {Id: "second", Position: 1, Text: "second"}},
+	}
+	if err = poll.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, VotePoll(db), map[string]any{"UserId": utils.GenerateJWT(post.AuthorId), "PostId": post.Id, "OptionIds": []string{"first", "second"}}); success {
+		t.Fatal("A closed poll can't receive votes")
+	}
+
+	if err = checkPoll(&model.Poll{ClosingDate: "2000-01-01 10:00", Options: model.PollOptions{{Text: "first"}, {Text: "second"}}}); err == nil {
+		t.Fatal("A poll can't be created with a closing date in the past")
+	}
+}
+
+func TestPollResultsReceivers(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"leaderId", "mutedId"} {
+		CreateTestUser(t, db, id)
+	}
+
+	group := model.Group{Id: "groupId", LeaderId: "leaderId", MemberIds: "leaderId | mutedId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, MuteGroup(db), map[string]any{"UserId": utils.GenerateJWT("mutedId"), "GroupId": group.Id}); !success {
+		t.Fatalf("The member should mute the group : %s", rr.Body.String())
+	}
+
+	if err = group.SelectFromDb(db, map[string]any{"Id": group.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The member who muted the group doesn't receive the live results
+	if receiverIds := pollResultsReceivers(db, group); len(receiverIds) != 1 || receiverIds[0] != "leaderId" {
+		t.Fatalf("Only the members who didn't mute the group should receive the results : %v", receiverIds)
+	}
+}
@@ -60,6 +60,33 @@ func CreatePost(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
+		if err = checkContentWarning(post.ContentWarning); err != nil {
+			nw.Error("Invalid content warning")
+			log.Printf("[%s] [CreatePost] Invalid content warning : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = checkAnnouncement(db, post); err != nil {
+			nw.Error(err.Error())
+			log.Printf("[%s] [CreatePost] Invalid announcement : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if post.IsGroup != "" && isGroupArchived(db, post.IsGroup) {
+			nw.Error("This group is archived")
+			log.Printf("[%s] [CreatePost] The group %s is archived", r.RemoteAddr, post.IsGroup)
+			return
+		}
+
+		// Validate the optional poll before saving anything.
+		if post.Poll != nil {
+			if err = checkPoll(post.Poll); err != nil {
+				nw.Error("Invalid poll : " + err.Error())
+				log.Printf("[%s] [CreatePost] Invalid poll : %v", r.RemoteAddr, err)
+				return
+			}
+		}
+
 		// Generate a new UUID for the post.
 		uid, err := uuid.NewV7()
 		if err != nil {
@@ -79,91 +106,236 @@ func CreatePost(db *sql.DB) http.HandlerFunc {
 			return
 		}
 
-		if post.IsGroup != "" {
-			var group model.Group
-			if err = group.SelectFromDb(db, map[string]any{"Id": post.IsGroup}); err != nil {
-				nw.Error("There is a problem during the fetching of the group") // Handle UUID generation error
-				log.Printf("[%s] [CreatePost] There is a problem during the fetching of the group : %s", r.RemoteAddr, err)
+		if post.Poll != nil {
+			if err = createPostPoll(db, post); err != nil {
+				// A post mustn't stay without the poll sent with it.
+				post.DeleteFromDb(db, map[string]any{"Id": post.Id})
+
+				nw.Error("Internal Error: There is a problem during the push of the poll in the DB: " + err.Error())
+				log.Printf("[%s] [CreatePost] %s", r.RemoteAddr, err.Error())
 				return
 			}
+		}
 
-			var userData model.Register
-			if err = userData.SelectFromDb(db, map[string]any{"Id": post.AuthorId}); err != nil {
-				nw.Error("There is a problem during the fetching of the user") // Handle UUID generation error
-				log.Printf("[%s] [CreatePost] There is a problem during the fetching of the user : %s", r.RemoteAddr, err)
+		// Notify the members of the group if the post is a group post.
+		if err = NotifyGroupPost(db, post); err != nil {
+			nw.Error("There is a probleme during the sending of the notifications")
+			log.Printf("[%s] [CreatePost] There is a probleme during the sending of the notifications : %s", r.RemoteAddr, err)
+			return
+		}
+
+		// The preview of the first link of the text is fetched in the background.
+		StartLinkPreview(db, "Post", post.Id, post.Text)
+
+		// Set response headers for JSON content.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Encode the response JSON for successful post creation.
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Post created successfully",
+			// Return the newly created post ID.
+			"IdPost": post.Id,
+		})
+		if err != nil {
+			log.Printf("[%s] [CreatePost] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+RepostPost handles the sharing of the public post of another user into the feed of the user.
+
+It takes a pointer to an SQL database as an argument and returns an http.HandlerFunc.
+
+The repost is a new post referencing the original post with RepostOf, with an optional text for a quote post.
+The repost count of the original post is incremented and its author is notified.
+The private and group posts can't be reposted.
+*/
+func RepostPost(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Initialize a new ResponseWriter for structured error handling.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Create a variable to hold the repost data.
+		var post model.Post
+
+		// Decode the incoming JSON request body into the post structure.
+		if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [RepostPost] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Decrypt the Author ID from the JWT.
+		decryptAuthorId, err := utils.DecryptJWT(post.AuthorId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [RepostPost] Error during the decrypt of the JWT: %v", r.RemoteAddr, err)
+			return
+		}
+		post.AuthorId = decryptAuthorId
+
+		// A repost is always shared in the feed of the user, with the public status by default.
+		post.IsGroup = ""
+		if post.Status == "" {
+			post.Status = "public"
+		}
+
+		if post.RepostOf == "" || post.CreationDate == "" ||
+			(post.Status != "public" && post.Status != "private" && strings.Split(post.Status, " | ")[0] != "almost private") {
+			nw.Error("There is an empty field")
+			log.Printf("[%s] [RepostPost] There is an empty field", r.RemoteAddr)
+			return
+		}
+
+		if err = checkContentWarning(post.ContentWarning); err != nil {
+			nw.Error("Invalid content warning")
+			log.Printf("[%s] [RepostPost] Invalid content warning : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var original model.Post
+		if err = original.SelectFromDb(db, map[string]any{"Id": post.RepostOf}); err != nil {
+			nw.Error("There is no post with this id")
+			log.Printf("[%s] [RepostPost] There is no post with the id %s : %v", r.RemoteAddr, post.RepostOf, err)
+			return
+		}
+
+		// Reposting a repost without text shares the original post.
+		if original.RepostOf != "" && original.Text == "" {
+			if err = original.SelectFromDb(db, map[string]any{"Id": original.RepostOf}); err != nil {
+				nw.Error("There is no post with this id")
+				log.Printf("[%s] [RepostPost] There is no post with the id %s : %v", r.RemoteAddr, original.RepostOf, err)
 				return
 			}
+			post.RepostOf = original.Id
+		}
 
-			var userDataName string
-			if userData.Username == "" {
-				userDataName = userData.FirstName + " " + userData.LastName
-			} else {
-				userDataName = userData.Username
-			}
+		if original.Status != "public" || original.IsGroup != "" {
+			nw.Error("Only the public posts can be reposted")
+			log.Printf("[%s] [RepostPost] The post %s isn't public", r.RemoteAddr, original.Id)
+			return
+		}
 
-			group.SplitMembers()
-			for i := range group.SplitMemberIds {
+		if original.AuthorId == post.AuthorId {
+			nw.Error("You can't repost your own post")
+			log.Printf("[%s] [RepostPost] The user %s tried to repost their own post", r.RemoteAddr, post.AuthorId)
+			return
+		}
 
-				notifId, err := uuid.NewV7()
-				if err != nil {
-					nw.Error("There is a problem with the generation of the uuid") // Handle UUID generation error
-					log.Printf("[%s] [CreatePost] There is a problem with the generation of the uuid : %s", r.RemoteAddr, err)
-					return
-				}
+		// A block applies in both directions, so neither user can share the posts of the other.
+		if isBlocked(db, post.AuthorId, original.AuthorId) {
+			nw.Error("You can't repost this post")
+			log.Printf("[%s] [RepostPost] There is a block between the users %s and %s", r.RemoteAddr, post.AuthorId, original.AuthorId)
+			return
+		}
 
-				notification := model.Notification{
-					Id:          notifId.String(),
-					UserId:      group.SplitMemberIds[i],
-					Status:      "Group",
-					Description: fmt.Sprintf("A new post as been send by %s for the group %s", userDataName, group.GroupName),
-					GroupId:     group.Id,
-					OtherUserId: "",
-				}
+		// Generate a new UUID for the post.
+		uid, err := uuid.NewV7()
+		if err != nil {
+			nw.Error("There is a problem with the generation of the uuid")
+			log.Printf("[%s] [RepostPost] There is a problem with the generation of the uuid: %s", r.RemoteAddr, err)
+			return
+		}
+		post.Id = uid.String()
 
-				if err = notification.InsertIntoDb(db); err != nil {
-					nw.Error("There is a probleme during the sending of a notification")
-					log.Printf("[%s] [CreatePost] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
-					return
-				}
+		if err = post.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB: " + err.Error())
+			log.Printf("[%s] [RepostPost] %s", r.RemoteAddr, err.Error())
+			return
+		}
 
-				model.ConnectedWebSocket.Mu.Lock()
-				_, isOk := model.ConnectedWebSocket.Conn[group.SplitMemberIds[i]]
-				if isOk {
-					var WebsocketMessage struct {
-						Type        string
-						GroupId     string
-						Description string
-						Value       model.Post
-					}
-
-					WebsocketMessage.Type = "GroupPost"
-					WebsocketMessage.GroupId = group.Id
-					WebsocketMessage.Description = "A post has been send to the group"
-					WebsocketMessage.Value = post
-
-					if err = model.ConnectedWebSocket.Conn[group.SplitMemberIds[i]].WriteJSON(WebsocketMessage); err != nil {
-
-						nw.Error("Error during the communication with the websocket")
-						log.Printf("[%s] [CreatePost] Error during the communication with the websocket : %s", r.RemoteAddr, err)
-						return
-					}
-				}
-				model.ConnectedWebSocket.Mu.Unlock()
+		// The text of a quote post can contain a link too.
+		StartLinkPreview(db, "Post", post.Id, post.Text)
+
+		// The counter is updated relatively so two reposts never overwrite each other.
+		if _, err = db.Exec("UPDATE Post SET RepostCount = IFNULL(RepostCount, 0) + 1 WHERE Id = ?", original.Id); err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB")
+			log.Printf("[%s] [RepostPost] Error during the update of the repost count : %s", r.RemoteAddr, err)
+			return
+		}
+
+		var userData model.Register
+		if err = userData.SelectFromDb(db, map[string]any{"Id": post.AuthorId}); err != nil {
+			nw.Error("There is a problem during the fetching of the user")
+			log.Printf("[%s] [RepostPost] There is a problem during the fetching of the user : %s", r.RemoteAddr, err)
+			return
+		}
+
+		var userDataName string
+		if userData.Username == "" {
+			userDataName = userData.FirstName + " " + userData.LastName
+		} else {
+			userDataName = userData.Username
+		}
+
+		description := fmt.Sprintf("%s has reposted your post \"%s\"", userDataName, original.Text)
+		if post.Text != "" {
+			description = fmt.Sprintf("%s has quoted your post \"%s\"", userDataName, original.Text)
+		}
+
+		notifId, err := uuid.NewV7()
+		if err != nil {
+			nw.Error("There is a problem with the generation of the uuid")
+			log.Printf("[%s] [RepostPost] There is a problem with the generation of the uuid : %s", r.RemoteAddr, err)
+			return
+		}
+
+		notification := model.Notification{
+			Id:          notifId.String(),
+			UserId:      original.AuthorId,
+			Status:      "Repost",
+			Description: description,
+			GroupId:     "",
+			OtherUserId: post.AuthorId,
+		}
+
+		// The author of the original post isn't notified if they muted the author of the repost.
+		muted := isMuted(db, original.AuthorId, post.AuthorId, "")
+
+		if !muted {
+			if err = notification.InsertIntoDb(db); err != nil {
+				nw.Error("There is a probleme during the sending of a notification")
+				log.Printf("[%s] [RepostPost] There is a probleme during the sending of a notification : %s", r.RemoteAddr, err)
+				return
 			}
 		}
 
+		model.ConnectedWebSocket.Mu.Lock()
+		if conn, isOk := model.ConnectedWebSocket.Conn[original.AuthorId]; isOk && !muted {
+			var WebsocketMessage struct {
+				Type        string
+				PostId      string
+				Description string
+				Value       model.Post
+			}
+
+			WebsocketMessage.Type = "Repost"
+			WebsocketMessage.PostId = original.Id
+			WebsocketMessage.Description = description
+			WebsocketMessage.Value = post
+
+			if err = conn.WriteJSON(WebsocketMessage); err != nil {
+				// The repost is already saved, a closed websocket mustn't make the request fail.
+				log.Printf("[%s] [RepostPost] Error during the communication with the websocket : %s", r.RemoteAddr, err)
+			}
+		}
+		model.ConnectedWebSocket.Mu.Unlock()
+
 		// Set response headers for JSON content.
 		w.Header().Set("Content-Type", "application/json")
 
-		// Encode the response JSON for successful post creation.
 		err = json.NewEncoder(w).Encode(map[string]any{
 			"Success": true,
-			"Message": "Post created successfully",
+			"Message": "Post reposted successfully",
 			// Return the newly created post ID.
 			"IdPost": post.Id,
 		})
 		if err != nil {
-			log.Printf("[%s] [CreatePost] %s", r.RemoteAddr, err.Error())
+			log.Printf("[%s] [RepostPost] %s", r.RemoteAddr, err.Error())
 		}
 	}
 }
@@ -209,7 +381,7 @@ func GetPost(db *sql.DB) http.HandlerFunc {
 			// Retrieve post by ID
 			err = post.SelectFromDb(db, map[string]any{"Id": post.Id})
 			// Add the retrieved post to the posts slice.
-			posts[0] = post
+			posts = append(posts, post)
 		} else {
 			// Retrieve all posts.
 			err = posts.SelectFromDb(db, map[string]any{})
@@ -235,6 +407,19 @@ func GetPost(db *sql.DB) http.HandlerFunc {
 			}
 		}
 
+		// The posts of the users with a block with the current user are hidden.
+		posts = removeBlockedPosts(db, posts, JWT)
+		posts = removeOrphanReposts(posts)
+
+		// The feed doesn't show the posts of the users muted by the current user, a post asked by its id is still sent.
+		if post.Id == "" {
+			posts = removeMutedPosts(db, posts, JWT)
+		}
+
+		attachPostLinkPreviews(db, posts)
+		attachPostPolls(db, posts)
+		RecordPostViews(JWT, posts)
+
 		// Set response headers for JSON content.
 		w.Header().Set("Content-Type", "application/json")
 
@@ -252,3 +437,228 @@ func GetPost(db *sql.DB) http.HandlerFunc {
 		}
 	}
 }
+
+/*
+This function takes 1 argument:
+  - the Posts to filter
+
+The purpose of this function is to remove the reposts whose original post has been deleted.
+The db sets RepostOf to NULL when the original post is removed, so a repost without text has nothing left to show.
+A quote post keeps its text and is sent with an empty RepostOf.
+
+The function returns the remaining Posts, in the same order.
+*/
+func removeOrphanReposts(posts model.Posts) model.Posts {
+	return slices.DeleteFunc(posts, func(post model.Post) bool {
+		return post.Text == "" && post.RepostOf == ""
+	})
+}
+
+/*
+This function takes 3 arguments:
+  - a string containing the id of the user who wants to see the post
+  - a Post object, which is the post to check
+  - a pointer to an SQL database object
+
+The purpose of this function is to check if a post is visible for a user:
+  - the author always sees their own posts
+  - a post is hidden between two users with a block
+  - a group post is visible for the members of the group, and for everybody in a public group
+  - a private post is only visible for the followers of the author
+  - an almost private post is only visible for the users in its audience
+
+The function returns true if the user can see the post, false otherwise.
+*/
+func CanSeePost(userId string, post model.Post, db *sql.DB) bool {
+	if post.AuthorId == userId {
+		return true
+	}
+
+	if isBlocked(db, userId, post.AuthorId) {
+		return false
+	}
+
+	if post.IsGroup != "" {
+		return canSeeGroupContent(db, post.IsGroup, userId)
+	}
+
+	status := strings.Split(post.Status, " | ")
+	switch status[0] {
+	case "public":
+		return true
+	case "private":
+		return IsFollowedBy(userId, post.AuthorId, db)
+	case "almost private":
+		return slices.Contains(status[1:], userId)
+	}
+
+	return false
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - a Post object, which is the post that has just been published
+
+The purpose of this function is to run the side effects of the publication of a group post:
+each member of the group receives a notification and, if connected, a GroupPost websocket message
+(a GroupAnnouncement websocket message for an announcement).
+Nothing is done for a post outside of a group.
+
+The function returns an error if the group or the author can't be fetched, or if a notification can't be sent.
+*/
+func NotifyGroupPost(db *sql.DB, post model.Post) error {
+	if post.IsGroup == "" {
+		return nil
+	}
+
+	var group model.Group
+	if err := group.SelectFromDb(db, map[string]any{"Id": post.IsGroup}); err != nil {
+		return fmt.Errorf("error during the fetching of the group : %v", err)
+	}
+
+	var userData model.Register
+	if err := userData.SelectFromDb(db, map[string]any{"Id": post.AuthorId}); err != nil {
+		return fmt.Errorf("error during the fetching of the user : %v", err)
+	}
+
+	var userDataName string
+	if userData.Username == "" {
+		userDataName = userData.FirstName + " " + userData.LastName
+	} else {
+		userDataName = userData.Username
+	}
+
+	// An announcement is pushed apart, to be acknowledged by the members.
+	description := fmt.Sprintf("A new post as been send by %s for the group %s", userDataName, group.GroupName)
+	websocketType, websocketDescription := "GroupPost", "A post has been send to the group"
+	if post.IsAnnouncement {
+		description = fmt.Sprintf("A new announcement has been published by %s for the group %s", userDataName, group.GroupName)
+		websocketType, websocketDescription = "GroupAnnouncement", "An announcement has been published in the group"
+	}
+
+	group.SplitMembers()
+	for i := range group.SplitMemberIds {
+		// The members who muted the group or the author aren't notified, except for an announcement.
+		if !post.IsAnnouncement && isMuted(db, group.SplitMemberIds[i], post.AuthorId, group.Id) {
+			continue
+		}
+
+		notifId, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("error during the generation of the uuid : %v", err)
+		}
+
+		notification := model.Notification{
+			Id:          notifId.String(),
+			UserId:      group.SplitMemberIds[i],
+			Status:      "Group",
+			Description: description,
+			GroupId:     group.Id,
+			OtherUserId: "",
+		}
+
+		if err = notification.InsertIntoDb(db); err != nil {
+			return fmt.Errorf("error during the sending of a notification : %v", err)
+		}
+
+		model.ConnectedWebSocket.Mu.Lock()
+		if conn, isOk := model.ConnectedWebSocket.Conn[group.SplitMemberIds[i]]; isOk {
+			var WebsocketMessage struct {
+				Type        string
+				GroupId     string
+				Description string
+				Value       model.Post
+			}
+
+			WebsocketMessage.Type = websocketType
+			WebsocketMessage.GroupId = group.Id
+			WebsocketMessage.Description = websocketDescription
+			WebsocketMessage.Value = post
+
+			err = conn.WriteJSON(WebsocketMessage)
+		}
+		model.ConnectedWebSocket.Mu.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("error during the communication with the websocket : %v", err)
+		}
+	}
+
+	return nil
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the removal of a post. The author can remove their post,
+and the leader and the moderators of a group can remove the posts of the group.
+The comments, the reactions, the pins and the views of the post are removed with it.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func DeletePost(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			PostId string `json:"PostId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [DeletePost] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [DeletePost] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var post model.Post
+		if err = post.SelectFromDb(db, map[string]any{"Id": datas.PostId}); err != nil {
+			nw.Error("There is no post with this id")
+			log.Printf("[%s] [DeletePost] There is no post with the id %s : %v", r.RemoteAddr, datas.PostId, err)
+			return
+		}
+
+		if !canModerateContent(db, userId, post.AuthorId, post.IsGroup) {
+			nw.Error("You can't remove this post")
+			log.Printf("[%s] [DeletePost] The user %s can't remove the post %s", r.RemoteAddr, userId, post.Id)
+			return
+		}
+
+		if err = post.DeleteFromDb(db, map[string]any{"Id": post.Id}); err != nil {
+			nw.Error("Internal Error: There is a problem during the delete in the DB")
+			log.Printf("[%s] [DeletePost] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// A removed repost is no longer counted on the original post.
+		if post.RepostOf != "" {
+			if _, err = db.Exec("UPDATE Post SET RepostCount = MAX(IFNULL(RepostCount, 0) - 1, 0) WHERE Id = ?", post.RepostOf); err != nil {
+				log.Printf("[%s] [DeletePost] Error during the update of the repost count : %v", r.RemoteAddr, err)
+			}
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Post deleted successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [DeletePost] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
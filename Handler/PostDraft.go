@@ -0,0 +1,469 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+
+	"github.com/gofrs/uuid"
+)
+
+// The format of the dates chosen by the users (scheduled posts, closing of the polls), the same as the date of the events.
+const scheduleDateFormat = "2006-01-02 15:04"
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the saving of a draft, or of a scheduled post when a PublishDate is given.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func SavePostDraft(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var draft model.PostDraft
+
+		// Decode the JSON request body into the draft struct.
+		if err := json.NewDecoder(r.Body).Decode(&draft); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [SavePostDraft] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(draft.AuthorId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [SavePostDraft] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+		draft.AuthorId = userId
+
+		if err = checkPostDraft(db, &draft); err != nil {
+			nw.Error("Invalid draft : " + err.Error())
+			log.Printf("[%s] [SavePostDraft] Invalid draft : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Generate a new UUID for the draft.
+		uid, err := uuid.NewV7()
+		if err != nil {
+			nw.Error("There is a problem with the generation of the uuid")
+			log.Printf("[%s] [SavePostDraft] There is a problem with the generation of the uuid : %s", r.RemoteAddr, err)
+			return
+		}
+		draft.Id = uid.String()
+
+		if err = draft.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB: " + err.Error())
+			log.Printf("[%s] [SavePostDraft] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Draft saved successfully",
+
+			"IdDraft": draft.Id,
+		})
+		if err != nil {
+			log.Printf("[%s] [SavePostDraft] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the drafts and scheduled posts of the user.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetPostDrafts(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var draft model.PostDraft
+
+		// Decode the JSON request body into the draft struct.
+		if err := json.NewDecoder(r.Body).Decode(&draft); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetPostDrafts] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(draft.AuthorId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetPostDrafts] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var drafts model.PostDrafts
+		if err = drafts.SelectFromDb(db, map[string]any{"AuthorId": userId}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetPostDrafts] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Drafts getted successfully",
+
+			"Value": drafts,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetPostDrafts] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the edition of a draft or a scheduled post of the user.
+All the fields are replaced, so giving a PublishDate schedules a draft and removing it turns a scheduled post back into a draft.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UpdatePostDraft(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var draft model.PostDraft
+
+		// Decode the JSON request body into the draft struct.
+		if err := json.NewDecoder(r.Body).Decode(&draft); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [UpdatePostDraft] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(draft.AuthorId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UpdatePostDraft] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+		draft.AuthorId = userId
+
+		// A user can only edit their own drafts.
+		where := map[string]any{"Id": draft.Id, "AuthorId": draft.AuthorId}
+		if err = utils.IfExistsInDB("PostDraft", db, where); err != nil {
+			nw.Error("There is no draft with this id")
+			log.Printf("[%s] [UpdatePostDraft] There is no draft %s for the user %s : %v", r.RemoteAddr, draft.Id, draft.AuthorId, err)
+			return
+		}
+
+		if err = checkPostDraft(db, &draft); err != nil {
+			nw.Error("Invalid draft : " + err.Error())
+			log.Printf("[%s] [UpdatePostDraft] Invalid draft : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var isGroup = sql.NullString{String: draft.IsGroup, Valid: draft.IsGroup != ""}
+		err = draft.UpdateDb(db, map[string]any{
+			"Text":           draft.Text,
+			"Image":          draft.Image,
+			"Status":         draft.Status,
+			"IsGroup":        isGroup,
+			"PublishDate":    draft.PublishDate,
+			"ContentWarning": draft.ContentWarning,
+			"SensitiveMedia": draft.SensitiveMedia,
+		}, where)
+		if err != nil {
+			nw.Error("Internal Error: There is a problem during the update of the DB: " + err.Error())
+			log.Printf("[%s] [UpdatePostDraft] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Draft updated successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [UpdatePostDraft] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the deletion of a draft, or the cancellation of a scheduled post, of the user.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func CancelPostDraft(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var draft model.PostDraft
+
+		// Decode the JSON request body into the draft struct.
+		if err := json.NewDecoder(r.Body).Decode(&draft); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [CancelPostDraft] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(draft.AuthorId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [CancelPostDraft] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// A user can only cancel their own drafts.
+		where := map[string]any{"Id": draft.Id, "AuthorId": userId}
+		if err = utils.IfExistsInDB("PostDraft", db, where); err != nil {
+			nw.Error("There is no draft with this id")
+			log.Printf("[%s] [CancelPostDraft] There is no draft %s for the user %s : %v", r.RemoteAddr, draft.Id, userId, err)
+			return
+		}
+
+		if err = draft.DeleteFromDb(db, where); err != nil {
+			nw.Error("Internal Error: There is a problem during the delete in the DB")
+			log.Printf("[%s] [CancelPostDraft] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Draft canceled successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [CancelPostDraft] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - a pointer to the PostDraft object to check
+
+The purpose of this function is to validate a draft before saving it:
+  - the status must be a valid post status (public by default)
+  - a scheduled post must have a text and a publication date in the future
+  - the author must be in the group of a group draft
+
+The function returns an error describing the first invalid field, or nil.
+*/
+func checkPostDraft(db *sql.DB, draft *model.PostDraft) error {
+	if draft.Status == "" {
+		draft.Status = "public"
+	}
+
+	if draft.Status != "public" && draft.Status != "private" && strings.Split(draft.Status, " | ")[0] != "almost private" {
+		return errors.New("invalid status")
+	}
+
+	if len(draft.Text) > 1000 {
+		return errors.New("the text is too long")
+	}
+
+	if err := checkContentWarning(draft.ContentWarning); err != nil {
+		return err
+	}
+
+	if draft.PublishDate != "" {
+		publishDate, err := time.Parse(scheduleDateFormat, draft.PublishDate)
+		if err != nil {
+			return errors.New("invalid publication date")
+		}
+
+		if !publishDate.After(time.Now()) {
+			return errors.New("the publication date must be in the future")
+		}
+
+		if draft.Text == "" {
+			return errors.New("a scheduled post must have a text")
+		}
+	}
+
+	if draft.IsGroup != "" && !IsGroupMember(draft.IsGroup, draft.AuthorId, db) {
+		return errors.New("the author isn't in the group")
+	}
+
+	if draft.IsGroup != "" && isGroupArchived(db, draft.IsGroup) {
+		return errors.New("the group is archived")
+	}
+
+	return nil
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - a PostDraft object, which is the scheduled post to publish
+
+The purpose of this function is to turn a scheduled draft into a post, with the same side effects as CreatePost.
+The group is checked before the draft is touched: if the author left the group or if the group has been archived
+since the scheduling, the draft is kept unscheduled and the author is notified.
+The post is inserted and the draft removed in a single transaction, so a draft is never lost nor published twice.
+
+The function returns an error if the publication fails.
+*/
+func publishPostDraft(db *sql.DB, draft model.PostDraft) error {
+	if draft.IsGroup != "" {
+		reason := ""
+		if !IsGroupMember(draft.IsGroup, draft.AuthorId, db) {
+			reason = "the author isn't in the group anymore"
+		} else if isGroupArchived(db, draft.IsGroup) {
+			reason = "the group is archived"
+		}
+
+		if reason != "" {
+			if err := unschedulePostDraft(db, draft, reason); err != nil {
+				return err
+			}
+
+			return errors.New(reason)
+		}
+	}
+
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	post := model.Post{
+		Id:           uid.String(),
+		AuthorId:     draft.AuthorId,
+		Text:         draft.Text,
+		Image:        draft.Image,
+		CreationDate: draft.PublishDate,
+		Status:       draft.Status,
+		IsGroup:      draft.IsGroup,
+
+		ContentWarning: draft.ContentWarning,
+		SensitiveMedia: draft.SensitiveMedia,
+	}
+
+	published, err := draft.Publish(db, post)
+	if err != nil || !published {
+		// The draft has been canceled or already published when nothing is published without error.
+		return err
+	}
+
+	StartLinkPreview(db, "Post", post.Id, post.Text)
+
+	return NotifyGroupPost(db, post)
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a PostDraft object, which is the scheduled post that can't be published
+  - a string containing the reason of the failure
+
+The purpose of this function is to keep a scheduled post that can't be published as a simple draft,
+by clearing its PublishDate, and to send a notification and, if connected, a websocket message to its author.
+
+The function returns an error if the draft can't be updated or if the author can't be notified.
+*/
+func unschedulePostDraft(db *sql.DB, draft model.PostDraft, reason string) error {
+	if err := draft.UpdateDb(db, map[string]any{"PublishDate": ""}, map[string]any{"Id": draft.Id}); err != nil {
+		return fmt.Errorf("error during the update of the draft : %v", err)
+	}
+
+	notifId, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("error during the generation of the uuid : %v", err)
+	}
+
+	description := fmt.Sprintf("Your scheduled post couldn't be published because %s, it has been kept in your drafts", reason)
+
+	notification := model.Notification{
+		Id:          notifId.String(),
+		UserId:      draft.AuthorId,
+		Status:      "PostDraft",
+		Description: description,
+		GroupId:     draft.IsGroup,
+		OtherUserId: "",
+	}
+
+	if err = notification.InsertIntoDb(db); err != nil {
+		return fmt.Errorf("error during the sending of a notification : %v", err)
+	}
+
+	model.ConnectedWebSocket.Mu.Lock()
+	defer model.ConnectedWebSocket.Mu.Unlock()
+
+	if conn, isOk := model.ConnectedWebSocket.Conn[draft.AuthorId]; isOk {
+		var WebsocketMessage struct {
+			Type        string
+			Description string
+			Value       model.PostDraft
+		}
+
+		draft.PublishDate = ""
+
+		WebsocketMessage.Type = "PostDraftUnpublished"
+		WebsocketMessage.Description = description
+		WebsocketMessage.Value = draft
+
+		if err = conn.WriteJSON(WebsocketMessage); err != nil {
+			return fmt.Errorf("error during the communication with the websocket : %v", err)
+		}
+	}
+
+	return nil
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to publish the scheduled posts once their publication date is reached.
+It runs forever and must be started in its own goroutine.
+*/
+func AutoPublishScheduledPosts(db *sql.DB) {
+	for range time.Tick(time.Second * 1) {
+		var drafts model.PostDrafts
+		if err := drafts.SelectScheduledBefore(db, time.Now().UTC().Format(scheduleDateFormat)); err != nil {
+			log.Printf("[AutoPublishScheduledPosts] Error during the fetch of the scheduled posts : %v", err)
+			continue
+		}
+
+		for _, draft := range drafts {
+			if err := publishPostDraft(db, draft); err != nil {
+				log.Printf("[AutoPublishScheduledPosts] Error during the publication of the draft %s : %v", draft.Id, err)
+			}
+		}
+	}
+}
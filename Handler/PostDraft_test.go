@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+	"time"
+)
+
+func TestPostDrafts(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+	jwt := utils.GenerateJWT(post.AuthorId)
+	future := time.Now().UTC().Add(time.Hour).Format(scheduleDateFormat)
+
+	if success, rr := TryRequest(t, SavePostDraft(db), map[string]any{"AuthorId": jwt, "Text": "draft"}); !success {
+		t.Fatalf("The draft should be saved : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, SavePostDraft(db), map[string]any{"AuthorId": jwt, "Text": "late", "PublishDate": "2000-01-01 10:00"}); success {
+		t.Fatal("A post can't be scheduled in the past")
+	}
+
+	if success, _ := TryRequest(t, SavePostDraft(db), map[string]any{"AuthorId": jwt, "PublishDate": future}); success {
+		t.Fatal("A scheduled post must have a text")
+	}
+
+	if success, rr := TryRequest(t, SavePostDraft(db), map[string]any{"AuthorId": jwt, "Text": "scheduled", "PublishDate": future}); !success {
+		t.Fatalf("The post should be scheduled : %s", rr.Body.String())
+	}
+
+	var bodyValue struct {
+		Value model.PostDrafts
+	}
+
+	_, rr := TryRequest(t, GetPostDrafts(db), map[string]any{"AuthorId": jwt})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(bodyValue.Value) != 2 {
+		t.Fatalf("The user should have 2 drafts : %s", rr.Body.String())
+	}
+
+	draftId := bodyValue.Value[0].Id
+
+	// Only the author can edit or cancel a draft
+	if success, _ := TryRequest(t, UpdatePostDraft(db), map[string]any{"AuthorId": utils.GenerateJWT("otherId"), "Id": draftId, "Text": "stolen"}); success {
+		t.Fatal("Another user shouldn't edit the draft")
+	}
+
+	if success, rr := TryRequest(t, UpdatePostDraft(db), map[string]any{"AuthorId": jwt, "Id": draftId, "Text": "edited", "PublishDate": future}); !success {
+		t.Fatalf("The draft should be edited : %s", rr.Body.String())
+	}
+
+	var draft model.PostDraft
+	if err = draft.SelectFromDb(db, map[string]any{"Id": draftId}); err != nil || draft.Text != "edited" || draft.PublishDate != future {
+		t.Fatalf("The draft hasn't been edited : %v %v", draft, err)
+	}
+
+	if success, rr := TryRequest(t, CancelPostDraft(db), map[string]any{"AuthorId": jwt, "Id": draftId}); !success {
+		t.Fatalf("The draft should be canceled : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, CancelPostDraft(db), map[string]any{"AuthorId": jwt, "Id": draftId}); success {
+		t.Fatal("A canceled draft can't be canceled again")
+	}
+}
+
+func TestPublishPostDraft(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	group := model.Group{
+		Id:           "groupId",
+		LeaderId:     post.AuthorId,
+		MemberIds:    post.AuthorId,
+		GroupName:    "group",
+		CreationDate: "now",
+	}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	// The draft is inserted directly because a post can't be scheduled in the past
+	draft := model.PostDraft{
+		Id:          "draftId",
+		AuthorId:    post.AuthorId,
+		Text:        "scheduled",
+		Status:      "public",
+		IsGroup:     group.Id,
+		PublishDate: "2000-01-01 10:00",
+	}
+	if err = draft.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var drafts model.PostDrafts
+	if err = drafts.SelectScheduledBefore(db, time.Now().UTC().Format(scheduleDateFormat)); err != nil || len(drafts) != 1 {
+		t.Fatalf("The draft should be ready to be published : %v %v", drafts, err)
+	}
+
+	if err = publishPostDraft(db, drafts[0]); err != nil {
+		t.Fatalf("Error during the publication : %v", err)
+	}
+
+	// A second publication of the same draft does nothing
+	if err = publishPostDraft(db, drafts[0]); err != nil {
+		t.Fatalf("Error during the second publication : %v", err)
+	}
+
+	var posts model.Posts
+	if err = posts.SelectFromDb(db, map[string]any{"IsGroup": group.Id}); err != nil || len(posts) != 1 || posts[0].Text != "scheduled" {
+		t.Fatalf("The post should be published once : %v %v", posts, err)
+	}
+
+	if err = utils.IfNotExistsInDB("PostDraft", db, map[string]any{"Id": draft.Id}); err != nil {
+		t.Fatal("The draft should be removed once published")
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"UserId": post.AuthorId, "GroupId": group.Id}); err != nil || len(notifications) != 1 {
+		t.Fatalf("The members of the group should be notified : %v %v", notifications, err)
+	}
+
+	// A draft of a user who left the group is kept unscheduled and its author is told
+	outsiderDraft := model.PostDraft{
+		Id:          "outsiderDraftId",
+		AuthorId:    "outsiderId",
+		Text:        "scheduled",
+		Status:      "public",
+		IsGroup:     group.Id,
+		PublishDate: "2000-01-01 10:00",
+	}
+	CreateTestUser(t, db, outsiderDraft.AuthorId)
+	if err = outsiderDraft.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = publishPostDraft(db, outsiderDraft); err == nil {
+		t.Fatal("The draft of a user outside of the group shouldn't be published")
+	}
+
+	if err = outsiderDraft.SelectFromDb(db, map[string]any{"Id": outsiderDraft.Id}); err != nil || outsiderDraft.PublishDate != "" {
+		t.Fatalf("The draft should be kept without its publication date : %+v %v", outsiderDraft, err)
+	}
+
+	notifications = nil
+	if err = notifications.SelectFromDb(db, map[string]any{"UserId": outsiderDraft.AuthorId, "Status": "PostDraft"}); err != nil || len(notifications) != 1 {
+		t.Fatalf("The author of the draft should be notified : %v %v", notifications, err)
+	}
+
+	// A draft for a group archived since the scheduling is kept unscheduled too
+	archivedDraft := model.PostDraft{
+		Id:          "archivedDraftId",
+		AuthorId:    post.AuthorId,
+		Text:        "scheduled",
+		Status:      "public",
+		IsGroup:     group.Id,
+		PublishDate: "2000-01-01 10:00",
+	}
+	if err = archivedDraft.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = group.UpdateDb(db, map[string]any{"ArchivedAt": "2000-01-01 00:00"}, map[string]any{"Id": group.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = publishPostDraft(db, archivedDraft); err == nil {
+		t.Fatal("The draft of an archived group shouldn't be published")
+	}
+
+	if err = archivedDraft.SelectFromDb(db, map[string]any{"Id": archivedDraft.Id}); err != nil || archivedDraft.PublishDate != "" {
+		t.Fatalf("The draft should be kept without its publication date : %+v %v", archivedDraft, err)
+	}
+
+	posts = nil
+	if err = posts.SelectFromDb(db, map[string]any{"IsGroup": group.Id}); err != nil || len(posts) != 1 {
+		t.Fatalf("Only the first draft should be published : %v %v", posts, err)
+	}
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+const (
+	// The format of the days of the views and of the daily statistics (UTC).
+	statsDayFormat = "2006-01-02"
+
+	// The views are kept in memory and saved in the DB by batch at this interval.
+	postViewFlushInterval = 10 * time.Second
+
+	defaultStatsDays = 30
+	maxStatsDays     = 365
+)
+
+// The views waiting to be saved. The map keeps each view once, so serving the same
+// post several times to a user doesn't cost more than a map access.
+var pendingPostViews = struct {
+	Mu    sync.Mutex
+	Views map[model.PostView]struct{}
+}{
+	Views: make(map[model.PostView]struct{}),
+}
+
+/*
+This function takes 2 arguments:
+  - a string containing the id of the user who receives the posts
+  - the Posts sent to the user
+
+The purpose of this function is to record the views of the posts sent to a user.
+The views are only kept in memory here, they are saved by AutoFlushPostViews.
+The views of an author on their own posts are not counted.
+*/
+func RecordPostViews(viewerId string, posts model.Posts) {
+	day := time.Now().UTC().Format(statsDayFormat)
+
+	pendingPostViews.Mu.Lock()
+	defer pendingPostViews.Mu.Unlock()
+
+	for _, post := range posts {
+		if post.AuthorId != viewerId {
+			pendingPostViews.Views[model.PostView{PostId: post.Id, ViewerId: viewerId, Day: day}] = struct{}{}
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to save the recorded views in the DB.
+The pending views are swapped under the lock, so the requests are not blocked during the insertion.
+
+The function returns an error if the insertion fails, the batch is then lost.
+*/
+func flushPostViews(db *sql.DB) error {
+	pendingPostViews.Mu.Lock()
+	pending := pendingPostViews.Views
+	pendingPostViews.Views = make(map[model.PostView]struct{})
+	pendingPostViews.Mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	views := make(model.PostViews, 0, len(pending))
+	for view := range pending {
+		views = append(views, view)
+	}
+
+	return views.InsertIntoDb(db)
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to save the recorded views of the posts at a regular interval.
+It is started as a goroutine with the server and never returns.
+*/
+func AutoFlushPostViews(db *sql.DB) {
+	for range time.Tick(postViewFlushInterval) {
+		if err := flushPostViews(db); err != nil {
+			log.Printf("[AutoFlushPostViews] Error during the save of the views : %v", err)
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the statistics of the posts of the user:
+the views, the unique viewers, the reactions and the comments, in total and for each day of the period.
+The statistics are for one post when a PostId is given, or for all the posts of the user.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetPostStats(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+			PostId string `json:"PostId"`
+			Days   int    `json:"Days"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetPostStats] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetPostStats] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if datas.Days <= 0 {
+			datas.Days = defaultStatsDays
+		}
+		datas.Days = min(datas.Days, maxStatsDays)
+
+		if datas.PostId != "" {
+			var post model.Post
+			if err = post.SelectFromDb(db, map[string]any{"Id": datas.PostId}); err != nil {
+				nw.Error("There is no post with this id")
+				log.Printf("[%s] [GetPostStats] There is no post with the id %s : %v", r.RemoteAddr, datas.PostId, err)
+				return
+			}
+
+			if post.AuthorId != userId {
+				nw.Error("Only the author can see the statistics of the post")
+				log.Printf("[%s] [GetPostStats] The user %s isn't the author of the post %s", r.RemoteAddr, userId, post.Id)
+				return
+			}
+		}
+
+		// The days of the period, from the oldest to today.
+		today := time.Now().UTC()
+		days := make([]string, datas.Days)
+		for i := range days {
+			days[i] = today.AddDate(0, 0, i-datas.Days+1).Format(statsDayFormat)
+		}
+
+		// The views still in memory are saved first, so the statistics are up to date.
+		if err = flushPostViews(db); err != nil {
+			log.Printf("[%s] [GetPostStats] Error during the save of the views : %v", r.RemoteAddr, err)
+		}
+
+		stats := model.PostStats{PostId: datas.PostId}
+		if err = stats.SelectFromDb(db, userId, days); err != nil {
+			nw.Error("Error during the fetch of the statistics")
+			log.Printf("[%s] [GetPostStats] Error during the fetch of the statistics : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Post statistics getted successfully",
+
+			"Value": stats,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetPostStats] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+	"time"
+)
+
+func TestPostStats(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+	CreateTestUser(t, db, "otherId")
+	jwt := utils.GenerateJWT(post.AuthorId)
+	otherJwt := utils.GenerateJWT("otherId")
+	today := time.Now().UTC().Format(statsDayFormat)
+
+	// The same user sees the post twice the same day, the author's views are not counted
+	for _, viewer := range []string{otherJwt, otherJwt, jwt} {
+		if success, rr := TryRequest(t, GetPost(db), map[string]any{"AuthorId": viewer}); !success {
+			t.Fatalf("The posts should be sent : %s", rr.Body.String())
+		}
+	}
+
+	if success, rr := TryRequest(t, HandleReaction(db), map[string]any{"UserId": otherJwt, "TargetType": "Post", "TargetId": post.Id, "Kind": "like"}); !success {
+		t.Fatalf("The reaction should be saved : %s", rr.Body.String())
+	}
+
+	comment := model.Comment{Id: "commentId", AuthorId: "otherId", Text: "comment", CreationDate: today + " 10:00", PostId: post.Id}
+	if err = comment.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, GetPostStats(db), map[string]any{"UserId": otherJwt, "PostId": post.Id}); success {
+		t.Fatal("Only the author can see the statistics of the post")
+	}
+
+	var bodyValue struct {
+		Value model.PostStats
+	}
+
+	_, rr := TryRequest(t, GetPostStats(db), map[string]any{"UserId": jwt, "PostId": post.Id, "Days": 7})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	stats := bodyValue.Value
+	if stats.Views != 1 || stats.UniqueViewers != 1 || stats.Reactions != 1 || stats.Comments != 1 {
+		t.Fatalf("The totals are not the good ones : %s", rr.Body.String())
+	}
+
+	if len(stats.Daily) != 7 {
+		t.Fatalf("There should be a statistic for each day : %s", rr.Body.String())
+	}
+
+	last := stats.Daily[len(stats.Daily)-1]
+	if last.Day != today || last.Views != 1 || last.Reactions != 1 || last.Comments != 1 || stats.Daily[0].Views != 0 {
+		t.Fatalf("The daily statistics are not the good ones : %s", rr.Body.String())
+	}
+
+	// Without a post, the statistics are for all the posts of the author
+	_, rr = TryRequest(t, GetPostStats(db), map[string]any{"UserId": jwt})
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if bodyValue.Value.Views != 1 || len(bodyValue.Value.Daily) != defaultStatsDays {
+		t.Fatalf("The statistics of all the posts are not the good ones : %s", rr.Body.String())
+	}
+}
@@ -190,3 +190,117 @@ func TestGetPost(t *testing.T) {
 		return
 	}
 }
+
+func TryRequest(t *testing.T, handler http.HandlerFunc, datas map[string]any) (bool, *httptest.ResponseRecorder) {
+	body, err := json.Marshal(datas)
+	if err != nil {
+		t.Fatalf("Erreur lors de la sérialisation du corps de la requête : %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "/", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	var bodyValue struct {
+		Success bool
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	return bodyValue.Success, rr
+}
+
+func TestRepostPost(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	original := CreateReactionTarget(t, db)
+
+	reposter := model.Register{
+		Auth:      model.Auth{Id: "reposterId", Email: "reposter", Password: "password"},
+		FirstName: "reposter",
+		LastName:  "reposter",
+		BirthDate: "monday",
+	}
+	if err = reposter.Auth.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+	if err = reposter.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	jwt := utils.GenerateJWT(reposter.Id)
+
+	if success, rr := TryRequest(t, RepostPost(db), map[string]any{"AuthorId": jwt, "RepostOf": original.Id, "CreationDate": "now"}); !success {
+		t.Fatalf("The public post should be reposted : %s", rr.Body.String())
+	}
+
+	if success, rr := TryRequest(t, RepostPost(db), map[string]any{"AuthorId": jwt, "RepostOf": original.Id, "Text": "quote", "CreationDate": "now"}); !success {
+		t.Fatalf("The public post should be quoted : %s", rr.Body.String())
+	}
+
+	if err = original.SelectFromDb(db, map[string]any{"Id": original.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	if original.RepostCount != 2 {
+		t.Fatalf("The repost count should be 2, got %d", original.RepostCount)
+	}
+
+	var notifications model.Notifications
+	if err = notifications.SelectFromDb(db, map[string]any{"UserId": original.AuthorId, "Status": "Repost"}); err != nil || len(notifications) != 2 {
+		t.Fatalf("The author should have 2 notifications : %v %v", notifications, err)
+	}
+
+	// The author can't repost their own post
+	if success, _ := TryRequest(t, RepostPost(db), map[string]any{"AuthorId": utils.GenerateJWT(original.AuthorId), "RepostOf": original.Id, "CreationDate": "now"}); success {
+		t.Fatal("A user shouldn't repost their own post")
+	}
+
+	private := model.Post{Id: "privateId", AuthorId: original.AuthorId, Text: "text", CreationDate: "now", Status: "private"}
+	if err = private.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, RepostPost(db), map[string]any{"AuthorId": jwt, "RepostOf": private.Id, "CreationDate": "now"}); success {
+		t.Fatal("A private post shouldn't be reposted")
+	}
+
+	// Once the original post is deleted, the repost without text is hidden and the quote is kept
+	if success, rr := TryRequest(t, DeletePost(db), map[string]any{"UserId": utils.GenerateJWT(original.AuthorId), "PostId": original.Id}); !success {
+		t.Fatalf("The author should delete the original post : %s", rr.Body.String())
+	}
+
+	_, rr := TryRequest(t, GetPost(db), map[string]any{"AuthorId": jwt})
+	var postsValue struct {
+		Posts model.Posts
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &postsValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	reposts := 0
+	for _, post := range postsValue.Posts {
+		if post.AuthorId == reposter.Id {
+			reposts++
+			if post.Text != "quote" || post.RepostOf != "" {
+				t.Fatalf("Only the quote should be kept, without its original post : %+v", postsValue.Posts)
+			}
+		}
+	}
+
+	if reposts != 1 {
+		t.Fatalf("The quote should be kept : %+v", postsValue.Posts)
+	}
+}
@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle user requests for reacting (like, love, laugh, sad, angry or dislike) to posts or comments.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func HandleReaction(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var reaction model.Reaction
+
+		// Decode the JSON request body into the reaction struct.
+		if err := json.NewDecoder(r.Body).Decode(&reaction); err != nil {
+			// Return error if the request body is invalid.
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [HandleReaction] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(reaction.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [HandleReaction] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+		reaction.UserId = userId
+
+		// Validate the provided target type and kind of reaction.
+		if reaction.TargetType != "Post" && reaction.TargetType != "Comment" {
+			nw.Error("Invalid target type")
+			log.Printf("[%s] [HandleReaction] Invalid target type", r.RemoteAddr)
+			return
+		}
+
+		if !slices.Contains(model.ReactionKinds, reaction.Kind) {
+			nw.Error("Invalid kind of reaction")
+			log.Printf("[%s] [HandleReaction] Invalid kind of reaction : %s", r.RemoteAddr, reaction.Kind)
+			return
+		}
+
+		// The target type is also the name of the table where the target is stored.
+		if err = utils.IfExistsInDB(reaction.TargetType, db, map[string]any{"Id": reaction.TargetId}); err != nil {
+			nw.Error("There is no target with this id")
+			log.Printf("[%s] [HandleReaction] There is no %s with the id %s : %v", r.RemoteAddr, reaction.TargetType, reaction.TargetId, err)
+			return
+		}
+
+		// Process the reaction logic.
+		if err = handleReactionLogic(db, reaction); err != nil {
+			nw.Error("Error during the reaction logic")
+			log.Printf("[%s] [HandleReaction] Error during the reaction logic : %v", r.RemoteAddr, err)
+			return
+		}
+
+		counts, err := reaction.CountByKind(db)
+		if err != nil {
+			nw.Error("Error during the count of the reactions")
+			log.Printf("[%s] [HandleReaction] Error during the count of the reactions : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Reaction handled successfully",
+			// The quantity of reactions of each kind after the update.
+			"Count": counts,
+		})
+		if err != nil {
+			// Log any error that occurs while encoding the response.
+			log.Printf("[%s] [HandleReaction] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - a Reaction object with the target, the user and the kind of the reaction
+
+The purpose of this function is to manage the reaction logic for a given post or comment.
+A user can only have one reaction on a target:
+  - reacting again with the same kind removes the reaction
+  - reacting with another kind replaces the reaction
+
+Every step is done in a single transaction so the counters of the target can't drift away from the Reaction rows.
+
+The function returns an error if there is a problem during the reaction handling process; otherwise, it returns nil.
+*/
+func handleReactionLogic(db *sql.DB, reaction model.Reaction) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Check if the user has already reacted to the target.
+	previous, err := getUserReaction(tx, reaction.TargetType, reaction.TargetId, reaction.UserId)
+	if err != nil {
+		return fmt.Errorf("error checking reaction status: %v", err)
+	}
+
+	if previous != "" {
+		// Remove the previous reaction, whatever its kind.
+		if err = removeReaction(tx, reaction.TargetType, reaction.TargetId, reaction.UserId, previous); err != nil {
+			return fmt.Errorf("error removing reaction: %v", err)
+		}
+	}
+
+	// Reacting twice with the same kind only removes the reaction.
+	if previous != reaction.Kind {
+		if err = addReaction(tx, reaction); err != nil {
+			return fmt.Errorf("error adding reaction: %v", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing reaction: %v", err)
+	}
+
+	return nil
+}
+
+/*
+This function takes 4 arguments:
+  - a pointer to an SQL transaction
+  - a string with the type of the target (Post or Comment)
+  - a string containing the target ID
+  - a string containing the user ID
+
+The purpose of this function is to get the kind of the reaction a user has given to a target.
+
+The function returns the kind of the reaction (empty if the user hasn't reacted) and an error if there is an issue during the query.
+*/
+func getUserReaction(tx *sql.Tx, targetType, targetID, userID string) (string, error) {
+	var kind string
+	err := tx.QueryRow("SELECT Kind FROM Reaction WHERE TargetType = ? AND TargetId = ? AND UserId = ?", targetType, targetID, userID).Scan(&kind)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return kind, err
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL transaction
+  - a Reaction object to insert
+
+The purpose of this function is to add a reaction for a specific target by a user.
+
+The function returns an error if there is an issue during the insertion or updating process.
+*/
+func addReaction(tx *sql.Tx, reaction model.Reaction) error {
+	if reaction.TargetType == "" || reaction.TargetId == "" || reaction.UserId == "" || !slices.Contains(model.ReactionKinds, reaction.Kind) {
+		return errors.New("empty field")
+	}
+
+	// The unique index on (TargetType, TargetId, UserId) refuses a second reaction of the same user.
+	// The date is used by the statistics of the posts.
+	if _, err := tx.Exec("INSERT INTO Reaction (TargetType, TargetId, UserId, Kind, CreationDate) VALUES (?, ?, ?, ?, ?)",
+		reaction.TargetType, reaction.TargetId, reaction.UserId, reaction.Kind, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	// Update the counter of the target for this kind of reaction.
+	return updateReactionCount(tx, reaction.TargetType, reaction.TargetId, reaction.Kind, 1)
+}
+
+/*
+This function takes 5 arguments:
+  - a pointer to an SQL transaction
+  - a string with the type of the target (Post or Comment)
+  - a string containing the target ID
+  - a string containing the user ID
+  - a string with the kind of the reaction to remove
+
+The purpose of this function is to remove the reaction of a user on a specific target.
+
+The function returns an error if there is an issue during the deletion or updating process.
+*/
+func removeReaction(tx *sql.Tx, targetType, targetID, userID, kind string) error {
+	result, err := tx.Exec("DELETE FROM Reaction WHERE TargetType = ? AND TargetId = ? AND UserId = ? AND Kind = ?", targetType, targetID, userID, kind)
+	if err != nil {
+		return err
+	}
+
+	// Only update the counter if a reaction has really been removed.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("reaction not found")
+	}
+
+	// Update the counter of the target for this kind of reaction.
+	return updateReactionCount(tx, targetType, targetID, kind, -1)
+}
+
+/*
+This function takes 5 arguments:
+  - a pointer to an SQL transaction
+  - a string with the type of the target, which is also the table where the counter is stored
+  - a string containing the target ID
+  - a string with the kind of the reaction
+  - an integer (delta) that indicates how much to change the counter
+
+The purpose of this function is to keep the LikeCount and DislikeCount columns of the target up to date.
+The other kinds of reaction have no column and are only counted from the Reaction table.
+
+The function returns an error if there is an issue during the update process.
+*/
+func updateReactionCount(tx *sql.Tx, table, targetID, kind string, delta int) error {
+	column := ""
+	switch kind {
+	case "like":
+		column = "LikeCount"
+	case "dislike":
+		column = "DislikeCount"
+	default:
+		return nil
+	}
+
+	if table != "Post" && table != "Comment" {
+		return fmt.Errorf("invalid table : %s", table)
+	}
+
+	// The counter is updated relatively so two transactions never overwrite each other.
+	_, err := tx.Exec(fmt.Sprintf("UPDATE %s SET %s = IFNULL(%s, 0) + ? WHERE Id = ?", table, column, column), delta, targetID)
+
+	return err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the users who reacted to a post or a comment, with the quantity of each kind of reaction.
+An optional Kind can be given to only get the users who reacted with this kind.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetReactions(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var request model.Reaction
+
+		// Decode the JSON request body into the request struct.
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetReactions] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		if _, err := utils.DecryptJWT(request.UserId, db); err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetReactions] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if request.TargetType != "Post" && request.TargetType != "Comment" {
+			nw.Error("Invalid target type")
+			log.Printf("[%s] [GetReactions] Invalid target type", r.RemoteAddr)
+			return
+		}
+
+		where := map[string]any{"TargetType": request.TargetType, "TargetId": request.TargetId}
+		if request.Kind != "" {
+			if !slices.Contains(model.ReactionKinds, request.Kind) {
+				nw.Error("Invalid kind of reaction")
+				log.Printf("[%s] [GetReactions] Invalid kind of reaction : %s", r.RemoteAddr, request.Kind)
+				return
+			}
+
+			where["Kind"] = request.Kind
+		}
+
+		var reactions model.Reactions
+		if err := reactions.SelectFromDb(db, where); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetReactions] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		counts, err := request.CountByKind(db)
+		if err != nil {
+			nw.Error("Error during the count of the reactions")
+			log.Printf("[%s] [GetReactions] Error during the count of the reactions : %v", r.RemoteAddr, err)
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Reactions getted successfully",
+
+			"Value": reactions,
+			"Count": counts,
+		})
+		if err != nil {
+			// Log any error that occurs while encoding the response.
+			log.Printf("[%s] [GetReactions] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"testing"
+)
+
+func CreateReactionTarget(t *testing.T, db *sql.DB) model.Post {
+	var register = model.Register{
+		Auth: model.Auth{
+			Id:       "userId",
+			Email:    "email",
+			Password: "password",
+		},
+
+		FirstName: "firstname",
+		LastName:  "lastname",
+		BirthDate: "monday",
+	}
+
+	if err := register.Auth.InsertIntoDb(db); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if err := register.InsertIntoDb(db); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var post = model.Post{
+		Id:           "postId",
+		AuthorId:     register.Id,
+		Text:         "text",
+		CreationDate: "now",
+		Status:       "public",
+	}
+	if err := post.InsertIntoDb(db); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return post
+}
+
+func TestHandleReaction(t *testing.T) {
+	// Crée un mock de base de données (ou une vraie connexion en mémoire)
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	var reaction = map[string]any{
+		"UserId":     utils.GenerateJWT(post.AuthorId),
+		"TargetType": "Post",
+		"TargetId":   post.Id,
+		"Kind":       "love",
+	}
+
+	body, err := json.Marshal(reaction)
+	if err != nil {
+		t.Fatalf("Erreur lors de la sérialisation du corps de la requête : %v", err)
+		return
+	}
+
+	// Create a request to pass to our handler. We don't have any query parameters for now, so we'll
+	// pass 'nil' as the third parameter.
+	req, err := http.NewRequest("POST", "/reaction", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(HandleReaction(db))
+
+	// Our handlers satisfy http.Handler, so we can call their ServeHTTP method
+	// directly and pass in our Request and ResponseRecorder.
+	handler.ServeHTTP(rr, req)
+	// Check the status code is what we expect.
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		return
+	}
+
+	// Check the response body is what we expect.
+	expected := "Reaction handled successfully"
+	var bodyValue struct {
+		Success bool
+		Count   map[string]int
+	}
+
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+		return
+	}
+
+	if !bodyValue.Success {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+		return
+	}
+
+	if bodyValue.Count["love"] != 1 || bodyValue.Count["like"] != 0 {
+		t.Errorf("handler returned unexpected counts: got %v", bodyValue.Count)
+		return
+	}
+}
+
+func TestHandleReactionLogic(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	reaction := model.Reaction{
+		TargetType: "Post",
+		TargetId:   post.Id,
+		UserId:     post.AuthorId,
+		Kind:       "like",
+	}
+
+	if err = handleReactionLogic(db, reaction); err != nil {
+		t.Fatalf("error during the function : %v", err)
+		return
+	}
+
+	// Reacting with another kind replaces the previous reaction
+	reaction.Kind = "dislike"
+	if err = handleReactionLogic(db, reaction); err != nil {
+		t.Fatalf("error during the function : %v", err)
+		return
+	}
+
+	if err = post.SelectFromDb(db, map[string]any{"Id": post.Id}); err != nil {
+		t.Fatalf("error during the fetch of the post : %v", err)
+		return
+	}
+
+	if post.LikeCount != 0 || post.DislikeCount != 1 {
+		t.Fatalf("The counters are not the good ones : %d likes and %d dislikes", post.LikeCount, post.DislikeCount)
+		return
+	}
+
+	// Reacting again with the same kind removes the reaction
+	if err = handleReactionLogic(db, reaction); err != nil {
+		t.Fatalf("error during the function : %v", err)
+		return
+	}
+
+	var count int
+	if err = db.QueryRow("SELECT COUNT(*) FROM Reaction WHERE TargetId = ?", post.Id).Scan(&count); err != nil {
+		t.Fatalf("error during the count of the reactions : %v", err)
+		return
+	}
+
+	if count != 0 {
+		t.Fatalf("The reaction should have been removed, got %d reactions", count)
+	}
+}
+
+func TestGetUserReaction(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error while starting the transaction : %v", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err = addReaction(tx, model.Reaction{TargetType: "Post", TargetId: post.Id, UserId: post.AuthorId, Kind: "laugh"}); err != nil {
+		t.Fatalf("error while adding reaction : %v", err)
+		return
+	}
+
+	kind, err := getUserReaction(tx, "Post", post.Id, post.AuthorId)
+	if err != nil {
+		t.Fatalf("error during the function : %v", err)
+	}
+
+	if kind != "laugh" {
+		t.Fatal("The result is not the good")
+	}
+}
+
+func TestAddReactionAndRemove(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error while starting the transaction : %v", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err = addReaction(tx, model.Reaction{TargetType: "Post", TargetId: post.Id, UserId: post.AuthorId, Kind: "like"}); err != nil {
+		t.Fatalf("error while adding reaction : %v", err)
+		return
+	}
+
+	// A user can only have one reaction on a target
+	if err = addReaction(tx, model.Reaction{TargetType: "Post", TargetId: post.Id, UserId: post.AuthorId, Kind: "love"}); err == nil {
+		t.Fatal("A second reaction of the same user should be refused")
+		return
+	}
+
+	if err = removeReaction(tx, "Post", post.Id, post.AuthorId, "like"); err != nil {
+		t.Fatalf("error while removing reaction : %v", err)
+		return
+	}
+
+	if err = removeReaction(tx, "Post", post.Id, post.AuthorId, "like"); err == nil {
+		t.Fatal("Removing a reaction who doesn't exist should fail")
+		return
+	}
+
+	if err = addReaction(tx, model.Reaction{TargetType: "Post", TargetId: post.Id, UserId: post.AuthorId, Kind: "unknown"}); err == nil {
+		t.Fatal("An unknown kind of reaction should be refused")
+		return
+	}
+}
+
+func TestUpdateReactionCount(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error while starting the transaction : %v", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err = updateReactionCount(tx, "Post", post.Id, "like", 1); err != nil {
+		t.Fatalf("Error during the function : %v", err)
+		return
+	}
+
+	if err = updateReactionCount(tx, "UserInfo", post.Id, "like", 1); err == nil {
+		t.Fatal("Only the Post and Comment tables can be updated")
+		return
+	}
+}
+
+func TestGetReactions(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	post := CreateReactionTarget(t, db)
+
+	if err = handleReactionLogic(db, model.Reaction{TargetType: "Post", TargetId: post.Id, UserId: post.AuthorId, Kind: "sad"}); err != nil {
+		t.Fatalf("error while adding reaction : %v", err)
+		return
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"UserId":     utils.GenerateJWT(post.AuthorId),
+		"TargetType": "Post",
+		"TargetId":   post.Id,
+	})
+	if err != nil {
+		t.Fatalf("Erreur lors de la sérialisation du corps de la requête : %v", err)
+		return
+	}
+
+	req, err := http.NewRequest("POST", "/getReactions", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(GetReactions(db))
+	handler.ServeHTTP(rr, req)
+
+	var bodyValue struct {
+		Success bool
+		Value   model.Reactions
+		Count   map[string]int
+	}
+
+	if err = json.Unmarshal(rr.Body.Bytes(), &bodyValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+		return
+	}
+
+	if !bodyValue.Success || len(bodyValue.Value) != 1 || bodyValue.Value[0].User_Name != "firstname lastname" || bodyValue.Count["sad"] != 1 {
+		t.Errorf("handler returned unexpected body: got %v", rr.Body.String())
+		return
+	}
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	model "social-network/Model"
+	utils "social-network/Utils"
+)
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the block of a user by the current user.
+The follows and the follow requests between the two users are removed in both directions,
+and the block is silent: the blocked user isn't notified.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func BlockUser(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId      string `json:"UserId"`
+			BlockedUser string `json:"BlockedUser"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [BlockUser] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [BlockUser] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if datas.BlockedUser == "" || datas.BlockedUser == userId {
+			nw.Error("You can't block yourself")
+			log.Printf("[%s] [BlockUser] The user %s tried to block themself", r.RemoteAddr, userId)
+			return
+		}
+
+		if err = utils.IfExistsInDB("UserInfo", db, map[string]any{"Id": datas.BlockedUser}); err != nil {
+			nw.Error("There is no user with this id")
+			log.Printf("[%s] [BlockUser] There is no user with the id %s : %v", r.RemoteAddr, datas.BlockedUser, err)
+			return
+		}
+
+		if err = utils.IfNotExistsInDB("UserBlock", db, map[string]any{"BlockerId": userId, "BlockedId": datas.BlockedUser}); err != nil {
+			nw.Error("This user is already blocked")
+			log.Printf("[%s] [BlockUser] The user %s already blocked the user %s", r.RemoteAddr, userId, datas.BlockedUser)
+			return
+		}
+
+		block := model.UserBlock{
+			BlockerId:    userId,
+			BlockedId:    datas.BlockedUser,
+			CreationDate: time.Now().UTC().Format(scheduleDateFormat),
+		}
+
+		if err = block.InsertIntoDb(db); err != nil {
+			nw.Error("Internal Error: There is a problem during the push in the DB")
+			log.Printf("[%s] [BlockUser] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "User blocked successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [BlockUser] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the removal of a block by the user who created it.
+The follows removed by the block aren't restored.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func UnblockUser(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId      string `json:"UserId"`
+			BlockedUser string `json:"BlockedUser"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [UnblockUser] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [UnblockUser] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if err = utils.IfExistsInDB("UserBlock", db, map[string]any{"BlockerId": userId, "BlockedId": datas.BlockedUser}); err != nil {
+			nw.Error("This user isn't blocked")
+			log.Printf("[%s] [UnblockUser] The user %s didn't block the user %s : %v", r.RemoteAddr, userId, datas.BlockedUser, err)
+			return
+		}
+
+		var block model.UserBlock
+		if err = block.DeleteFromDb(db, map[string]any{"BlockerId": userId, "BlockedId": datas.BlockedUser}); err != nil {
+			nw.Error("Internal Error: There is a problem during the remove in the DB")
+			log.Printf("[%s] [UnblockUser] %s", r.RemoteAddr, err.Error())
+			return
+		}
+
+		// Set the response header to indicate JSON content and respond with a success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "User unblocked successfully",
+		})
+		if err != nil {
+			log.Printf("[%s] [UnblockUser] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an SQL database object
+
+The purpose of this function is to handle the retrieval of the users blocked by the current user.
+
+The function returns an http.HandlerFunc that can be used as a handler for HTTP requests.
+*/
+func GetBlockedUsers(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Create a custom response writer to handle errors and responses.
+		nw := model.ResponseWriter{
+			ResponseWriter: w,
+		}
+
+		// Struct to hold the data from the request body.
+		var datas struct {
+			UserId string `json:"UserId"`
+		}
+
+		// Decode the JSON request body into the datas struct.
+		if err := json.NewDecoder(r.Body).Decode(&datas); err != nil {
+			nw.Error("Invalid request body")
+			log.Printf("[%s] [GetBlockedUsers] Invalid request body: %v", r.RemoteAddr, err)
+			return
+		}
+
+		userId, err := utils.DecryptJWT(datas.UserId, db)
+		if err != nil {
+			nw.Error("Invalid JWT")
+			log.Printf("[%s] [GetBlockedUsers] Error during the decrypt of the JWT : %v", r.RemoteAddr, err)
+			return
+		}
+
+		var blocks model.UserBlocks
+		if err = blocks.SelectFromDb(db, map[string]any{"BlockerId": userId}); err != nil {
+			nw.Error("Error during the fetch of the DB")
+			log.Printf("[%s] [GetBlockedUsers] Error during the fetch of the DB : %v", r.RemoteAddr, err)
+			return
+		}
+
+		if blocks == nil {
+			blocks = model.UserBlocks{}
+		}
+
+		// Set the response header to indicate JSON content and respond with success message.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]any{
+			"Success": true,
+			"Message": "Blocked users getted successfully",
+			"Value":   blocks,
+		})
+		if err != nil {
+			log.Printf("[%s] [GetBlockedUsers] %s", r.RemoteAddr, err.Error())
+		}
+	}
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of a user
+  - a string containing the id of another user
+
+The purpose of this function is to check if one of the two users has blocked the other one, a block applies in both directions.
+
+The function returns true if there is a block between the two users, false otherwise.
+*/
+func isBlocked(db *sql.DB, userId, otherId string) bool {
+	if userId == "" || otherId == "" || userId == otherId {
+		return false
+	}
+
+	return utils.IfExistsInDB("UserBlock", db, map[string]any{"BlockerId": userId, "BlockedId": otherId}) == nil ||
+		utils.IfExistsInDB("UserBlock", db, map[string]any{"BlockerId": otherId, "BlockedId": userId}) == nil
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an SQL database object
+  - a string containing the id of the user
+
+The purpose of this function is to find all the users with a block with the user, blocked by them or blocking them.
+
+The function returns a set of the ids of these users (empty if the blocks can't be fetched).
+*/
+func blockedUsers(db *sql.DB, userId string) map[string]bool {
+	users := map[string]bool{}
+
+	var blocks model.UserBlocks
+	if err := blocks.SelectFromDb(db, map[string]any{"BlockerId": userId}); err == nil {
+		for _, block := range blocks {
+			users[block.BlockedId] = true
+		}
+	}
+
+	blocks = nil
+	if err := blocks.SelectFromDb(db, map[string]any{"BlockedId": userId}); err == nil {
+		for _, block := range blocks {
+			users[block.BlockerId] = true
+		}
+	}
+
+	return users
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Posts to filter
+  - a string containing the id of the user who reads the posts
+
+The purpose of this function is to remove from a list of posts the posts written by a user with a block with the reader.
+
+The function returns the remaining Posts, in the same order.
+*/
+func removeBlockedPosts(db *sql.DB, posts model.Posts, userId string) model.Posts {
+	blocked := blockedUsers(db, userId)
+	if len(blocked) == 0 {
+		return posts
+	}
+
+	return slices.DeleteFunc(posts, func(post model.Post) bool {
+		return blocked[post.AuthorId]
+	})
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an SQL database object
+  - the Comments to filter
+  - a string containing the id of the user who reads the comments
+
+The purpose of this function is to remove from a list of comments the comments written by a user with a block with the reader.
+
+The function returns the remaining Comments, in the same order.
+*/
+func removeBlockedComments(db *sql.DB, comments model.Comments, userId string) model.Comments {
+	blocked := blockedUsers(db, userId)
+	if len(blocked) == 0 {
+		return comments
+	}
+
+	return slices.DeleteFunc(comments, func(comment model.Comment) bool {
+		return blocked[comment.AuthorId]
+	})
+}
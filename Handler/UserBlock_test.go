@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	model "social-network/Model"
+	utils "social-network/Utils"
+	"strings"
+	"testing"
+)
+
+func TestUserBlock(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	CreateTables(db)
+
+	for _, id := range []string{"blockerId", "blockedId", "otherId"} {
+		CreateTestUser(t, db, id)
+	}
+	blockerJwt := utils.GenerateJWT("blockerId")
+	blockedJwt := utils.GenerateJWT("blockedId")
+
+	for _, follow := range []model.Follower{
+		{Id: "firstFollowId", FollowerId: "blockerId", FollowedId: "blockedId"},
+		{Id: "secondFollowId", FollowerId: "blockedId", FollowedId: "blockerId"},
+		{Id: "otherFollowId", FollowerId: "otherId", FollowedId: "blockerId"},
+	} {
+		if err = follow.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = (&model.FollowRequest{FollowerId: "blockedId", FollowedId: "blockerId"}).InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, post := range []model.Post{
+		{Id: "blockedPostId", AuthorId: "blockedId", Text: "text", CreationDate: "now", Status: "public"},
+		{Id: "otherPostId", AuthorId: "otherId", Text: "text", CreationDate: "now", Status: "public"},
+	} {
+		if err = post.InsertIntoDb(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	comment := model.Comment{Id: "commentId", AuthorId: "blockedId", Text: "text", CreationDate: "now", PostId: "otherPostId"}
+	if err = comment.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, _ := TryRequest(t, BlockUser(db), map[string]any{"UserId": blockerJwt, "BlockedUser": "blockerId"}); success {
+		t.Fatal("A user can't block themself")
+	}
+
+	if success, _ := TryRequest(t, BlockUser(db), map[string]any{"UserId": blockerJwt, "BlockedUser": "unknownId"}); success {
+		t.Fatal("An unknown user can't be blocked")
+	}
+
+	if success, rr := TryRequest(t, BlockUser(db), map[string]any{"UserId": blockerJwt, "BlockedUser": "blockedId"}); !success {
+		t.Fatalf("The user should be blocked : %s", rr.Body.String())
+	}
+
+	if success, _ := TryRequest(t, BlockUser(db), map[string]any{"UserId": blockerJwt, "BlockedUser": "blockedId"}); success {
+		t.Fatal("The user is already blocked")
+	}
+
+	// The follows between the two users are removed in both directions, the other follows are kept
+	var followers model.Followers
+	if err = followers.SelectFromDb(db, map[string]any{}); err != nil || len(followers) != 1 || followers[0].Id != "otherFollowId" {
+		t.Fatalf("Only the follow of the other user should be kept : %+v %v", followers, err)
+	}
+
+	if utils.IfNotExistsInDB("FollowingRequest", db, map[string]any{"FollowerId": "blockedId", "FollowedId": "blockerId"}) != nil {
+		t.Fatal("The follow request should be removed")
+	}
+
+	_, rr := TryRequest(t, GetBlockedUsers(db), map[string]any{"UserId": blockerJwt})
+	var blocksValue struct {
+		Value model.UserBlocks
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &blocksValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(blocksValue.Value) != 1 || blocksValue.Value[0].BlockedId != "blockedId" {
+		t.Fatalf("The blocked user should be listed : %s", rr.Body.String())
+	}
+
+	// The block applies in both directions
+	if success, rr := TryRequest(t, AddFollower(db), map[string]any{"FollowerId": blockedJwt, "FollowedId": "blockerId"}); success || !strings.Contains(rr.Body.String(), "follow this user") {
+		t.Fatal("The blocked user can't follow the blocker")
+	}
+
+	if success, rr := TryRequest(t, AddMessage(db), map[string]any{"SenderId": blockedJwt, "ReceiverId": "blockerId", "Message": "message"}); success || !strings.Contains(rr.Body.String(), "message to this user") {
+		t.Fatal("The blocked user can't write to the blocker")
+	}
+
+	if success, _ := TryRequest(t, AddMessage(db), map[string]any{"SenderId": blockerJwt, "ReceiverId": "blockedId", "Message": "message"}); success {
+		t.Fatal("The blocker can't write to the blocked user")
+	}
+
+	group := model.Group{Id: "groupId", LeaderId: "blockerId", GroupName: "group", CreationDate: "now"}
+	if err = group.InsertIntoDb(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if success, rr := TryRequest(t, InviteGroup(db), map[string]any{"SenderId": blockerJwt, "GroupId": group.Id, "ReceiverId": "blockedId"}); success || !strings.Contains(rr.Body.String(), "invite this user") {
+		t.Fatal("The blocker can't invite the blocked user")
+	}
+
+	if success, rr := TryRequest(t, CreateComment(db), map[string]any{"AuthorId": blockerJwt, "PostId": "blockedPostId", "Text": "text", "CreationDate": "now"}); success || !strings.Contains(rr.Body.String(), "comment this post") {
+		t.Fatal("The blocker can't comment the posts of the blocked user")
+	}
+
+	if success, rr := TryRequest(t, RepostPost(db), map[string]any{"AuthorId": blockerJwt, "RepostOf": "blockedPostId", "CreationDate": "now"}); success || !strings.Contains(rr.Body.String(), "repost this post") {
+		t.Fatal("The blocker can't repost the posts of the blocked user")
+	}
+
+	// The posts and the comments are hidden from each other
+	getPosts := func(userJwt string) model.Posts {
+		_, rr := TryRequest(t, GetPost(db), map[string]any{"AuthorId": userJwt})
+		var postsValue struct {
+			Posts model.Posts
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &postsValue); err != nil {
+			t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+		}
+		return postsValue.Posts
+	}
+
+	if posts := getPosts(blockerJwt); len(posts) != 1 || posts[0].Id != "otherPostId" {
+		t.Fatalf("The posts of the blocked user should be hidden : %+v", posts)
+	}
+
+	if posts := getPosts(utils.GenerateJWT("otherId")); len(posts) != 2 {
+		t.Fatalf("The other users should see all the posts : %+v", posts)
+	}
+
+	_, rr = TryRequest(t, GetComment(db), map[string]any{"AuthorId": blockerJwt})
+	var commentsValue struct {
+		Posts model.Comments
+	}
+	if err = json.Unmarshal(rr.Body.Bytes(), &commentsValue); err != nil {
+		t.Fatalf("Erreur lors de la réception de la réponse de la requête : %v", err)
+	}
+
+	if len(commentsValue.Posts) != 0 {
+		t.Fatalf("The comments of the blocked user should be hidden : %s", rr.Body.String())
+	}
+
+	if CanSeePost("blockedId", model.Post{AuthorId: "blockerId", Status: "public"}, db) {
+		t.Fatal("The blocked user can't see the posts of the blocker")
+	}
+
+	// Only the blocker can remove the block
+	if success, _ := TryRequest(t, UnblockUser(db), map[string]any{"UserId": blockedJwt, "BlockedUser": "blockerId"}); success {
+		t.Fatal("The blocked user can't remove the block")
+	}
+
+	if success, rr := TryRequest(t, UnblockUser(db), map[string]any{"UserId": blockerJwt, "BlockedUser": "blockedId"}); !success {
+		t.Fatalf("The blocker should remove the block : %s", rr.Body.String())
+	}
+
+	if posts := getPosts(blockerJwt); len(posts) != 2 {
+		t.Fatalf("The posts should be visible once the user is unblocked : %+v", posts)
+	}
+}
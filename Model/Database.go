@@ -421,7 +421,7 @@ func SelectFromDb(tabelName string, db *sql.DB, Args map[string]any) (UserData,
 /*
 This function takes 2 arguments:
   - a string who is the name of the table
-  - a map with containing the wanted values in each row
+  - a map with containing the wanted values in each row, a []string value accepts any of its values
 
 The objective of this function is to format, prepare and execute the SQL request.
 
@@ -436,6 +436,16 @@ func PrepareStmt(tabelName string, db *sql.DB, Args map[string]any) ([]string, *
 
 	// Building the WHERE clause with parameters
 	for column, value := range Args {
+		// A list of values selects the rows matching any of them
+		if values, ok := value.([]string); ok {
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+			whereClauses = append(whereClauses, fmt.Sprintf("%s IN (%s)", column, placeholders))
+			for _, v := range values {
+				params = append(params, v)
+			}
+			continue
+		}
+
 		// Use "?" for parameters
 		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", column))
 		// Add the corresponding values
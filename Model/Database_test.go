@@ -52,7 +52,7 @@ func CreateTables(db *sql.DB) {
 			Username VARCHAR(50),
 			AboutMe VARCHAR(280),
 			Status VARCHAR(20),
-			GroupsJoined TEXT,
+			SensitiveContent VARCHAR(10) NOT NULL DEFAULT 'hide',
 		
 			CONSTRAINT fk_id FOREIGN KEY (Id) REFERENCES "Auth"("Id") ON DELETE CASCADE
 		);
@@ -67,27 +67,17 @@ func CreateTables(db *sql.DB) {
 		    IsGroup VARCHAR(36),
 		    LikeCount INTEGER,
 		    DislikeCount INTEGER,
+		    RepostOf VARCHAR(36),
+		    RepostCount INTEGER DEFAULT 0,
+		    ContentWarning VARCHAR(200) NOT NULL DEFAULT '',
+		    SensitiveMedia BOOLEAN NOT NULL DEFAULT 0,
+		    IsAnnouncement BOOLEAN NOT NULL DEFAULT 0,
 		
 			PRIMARY KEY (Id),
 		
 			CONSTRAINT fk_authorid FOREIGN KEY (AuthorId) REFERENCES "UserInfo"("Id"),
-			CONSTRAINT fk_isgroup FOREIGN KEY (IsGroup) REFERENCES "Groups"("Id") ON DELETE CASCADE
-		);
-		
-		CREATE TABLE IF NOT EXISTS LikePost (
-			PostId VARCHAR(36) NOT NULL,
-			UserId VARCHAR(36) NOT NULL,
-		
-			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
-			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
-		);
-		
-		CREATE TABLE IF NOT EXISTS DislikePost (
-			PostId VARCHAR(36) NOT NULL,
-			UserId VARCHAR(36) NOT NULL,
-		
-			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
-			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+			CONSTRAINT fk_isgroup FOREIGN KEY (IsGroup) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_repostof FOREIGN KEY (RepostOf) REFERENCES "Post"("Id") ON DELETE SET NULL
 		);
 		
 		CREATE TABLE IF NOT EXISTS Comment (
@@ -99,6 +89,8 @@ func CreateTables(db *sql.DB) {
 			PostId VARCHAR(36),
 			LikeCount INTEGER,
 			DislikeCount INTEGER,
+			ContentWarning VARCHAR(200) NOT NULL DEFAULT '',
+			SensitiveMedia BOOLEAN NOT NULL DEFAULT 0,
 
 			PRIMARY KEY (Id),
 
@@ -106,22 +98,6 @@ func CreateTables(db *sql.DB) {
 			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE
 		);
 		
-		CREATE TABLE IF NOT EXISTS LikeComment (
-			PostId VARCHAR(36) NOT NULL,
-			UserId VARCHAR(36) NOT NULL,
-		
-			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
-			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
-		);
-		
-		CREATE TABLE IF NOT EXISTS DislikeComment (
-			PostId VARCHAR(36) NOT NULL,
-			UserId VARCHAR(36) NOT NULL,
-		
-			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
-			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
-		);
-		
 		CREATE TABLE IF NOT EXISTS Follower (
 			Id VARCHAR(36) NOT NULL,
 			FollowerId VARCHAR(36) NOT NULL,
@@ -136,18 +112,83 @@ func CreateTables(db *sql.DB) {
 		CREATE TABLE IF NOT EXISTS Groups (
 			Id VARCHAR(36) NOT NULL,
 			LeaderId VARCHAR(36) NOT NULL,
-			MemberIds TEXT NOT NULL,
 			GroupName VARCHAR(200) NOT NULL,
 			GroupDescription VARCHAR(500),
 			CreationDate VARCHAR(20) NOT NULL,
 			Banner TEXT,
 			GroupPicture TEXT,
+			Visibility VARCHAR(10) NOT NULL DEFAULT 'private',
+			Rules TEXT NOT NULL DEFAULT '',
+			ArchivedAt VARCHAR(30) NOT NULL DEFAULT '',
 
 			PRIMARY KEY (Id),
 
 			CONSTRAINT fk_leaderid FOREIGN KEY (LeaderId) REFERENCES "UserInfo"("Id")	
 		);
 
+		CREATE TABLE IF NOT EXISTS GroupMember (
+			GroupId VARCHAR(36) NOT NULL,
+			UserId VARCHAR(36) NOT NULL,
+			Role VARCHAR(20) NOT NULL DEFAULT 'member',
+			JoinedAt VARCHAR(30) NOT NULL DEFAULT '',
+
+			PRIMARY KEY (GroupId, UserId),
+
+			CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS GroupMemberUser ON GroupMember(UserId);
+
+		CREATE TABLE IF NOT EXISTS GroupBan (
+			GroupId VARCHAR(36) NOT NULL,
+			UserId VARCHAR(36) NOT NULL,
+			BannedBy VARCHAR(36) NOT NULL,
+			Reason VARCHAR(500) NOT NULL DEFAULT '',
+			CreationDate VARCHAR(20) NOT NULL,
+			ExpirationDate VARCHAR(20) NOT NULL DEFAULT '',
+
+			PRIMARY KEY (GroupId, UserId),
+
+			CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS GroupInviteLink (
+			Token VARCHAR(36) NOT NULL,
+			GroupId VARCHAR(36) NOT NULL,
+			CreatorId VARCHAR(36) NOT NULL,
+			CreationDate VARCHAR(20) NOT NULL,
+			ExpirationDate VARCHAR(20) NOT NULL DEFAULT '',
+			MaxUses INTEGER NOT NULL DEFAULT 0,
+			Uses INTEGER NOT NULL DEFAULT 0,
+			SkipApproval BOOLEAN NOT NULL DEFAULT 0,
+
+			PRIMARY KEY (Token),
+
+			CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_creatorid FOREIGN KEY (CreatorId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS GroupCategory (
+			GroupId VARCHAR(36) NOT NULL,
+			Category VARCHAR(50) NOT NULL,
+
+			PRIMARY KEY (GroupId, Category),
+
+			CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS GroupQuestion (
+			GroupId VARCHAR(36) NOT NULL,
+			Position INTEGER NOT NULL,
+			Question VARCHAR(300) NOT NULL,
+
+			PRIMARY KEY (GroupId, Position),
+
+			CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE
+		);
+
 		CREATE TABLE IF NOT EXISTS Event (
 			Id VARCHAR(36),
 			GroupId VARCHAR(36),
@@ -189,6 +230,12 @@ func CreateTables(db *sql.DB) {
 			p.AuthorId,
 			p.LikeCount,
 			p.DislikeCount,
+			p.Status,
+			p.RepostOf,
+			p.RepostCount,
+			p.ContentWarning,
+			p.SensitiveMedia,
+			p.IsAnnouncement,
 			u.FirstName,
 			u.LastName,
 			u.ProfilePicture,
@@ -205,6 +252,8 @@ func CreateTables(db *sql.DB) {
 			c.LikeCount,
 			c.DislikeCount,
 			c.PostId,
+			c.ContentWarning,
+			c.SensitiveMedia,
 			u.FirstName,
 			u.LastName,
 			u.ProfilePicture,
@@ -215,6 +264,7 @@ func CreateTables(db *sql.DB) {
 		CREATE VIEW IF NOT EXISTS EventDetail AS
 		  SELECT 
 		    e.Id,
+		    g.Id AS GroupId,
 		    g.GroupName,
 
 		    CASE 
@@ -245,7 +295,11 @@ func CreateTables(db *sql.DB) {
 		LEFT JOIN UserInfo AS u2 ON u2.Id = j.UserId
 
 		LEFT JOIN DeclineEvent AS d ON d.EventId = e.Id
-		LEFT JOIN UserInfo AS u3 ON u3.Id = d.UserId;
+		LEFT JOIN UserInfo AS u3 ON u3.Id = d.UserId
+
+		GROUP BY 
+		    e.Id, g.Id, g.GroupName, u1.Username, u1.FirstName, u1.LastName, 
+		    e.Title, e.Description, e.DateOfTheEvent;
 
 
 		CREATE TABLE IF NOT EXISTS FollowingRequest (
@@ -264,6 +318,24 @@ func CreateTables(db *sql.DB) {
 			CONSTRAINT fk_followerid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE
 		);
 
+		CREATE TABLE IF NOT EXISTS JoinGroupAnswer (
+			GroupId VARCHAR(36) NOT NULL,
+			UserId VARCHAR(36) NOT NULL,
+			Position INTEGER NOT NULL,
+			Question VARCHAR(300) NOT NULL,
+			Answer VARCHAR(1000) NOT NULL,
+
+			PRIMARY KEY (GroupId, UserId, Position),
+
+			CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TRIGGER IF NOT EXISTS JoinGroupAnswerCleanup AFTER DELETE ON JoinGroupRequest
+		BEGIN
+			DELETE FROM JoinGroupAnswer WHERE GroupId = OLD.GroupId AND UserId = OLD.UserId;
+		END;
+
 		CREATE TABLE IF NOT EXISTS InviteGroupRequest (
 			SenderId VARCHAR(36) NOT NULL,
 			GroupId VARCHAR(36) NOT NULL,
@@ -307,9 +379,18 @@ func CreateTables(db *sql.DB) {
 			ELSE u.Username 
 			END AS Leader,
 
-			g.MemberIds,
+			(
+				SELECT GROUP_CONCAT(m.UserId, ' | ')
+				FROM (
+					SELECT UserId FROM GroupMember WHERE GroupId = g.Id ORDER BY JoinedAt, rowid
+				) AS m
+			) AS MemberIds,
 			g.groupName,
-			g.CreationDate
+			g.GroupDescription,
+			g.CreationDate,
+			g.Visibility,
+			g.Rules,
+			g.ArchivedAt
 
 		FROM Groups AS g
 		INNER JOIN UserInfo AS u ON u.Id = g.LeaderId;
@@ -384,6 +465,199 @@ func CreateTables(db *sql.DB) {
 
 			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
 		);
+		CREATE TABLE IF NOT EXISTS Reaction (
+			TargetType VARCHAR(20) NOT NULL,
+			TargetId VARCHAR(36) NOT NULL,
+			UserId VARCHAR(36) NOT NULL,
+			Kind VARCHAR(20) NOT NULL,
+			CreationDate VARCHAR(30) NOT NULL DEFAULT '',
+
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+
+			CHECK (TargetType IN ('Post', 'Comment')),
+			CHECK (Kind IN ('like', 'love', 'laugh', 'sad', 'angry', 'dislike'))
+		);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS ReactionUnique ON Reaction (TargetType, TargetId, UserId);
+
+		CREATE VIEW IF NOT EXISTS ReactionDetail AS
+			SELECT
+				r.TargetType,
+				r.TargetId,
+				r.UserId,
+
+				CASE 
+					WHEN u.Username = '' THEN CONCAT(u.FirstName, ' ', u.LastName)
+					ELSE u.Username 
+				END AS User_Name,
+
+				u.ProfilePicture AS User_Picture,
+				r.Kind
+
+			FROM Reaction AS r
+			INNER JOIN UserInfo AS u ON u.Id = r.UserId;
+
+		CREATE TABLE IF NOT EXISTS Bookmark (
+			UserId VARCHAR(36) NOT NULL,
+			PostId VARCHAR(36) NOT NULL,
+			Collection VARCHAR(50) NOT NULL DEFAULT '',
+			CreationDate VARCHAR(30) NOT NULL,
+
+			PRIMARY KEY (UserId, PostId),
+
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS PostDraft (
+			Id VARCHAR(36) NOT NULL,
+			AuthorId VARCHAR(36) NOT NULL,
+			Text VARCHAR(1000) NOT NULL,
+			Image TEXT NOT NULL DEFAULT '',
+			Status TEXT NOT NULL,
+			IsGroup VARCHAR(36),
+			PublishDate VARCHAR(20) NOT NULL DEFAULT '',
+			ContentWarning VARCHAR(200) NOT NULL DEFAULT '',
+			SensitiveMedia BOOLEAN NOT NULL DEFAULT 0,
+
+			PRIMARY KEY (Id),
+
+			CONSTRAINT fk_authorid FOREIGN KEY (AuthorId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_isgroup FOREIGN KEY (IsGroup) REFERENCES "Groups"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS Poll (
+			Id VARCHAR(36) NOT NULL,
+			PostId VARCHAR(36) NOT NULL UNIQUE,
+			MultipleChoice BOOLEAN NOT NULL DEFAULT 0,
+			Anonymous BOOLEAN NOT NULL DEFAULT 0,
+			ClosingDate VARCHAR(20) NOT NULL DEFAULT '',
+
+			PRIMARY KEY (Id),
+
+			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS PollOption (
+			Id VARCHAR(36) NOT NULL,
+			PollId VARCHAR(36) NOT NULL,
+			Position INTEGER NOT NULL,
+			Text VARCHAR(100) NOT NULL,
+
+			PRIMARY KEY (Id),
+
+			CONSTRAINT fk_pollid FOREIGN KEY (PollId) REFERENCES "Poll"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS PollVote (
+			PollId VARCHAR(36) NOT NULL,
+			OptionId VARCHAR(36) NOT NULL,
+			UserId VARCHAR(36) NOT NULL,
+
+			PRIMARY KEY (OptionId, UserId),
+
+			CONSTRAINT fk_pollid FOREIGN KEY (PollId) REFERENCES "Poll"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_optionid FOREIGN KEY (OptionId) REFERENCES "PollOption"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS PinnedPost (
+			PostId VARCHAR(36) NOT NULL,
+			OwnerId VARCHAR(36) NOT NULL,
+			Position INTEGER NOT NULL,
+
+			PRIMARY KEY (PostId),
+
+			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS LinkPreview (
+			SourceType VARCHAR(20) NOT NULL,
+			SourceId VARCHAR(36) NOT NULL,
+			Url TEXT NOT NULL,
+			Title TEXT NOT NULL DEFAULT '',
+			Description TEXT NOT NULL DEFAULT '',
+			Image TEXT NOT NULL DEFAULT '',
+			FetchDate VARCHAR(30) NOT NULL,
+
+			PRIMARY KEY (SourceType, SourceId),
+
+			CHECK (SourceType IN ('Post', 'Message'))
+		);
+
+		CREATE TABLE IF NOT EXISTS PostView (
+			PostId VARCHAR(36) NOT NULL,
+			ViewerId VARCHAR(36) NOT NULL,
+			Day VARCHAR(10) NOT NULL,
+
+			PRIMARY KEY (PostId, ViewerId, Day),
+
+			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_viewerid FOREIGN KEY (ViewerId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS AnnouncementAck (
+			PostId VARCHAR(36) NOT NULL,
+			UserId VARCHAR(36) NOT NULL,
+			CreationDate VARCHAR(30) NOT NULL,
+
+			PRIMARY KEY (PostId, UserId),
+
+			CONSTRAINT fk_postid FOREIGN KEY (PostId) REFERENCES "Post"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS GroupFile (
+			Id VARCHAR(36) NOT NULL,
+			GroupId VARCHAR(36) NOT NULL,
+			UploaderId VARCHAR(36) NOT NULL,
+			Folder VARCHAR(200) NOT NULL DEFAULT '',
+			Name VARCHAR(255) NOT NULL,
+			MimeType VARCHAR(100) NOT NULL,
+			Size INTEGER NOT NULL,
+			CreationDate VARCHAR(30) NOT NULL,
+
+			PRIMARY KEY (Id),
+			UNIQUE (GroupId, Folder, Name),
+
+			CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_uploaderid FOREIGN KEY (UploaderId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS UserBlock (
+			BlockerId VARCHAR(36) NOT NULL,
+			BlockedId VARCHAR(36) NOT NULL,
+			CreationDate VARCHAR(20) NOT NULL,
+
+			PRIMARY KEY (BlockerId, BlockedId),
+
+			CONSTRAINT fk_blockerid FOREIGN KEY (BlockerId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_blockedid FOREIGN KEY (BlockedId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS UserMute (
+			UserId VARCHAR(36) NOT NULL,
+			MutedUserId VARCHAR(36) NOT NULL,
+			CreationDate VARCHAR(20) NOT NULL,
+			ExpirationDate VARCHAR(20) NOT NULL DEFAULT '',
+
+			PRIMARY KEY (UserId, MutedUserId),
+
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_muteduserid FOREIGN KEY (MutedUserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS GroupMute (
+			UserId VARCHAR(36) NOT NULL,
+			GroupId VARCHAR(36) NOT NULL,
+			CreationDate VARCHAR(20) NOT NULL,
+			ExpirationDate VARCHAR(20) NOT NULL DEFAULT '',
+
+			PRIMARY KEY (UserId, GroupId),
+
+			CONSTRAINT fk_userid FOREIGN KEY (UserId) REFERENCES "UserInfo"("Id") ON DELETE CASCADE,
+			CONSTRAINT fk_groupid FOREIGN KEY (GroupId) REFERENCES "Groups"("Id") ON DELETE CASCADE
+		);
 	`)
 }
 
@@ -622,6 +896,18 @@ func TestSelectFromDb(t *testing.T) {
 		t.Errorf("Mot de passe attendu : 'JAimeCoder1234', obtenu : '%s'", res.Password)
 		return
 	}
+
+	// A list of values selects the rows matching any of them
+	result, err = SelectFromDb("Auth", db, map[string]any{"Id": []string{"1", "2", "3"}})
+	if err != nil {
+		t.Fatalf("Erreur lors de l'exécution de SelectFromDb : %v", err)
+		return
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Nombre de lignes attendu : 2, obtenu : %d", len(result))
+		return
+	}
 }
 
 func TestPrepareUpdateStmt(t *testing.T) {
@@ -4,7 +4,9 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 )
@@ -185,6 +187,259 @@ func (userData *UserData) ParseCommentData() (Comment, error) {
 	return comments[0], err
 }
 
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains reaction data.
+
+The purpose of this function is to parse the reaction data into a structured array of Reaction objects.
+
+The function returns 2 values:
+  - an array of Reaction objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseReactionsData() (Reactions, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled reaction data
+	var reactionResult Reactions
+
+	// We unmarshal the JSON data into the reactionResult slice
+	err = json.Unmarshal(serializedData, &reactionResult)
+
+	// Return the result and any error encountered
+	return reactionResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "Poll" table.
+
+The purpose of this function is to parse the poll rows into an array of Poll objects.
+
+The function returns 2 values:
+  - an array of Poll objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParsePollsData() ([]Poll, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled poll data
+	var pollResult []Poll
+
+	// We unmarshal the JSON data into the pollResult slice
+	err = json.Unmarshal(serializedData, &pollResult)
+
+	// Return the result and any error encountered
+	return pollResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "PollOption" table.
+
+The purpose of this function is to parse the option rows into an array of PollOption objects.
+
+The function returns 2 values:
+  - an array of PollOption objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParsePollOptionsData() (PollOptions, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled option data
+	var optionResult PollOptions
+
+	// We unmarshal the JSON data into the optionResult slice
+	err = json.Unmarshal(serializedData, &optionResult)
+
+	// Return the result and any error encountered
+	return optionResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "PollVote" table.
+
+The purpose of this function is to parse the vote rows into an array of PollVote objects.
+
+The function returns 2 values:
+  - an array of PollVote objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParsePollVotesData() (PollVotes, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled vote data
+	var voteResult PollVotes
+
+	// We unmarshal the JSON data into the voteResult slice
+	err = json.Unmarshal(serializedData, &voteResult)
+
+	// Return the result and any error encountered
+	return voteResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "PinnedPost" table.
+
+The purpose of this function is to parse the pin rows into a PinnedPosts array.
+
+The function returns 2 values:
+  - an array of PinnedPost objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParsePinnedPostsData() (PinnedPosts, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled pin data
+	var pinResult PinnedPosts
+
+	// We unmarshal the JSON data into the pinResult slice
+	err = json.Unmarshal(serializedData, &pinResult)
+
+	// Return the result and any error encountered
+	return pinResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "AnnouncementAck" table.
+
+The purpose of this function is to parse the acknowledgement rows into an AnnouncementAcks array.
+
+The function returns 2 values:
+  - an array of AnnouncementAck objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseAnnouncementAcksData() (AnnouncementAcks, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled acknowledgement data
+	var ackResult AnnouncementAcks
+
+	// We unmarshal the JSON data into the ackResult slice
+	err = json.Unmarshal(serializedData, &ackResult)
+
+	// Return the result and any error encountered
+	return ackResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "LinkPreview" table.
+
+The purpose of this function is to parse the preview rows into a LinkPreviews array.
+
+The function returns 2 values:
+  - an array of LinkPreview objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseLinkPreviewsData() (LinkPreviews, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled preview data
+	var previewResult LinkPreviews
+
+	// We unmarshal the JSON data into the previewResult slice
+	err = json.Unmarshal(serializedData, &previewResult)
+
+	// Return the result and any error encountered
+	return previewResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "PostDraft" table.
+
+The purpose of this function is to parse the draft rows into a PostDrafts array.
+
+The function returns 2 values:
+  - an array of PostDraft objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParsePostDraftsData() (PostDrafts, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled draft data
+	var draftResult PostDrafts
+
+	// We unmarshal the JSON data into the draftResult slice
+	err = json.Unmarshal(serializedData, &draftResult)
+
+	// Return the result and any error encountered
+	return draftResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "Bookmark" table.
+
+The purpose of this function is to parse the bookmark rows into a Bookmarks array.
+The Post of each bookmark isn't filled, it must be fetched separately.
+
+The function returns 2 values:
+  - an array of Bookmark objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseBookmarksData() (Bookmarks, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled bookmark data
+	var bookmarkResult Bookmarks
+
+	// We unmarshal the JSON data into the bookmarkResult slice
+	err = json.Unmarshal(serializedData, &bookmarkResult)
+
+	// Return the result and any error encountered
+	return bookmarkResult, err
+}
+
 /*
 This function takes 1 argument:
   - a array of map who contain the value of the select and the name of the colum in the db selected
@@ -294,6 +549,90 @@ func (userData *UserData) ParseFollowRequestsData() (FollowRequests, error) {
 	return FollowRequests, err
 }
 
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "UserBlock" table.
+
+The purpose of this function is to parse the block rows into a UserBlocks array.
+
+The function returns 2 values:
+  - an array of UserBlock objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseUserBlocksData() (UserBlocks, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled block data
+	var blockResult UserBlocks
+
+	// We unmarshal the JSON data into the blockResult slice
+	err = json.Unmarshal(serializedData, &blockResult)
+
+	// Return the result and any error encountered
+	return blockResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "UserMute" table.
+
+The purpose of this function is to parse the mute rows into a UserMutes array.
+
+The function returns 2 values:
+  - an array of UserMute objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseUserMutesData() (UserMutes, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled mute data
+	var muteResult UserMutes
+
+	// We unmarshal the JSON data into the muteResult slice
+	err = json.Unmarshal(serializedData, &muteResult)
+
+	// Return the result and any error encountered
+	return muteResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "GroupMute" table.
+
+The purpose of this function is to parse the mute rows into a GroupMutes array.
+
+The function returns 2 values:
+  - an array of GroupMute objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseGroupMutesData() (GroupMutes, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled mute data
+	var muteResult GroupMutes
+
+	// We unmarshal the JSON data into the muteResult slice
+	err = json.Unmarshal(serializedData, &muteResult)
+
+	// Return the result and any error encountered
+	return muteResult, err
+}
+
 /*
 This function takes 1 argument:
   - a pointer to a UserData object, which contains group data.
@@ -341,32 +680,172 @@ func (userData *UserData) ParseGroupsData() (Groups, error) {
 	return groups, err
 }
 
-func (userData *UserData) ParseJoinGroupRequestsData() (JoinGroupRequests, error) {
-	serializedData, err := json.Marshal(userData)
-	if err != nil {
-		return nil, err
-	}
-
-	var joinGroupRequests JoinGroupRequests
-	err = json.Unmarshal(serializedData, &joinGroupRequests)
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "GroupMember" table.
 
-	return joinGroupRequests, err
-}
+The purpose of this function is to parse the membership rows into a GroupMembers array.
 
-func (userData *UserData) ParseInviteGroupRequestsData() (InviteGroupRequests, error) {
+The function returns 2 values:
+  - an array of GroupMember objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseGroupMembersData() (GroupMembers, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
 	serializedData, err := json.Marshal(userData)
 	if err != nil {
-		return nil, err
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
 	}
 
-	var inviteGroupRequests InviteGroupRequests
-	err = json.Unmarshal(serializedData, &inviteGroupRequests)
+	// We declare a variable to hold the unmarshaled membership data
+	var memberResult GroupMembers
 
-	return inviteGroupRequests, err
+	// We unmarshal the JSON data into the memberResult slice
+	err = json.Unmarshal(serializedData, &memberResult)
+
+	// Return the result and any error encountered
+	return memberResult, err
 }
 
 /*
-The purpose of this function is to parse the event data into a structured array of Events objects.
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "GroupBan" table.
+
+The purpose of this function is to parse the ban rows into a GroupBans array.
+
+The function returns 2 values:
+  - an array of GroupBan objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseGroupBansData() (GroupBans, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled ban data
+	var banResult GroupBans
+
+	// We unmarshal the JSON data into the banResult slice
+	err = json.Unmarshal(serializedData, &banResult)
+
+	// Return the result and any error encountered
+	return banResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "GroupInviteLink" table.
+
+The purpose of this function is to parse the invite link rows into a GroupInviteLinks array.
+
+The function returns 2 values:
+  - an array of GroupInviteLink objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseGroupInviteLinksData() (GroupInviteLinks, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled invite link data
+	var linkResult GroupInviteLinks
+
+	// We unmarshal the JSON data into the linkResult slice
+	err = json.Unmarshal(serializedData, &linkResult)
+
+	// Return the result and any error encountered
+	return linkResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "GroupFile" table.
+
+The purpose of this function is to parse the file rows into a GroupFiles array.
+
+The function returns 2 values:
+  - an array of GroupFile objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseGroupFilesData() (GroupFiles, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled file data
+	var fileResult GroupFiles
+
+	// We unmarshal the JSON data into the fileResult slice
+	err = json.Unmarshal(serializedData, &fileResult)
+
+	// Return the result and any error encountered
+	return fileResult, err
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserData object, which contains the data retrieved from the "JoinGroupAnswer" table.
+
+The purpose of this function is to parse the answer rows into a JoinGroupAnswers array.
+
+The function returns 2 values:
+  - an array of JoinGroupAnswer objects
+  - an error if something goes wrong during the parsing
+*/
+func (userData *UserData) ParseJoinGroupAnswersData() (JoinGroupAnswers, error) {
+	// We marshal the userData to convert it to JSON format ([]byte)
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		// Return an error if the marshaling fails
+		return nil, errors.New("internal error: conversion problem")
+	}
+
+	// We declare a variable to hold the unmarshaled answer data
+	var answerResult JoinGroupAnswers
+
+	// We unmarshal the JSON data into the answerResult slice
+	err = json.Unmarshal(serializedData, &answerResult)
+
+	// Return the result and any error encountered
+	return answerResult, err
+}
+
+func (userData *UserData) ParseJoinGroupRequestsData() (JoinGroupRequests, error) {
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		return nil, err
+	}
+
+	var joinGroupRequests JoinGroupRequests
+	err = json.Unmarshal(serializedData, &joinGroupRequests)
+
+	return joinGroupRequests, err
+}
+
+func (userData *UserData) ParseInviteGroupRequestsData() (InviteGroupRequests, error) {
+	serializedData, err := json.Marshal(userData)
+	if err != nil {
+		return nil, err
+	}
+
+	var inviteGroupRequests InviteGroupRequests
+	err = json.Unmarshal(serializedData, &inviteGroupRequests)
+
+	return inviteGroupRequests, err
+}
+
+/*
+The purpose of this function is to parse the event data into a structured array of Events objects.
 
 The function returns 2 values:
   - an array of Event objects
@@ -684,12 +1163,16 @@ func (register *Register) InsertIntoDb(db *sql.DB) error {
 		register.Status = "private"
 	}
 
+	if register.SensitiveContent != "expand" {
+		register.SensitiveContent = "hide"
+	}
+
 	if register.Banner == "" {
 		register.Banner = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAbAAAAD9CAYAAADd/yIsAAAABHNCSVQICAgIfAhkiAAAABl0RVh0U29mdHdhcmUAZ25vbWUtc2NyZWVuc2hvdO8Dvz4AAAAodEVYdENyZWF0aW9uIFRpbWUAbWVyLiAyMCBub3YuIDIwMjQgMTE6NDA6MjGc5VWRAAAD1ElEQVR4nO3VQQ0AIBDAMMC/58MDH7KkVbDf9szMAoCY8zsAAF4YGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkHQBjPMF9sYol6wAAAAASUVORK5CYII="
 	}
 
 	// We call InsertIntoDb to insert the registration data into the "UserInfo" table in the database
-	return InsertIntoDb("UserInfo", db, register.Auth.Id, register.Auth.Email, register.FirstName, register.LastName, register.BirthDate, register.ProfilePicture, register.Banner, register.Username, register.AboutMe, register.Status, register.GroupsJoined)
+	return InsertIntoDb("UserInfo", db, register.Auth.Id, register.Auth.Email, register.FirstName, register.LastName, register.BirthDate, register.ProfilePicture, register.Banner, register.Username, register.AboutMe, register.Status, register.SensitiveContent)
 }
 
 /*
@@ -712,10 +1195,23 @@ func (register *Register) SelectFromDb(db *sql.DB, where map[string]any) error {
 	}
 
 	// We parse the retrieved data into the Register structure and assign it to the register object
-	*register, err = userData.ParseRegisterData()
+	if *register, err = userData.ParseRegisterData(); err != nil {
+		return err
+	}
 
-	// Return any error encountered during parsing
-	return err
+	// The groups of the user are kept in the "GroupMember" table, GroupsJoined is only filled for the responses
+	var members GroupMembers
+	if err = members.SelectFromDb(db, map[string]any{"UserId": register.Id}); err != nil {
+		return err
+	}
+
+	register.SplitGroupsJoined = nil
+	for _, member := range members {
+		register.SplitGroupsJoined = append(register.SplitGroupsJoined, member.GroupId)
+	}
+	register.JoinGroups()
+
+	return nil
 }
 
 /*
@@ -783,6 +1279,18 @@ func (register *Register) JoinGroups() {
 // ----------------------------------------------------------------------------------------------
 // ----------------------------------------------------------------------------------------------
 
+/*
+This function takes 2 arguments:
+  - a pointer to a Users object, which will be populated with the users retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "UserInfo" table.
+
+The purpose of this function is to retrieve multiple users from the database based on the given conditions.
+The groups of the users are kept in the "GroupMember" table, they are fetched in a single query to fill GroupsJoined.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
 func (users *Users) SelectFromDb(db *sql.DB, where map[string]any) error {
 	// We call SelectFromDb to retrieve data from the "UserInfo" table based on the given conditions
 	userData, err := SelectFromDb("UserInfo", db, where)
@@ -791,10 +1299,39 @@ func (users *Users) SelectFromDb(db *sql.DB, where map[string]any) error {
 		return err
 	}
 
-	*users, err = userData.ParseUsersData()
+	if *users, err = userData.ParseUsersData(); err != nil {
+		return err
+	}
 
-	// Return any error encountered during parsing
-	return err
+	rows, err := db.Query("SELECT UserId, GROUP_CONCAT(GroupId, ' | ') FROM GroupMember GROUP BY UserId")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	groupsJoined := map[string]string{}
+	for rows.Next() {
+		var userId, groups string
+		if err = rows.Scan(&userId, &groups); err != nil {
+			return err
+		}
+
+		groupsJoined[userId] = groups
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range *users {
+		(*users)[i].GroupsJoined = groupsJoined[(*users)[i].Id]
+		(*users)[i].SplitGroupsJoined = nil
+		if (*users)[i].GroupsJoined != "" {
+			(*users)[i].SplitGroups()
+		}
+	}
+
+	return nil
 }
 
 // ----------------------------------------------------------------------------------------------
@@ -816,12 +1353,33 @@ The function returns 1 value:
   - an error if any of the required fields are empty or if the insertion into the database fails
 */
 func (post *Post) InsertIntoDb(db *sql.DB) error {
-	// We check if any of the required fields (Id, AuthorId, Text, CreationDate) are empty
+	values, err := post.insertValues()
+	if err != nil {
+		return err
+	}
+
+	// We call InsertIntoDb to insert the post data into the "Post" table in the database
+	return InsertIntoDb("Post", db, values...)
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a Post object, which contains the post data to be inserted into the database.
+
+The purpose of this function is to check the post data and to build the values of a new row of the "Post" table,
+in the order of its columns.
+
+The function returns 2 values:
+  - the values of the row
+  - an error if any of the required fields are empty or if the status is invalid
+*/
+func (post *Post) insertValues() ([]any, error) {
+	// We check if any of the required fields (Id, AuthorId, Text, CreationDate) are empty, a repost can have no text
 	// We also validate the Status field to ensure it has an acceptable value
-	if post.Id == "" || post.AuthorId == "" || post.Text == "" || post.CreationDate == "" ||
+	if post.Id == "" || post.AuthorId == "" || (post.Text == "" && post.RepostOf == "") || post.CreationDate == "" ||
 		(post.Status != "public" && post.Status != "private" && strings.Split(post.Status, " | ")[0] != "almost private") {
 		// Return an error if any field is empty or if Status is invalid
-		return errors.New("empty field")
+		return nil, errors.New("empty field")
 	}
 
 	// We create a sql.NullString to handle the IsGroup field for optional values
@@ -831,8 +1389,14 @@ func (post *Post) InsertIntoDb(db *sql.DB) error {
 		isGroup.Valid = true
 	}
 
-	// We call InsertIntoDb to insert the post data into the "Post" table in the database
-	return InsertIntoDb("Post", db, post.Id, post.AuthorId, post.Text, post.Image, post.CreationDate, post.Status, isGroup, 0, 0)
+	// The RepostOf field is optional too
+	var repostOf = sql.NullString{Valid: false}
+	if post.RepostOf != "" {
+		repostOf.String = post.RepostOf
+		repostOf.Valid = true
+	}
+
+	return []any{post.Id, post.AuthorId, post.Text, post.Image, post.CreationDate, post.Status, isGroup, 0, 0, repostOf, 0, post.ContentWarning, post.SensitiveMedia, post.IsAnnouncement}, nil
 }
 
 /*
@@ -923,407 +1487,2776 @@ func (post *Posts) SelectFromDb(db *sql.DB, where map[string]any) error {
 // ----------------------------------------------------------------------------------------------
 // ----------------------------------------------------------------------------------------------
 //
-//	DB Method for Comment struct
+//	DB Method for PostDraft struct
 //
 // ----------------------------------------------------------------------------------------------
 // ----------------------------------------------------------------------------------------------
 
 /*
 This function takes 1 argument:
-  - a pointer to a Comment object, which contains the comment data to be inserted into the database.
+  - a pointer to a PostDraft object, which contains the draft data to be inserted into the database.
   - a pointer to an sql.DB object, representing the database connection.
 
-The purpose of this function is to insert the comment data into the "Comment" table in the database.
+The purpose of this function is to insert the draft data into the "PostDraft" table in the database.
 
 The function returns 1 value:
   - an error if any of the required fields are empty or if the insertion into the database fails
 */
-func (comment *Comment) InsertIntoDb(db *sql.DB) error {
-	// We check if any of the required fields (Id, AuthorId, Text, CreationDate, PostId) are empty
-	if comment.Id == "" || comment.AuthorId == "" || comment.Text == "" || comment.CreationDate == "" || comment.PostId == "" {
-		// Return an error if any field is empty
+func (draft *PostDraft) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (Id, AuthorId, Status) are empty, the text can be written later
+	if draft.Id == "" || draft.AuthorId == "" || draft.Status == "" {
 		return errors.New("empty field")
 	}
 
-	// We call InsertIntoDb to insert the comment data into the "Comment" table in the database
-	return InsertIntoDb("Comment", db, comment.Id, comment.AuthorId, comment.Text, comment.Image, comment.CreationDate, comment.PostId, 0, 0)
+	// We create a sql.NullString to handle the IsGroup field for optional values
+	var isGroup = sql.NullString{Valid: false}
+	if draft.IsGroup != "" {
+		isGroup.String = draft.IsGroup
+		isGroup.Valid = true
+	}
+
+	// We call InsertIntoDb to insert the draft data into the "PostDraft" table in the database
+	return InsertIntoDb("PostDraft", db, draft.Id, draft.AuthorId, draft.Text, draft.Image, draft.Status, isGroup, draft.PublishDate, draft.ContentWarning, draft.SensitiveMedia)
 }
 
 /*
 This function takes 2 arguments:
-  - a pointer to a Comment object, which will be populated with the comment data retrieved from the database.
+  - a pointer to a PostDraft object, which will be populated with the draft data retrieved from the database.
   - a pointer to an sql.DB object, representing the database connection.
-  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "CommentDetail" table.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "PostDraft" table.
 
-The purpose of this function is to retrieve comment data from the database based on the given conditions.
+The purpose of this function is to retrieve the draft data from the database based on the given conditions.
 
 The function returns 1 value:
-  - an error if the Id field is not set, or if the data retrieval or parsing fails
+  - an error if the data retrieval or parsing fails, or if there is no draft
 */
-func (comment *Comment) SelectFromDb(db *sql.DB, where map[string]any) error {
-	// We call SelectFromDb to retrieve data from the "CommentDetail" table based on the given conditions
-	userData, err := SelectFromDb("CommentDetail", db, where)
-	if err != nil {
-		// Return an error if the data retrieval fails
+func (draft *PostDraft) SelectFromDb(db *sql.DB, where map[string]any) error {
+	var drafts PostDrafts
+	if err := drafts.SelectFromDb(db, where); err != nil {
 		return err
 	}
 
-	// We parse the retrieved data into the Comment structure and assign it to the comment object
-	*comment, err = userData.ParseCommentData()
+	if len(drafts) == 0 {
+		return errors.New("there is no data")
+	}
 
-	// Return any error encountered during parsing
-	return err
+	*draft = drafts[0]
+	return nil
 }
 
 /*
 This function takes 3 arguments:
-  - a pointer to a Comment object, which represents the comment data to be updated.
+  - a pointer to a PostDraft object, which represents the draft data to be updated.
   - a pointer to an sql.DB object, representing the database connection.
   - a map[string]any containing the updateData, which holds the values to be updated.
   - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to update.
 
-The purpose of this function is to update the comment data in the "Comment" table based on the provided conditions.
+The purpose of this function is to update the draft data in the "PostDraft" table based on the provided conditions.
 
 The function returns 1 value:
   - an error if the update operation fails
 */
-func (comment *Comment) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
-	// We call UpdateDb to update the "Comment" table with the provided data and conditions
-	return UpdateDb("Comment", db, updateData, where)
+func (draft *PostDraft) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
+	// We call UpdateDb to update the "PostDraft" table with the provided data and conditions
+	return UpdateDb("PostDraft", db, updateData, where)
 }
 
 /*
 This function takes 2 arguments:
-  - a pointer to a Comment object, which represents the comment data to be deleted.
+  - a pointer to a PostDraft object, which represents the draft data to be deleted.
   - a pointer to an sql.DB object, representing the database connection.
   - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
 
-The purpose of this function is to delete comment data from the "Comment" table based on the provided conditions.
+The purpose of this function is to delete draft data from the "PostDraft" table based on the provided conditions.
 
 The function returns 1 value:
   - an error if the delete operation fails
 */
-func (comment *Comment) DeleteFromDb(db *sql.DB, where map[string]any) error {
-	// We call RemoveFromDB to delete the record(s) from the "Comment" table based on the specified conditions
-	return RemoveFromDB("Comment", db, where)
+func (draft *PostDraft) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "PostDraft" table based on the specified conditions
+	return RemoveFromDB("PostDraft", db, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a PostDraft object, which represents the draft to publish.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a Post object, which is the post built from the draft.
+
+The purpose of this function is to insert the post and to remove the draft in a single transaction,
+so the draft is only removed once the post has been saved, and a draft is never published twice.
+
+The function returns 2 values:
+  - false if the draft doesn't exist anymore (canceled or already published), nothing is inserted then
+  - an error if the post is invalid or if the transaction fails
+*/
+func (draft *PostDraft) Publish(db *sql.DB, post Post) (bool, error) {
+	values, err := post.insertValues()
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	// Rollback does nothing once the transaction has been committed.
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("INSERT INTO Post VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", values...); err != nil {
+		return false, err
+	}
+
+	result, err := tx.Exec("DELETE FROM PostDraft WHERE Id = ?", draft.Id)
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a PostDrafts object, which will be populated with the draft data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "PostDraft" table.
+
+The purpose of this function is to retrieve multiple drafts from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (drafts *PostDrafts) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "PostDraft" table based on the given conditions
+	userData, err := SelectFromDb("PostDraft", db, where)
+	if err != nil {
+		return err
+	}
+
+	// We parse the retrieved data into the PostDrafts structure and assign it to the drafts object
+	*drafts, err = userData.ParsePostDraftsData()
+
+	return err
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a PostDrafts object, which will be populated with the scheduled drafts.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string with a date in the "2006-01-02 15:04" format.
+
+The purpose of this function is to retrieve the scheduled drafts whose publication date is before or equal to the given date.
+The dates have a fixed format, so the db can compare them as strings.
+
+The function returns 1 value:
+  - an error if the data retrieval fails
+*/
+func (drafts *PostDrafts) SelectScheduledBefore(db *sql.DB, date string) error {
+	rows, err := db.Query("SELECT Id, AuthorId, Text, Image, Status, IsGroup, PublishDate, ContentWarning, SensitiveMedia FROM PostDraft WHERE PublishDate <> '' AND PublishDate <= ? ORDER BY PublishDate", date)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	*drafts = PostDrafts{}
+	for rows.Next() {
+		var draft PostDraft
+		var isGroup sql.NullString
+		if err = rows.Scan(&draft.Id, &draft.AuthorId, &draft.Text, &draft.Image, &draft.Status, &isGroup, &draft.PublishDate, &draft.ContentWarning, &draft.SensitiveMedia); err != nil {
+			return err
+		}
+
+		draft.IsGroup = isGroup.String
+		*drafts = append(*drafts, draft)
+	}
+
+	return rows.Err()
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for Poll struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a Poll object, which contains the poll data and its options to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the poll into the "Poll" table and its options into the "PollOption" table.
+Everything is done in a single transaction, so a poll is never saved without its options.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (poll *Poll) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (Id, PostId, Options) are empty
+	if poll.Id == "" || poll.PostId == "" || len(poll.Options) == 0 {
+		return errors.New("empty field")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("INSERT INTO Poll VALUES(?, ?, ?, ?, ?)", poll.Id, poll.PostId, poll.MultipleChoice, poll.Anonymous, poll.ClosingDate); err != nil {
+		return err
+	}
+
+	for _, option := range poll.Options {
+		if option.Id == "" || option.Text == "" {
+			return errors.New("empty field")
+		}
+
+		if _, err = tx.Exec("INSERT INTO PollOption VALUES(?, ?, ?, ?)", option.Id, poll.Id, option.Position, option.Text); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Poll object, which will be populated with the poll data and its options retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "Poll" table.
+
+The purpose of this function is to retrieve a poll and its options, sorted by position, from the database.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails, or if there is no poll
+*/
+func (poll *Poll) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "Poll" table based on the given conditions
+	userData, err := SelectFromDb("Poll", db, where)
+	if err != nil {
+		return err
+	}
+
+	polls, err := userData.ParsePollsData()
+	if err != nil {
+		return err
+	}
+
+	if len(polls) == 0 {
+		return errors.New("there is no data")
+	}
+	*poll = polls[0]
+
+	// We fetch the options of the poll
+	userData, err = SelectFromDb("PollOption", db, map[string]any{"PollId": poll.Id})
+	if err != nil {
+		return err
+	}
+
+	if poll.Options, err = userData.ParsePollOptionsData(); err != nil {
+		return err
+	}
+
+	slices.SortFunc(poll.Options, func(a, b PollOption) int {
+		return a.Position - b.Position
+	})
+
+	return nil
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a PollVotes object, which contains the votes of a user to insert.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert all the votes of a user in a single transaction.
+The transaction fails if the user has already voted for the poll, so a user can only vote once.
+
+The function returns 1 value:
+  - an error if the user has already voted or if the insertion fails
+*/
+func (votes *PollVotes) InsertIntoDb(db *sql.DB) error {
+	if len(*votes) == 0 {
+		return errors.New("empty field")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var alreadyVoted int
+	if err = tx.QueryRow("SELECT COUNT(*) FROM PollVote WHERE PollId = ? AND UserId = ?", (*votes)[0].PollId, (*votes)[0].UserId).Scan(&alreadyVoted); err != nil {
+		return err
+	}
+
+	if alreadyVoted > 0 {
+		return errors.New("the user has already voted")
+	}
+
+	for _, vote := range *votes {
+		if vote.PollId == "" || vote.OptionId == "" || vote.UserId == "" {
+			return errors.New("empty field")
+		}
+
+		if _, err = tx.Exec("INSERT INTO PollVote VALUES(?, ?, ?)", vote.PollId, vote.OptionId, vote.UserId); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a PollVotes object, which will be populated with the votes retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "PollVote" table.
+
+The purpose of this function is to retrieve the votes of a poll from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (votes *PollVotes) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "PollVote" table based on the given conditions
+	userData, err := SelectFromDb("PollVote", db, where)
+	if err != nil {
+		return err
+	}
+
+	*votes, err = userData.ParsePollVotesData()
+
+	return err
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for PinnedPost struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a PinnedPost object, which contains the pin data to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the pin data into the "PinnedPost" table in the database.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (pin *PinnedPost) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (PostId, OwnerId) are empty
+	if pin.PostId == "" || pin.OwnerId == "" {
+		return errors.New("empty field")
+	}
+
+	// We call InsertIntoDb to insert the pin data into the "PinnedPost" table in the database
+	return InsertIntoDb("PinnedPost", db, pin.PostId, pin.OwnerId, pin.Position)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a PinnedPost object, which represents the pin data to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete pin data from the "PinnedPost" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (pin *PinnedPost) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "PinnedPost" table based on the specified conditions
+	return RemoveFromDB("PinnedPost", db, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a PinnedPosts object, which will be populated with the pin data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "PinnedPost" table.
+
+The purpose of this function is to retrieve the pins from the database based on the given conditions, sorted by position.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (pins *PinnedPosts) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "PinnedPost" table based on the given conditions
+	userData, err := SelectFromDb("PinnedPost", db, where)
+	if err != nil {
+		return err
+	}
+
+	if *pins, err = userData.ParsePinnedPostsData(); err != nil {
+		return err
+	}
+
+	slices.SortFunc(*pins, func(a, b PinnedPost) int {
+		return a.Position - b.Position
+	})
+
+	return nil
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a PinnedPosts object, which contains all the pins of an owner in the wanted order.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to save the order of the pins, the position of each pin being its index.
+Everything is done in a single transaction.
+
+The function returns 1 value:
+  - an error if the update fails
+*/
+func (pins *PinnedPosts) UpdatePositions(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, pin := range *pins {
+		if _, err = tx.Exec("UPDATE PinnedPost SET Position = ? WHERE PostId = ? AND OwnerId = ?", i, pin.PostId, pin.OwnerId); err != nil {
+			return err
+		}
+		(*pins)[i].Position = i
+	}
+
+	return tx.Commit()
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for LinkPreview struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a LinkPreview object, which contains the preview data to be saved into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to save the preview data into the "LinkPreview" table in the database.
+An existing preview of the same content is replaced.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (preview *LinkPreview) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (SourceType, SourceId, Url, FetchDate) are empty
+	if preview.SourceType == "" || preview.SourceId == "" || preview.Url == "" || preview.FetchDate == "" {
+		return errors.New("empty field")
+	}
+
+	_, err := db.Exec("INSERT OR REPLACE INTO LinkPreview VALUES(?, ?, ?, ?, ?, ?, ?)",
+		preview.SourceType, preview.SourceId, preview.Url, preview.Title, preview.Description, preview.Image, preview.FetchDate)
+
+	return err
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a LinkPreview object, which will be populated with the preview data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "LinkPreview" table.
+
+The purpose of this function is to retrieve a single preview from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if there is no preview or if the data retrieval or parsing fails
+*/
+func (preview *LinkPreview) SelectFromDb(db *sql.DB, where map[string]any) error {
+	var previews LinkPreviews
+	if err := previews.SelectFromDb(db, where); err != nil {
+		return err
+	}
+
+	if len(previews) != 1 {
+		return errors.New("there is no preview")
+	}
+
+	*preview = previews[0]
+
+	return nil
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a LinkPreviews object, which will be populated with the preview data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "LinkPreview" table.
+
+The purpose of this function is to retrieve the previews from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (previews *LinkPreviews) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "LinkPreview" table based on the given conditions
+	userData, err := SelectFromDb("LinkPreview", db, where)
+	if err != nil {
+		return err
+	}
+
+	*previews, err = userData.ParseLinkPreviewsData()
+
+	return err
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for PostView struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a PostViews object, which contains the views to be saved into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to save a batch of views into the "PostView" table in a single transaction.
+A view already saved for the same post, viewer and day is ignored, so the views stay de-duplicated.
+
+The function returns 1 value:
+  - an error if the insertion into the database fails
+*/
+func (views *PostViews) InsertIntoDb(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// A post or a user deleted since the view is skipped instead of failing on the foreign keys.
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO PostView
+		SELECT ?, ?, ? WHERE EXISTS (SELECT 1 FROM Post WHERE Id = ?) AND EXISTS (SELECT 1 FROM UserInfo WHERE Id = ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, view := range *views {
+		if view.PostId == "" || view.ViewerId == "" || view.Day == "" {
+			return errors.New("empty field")
+		}
+
+		if _, err = stmt.Exec(view.PostId, view.ViewerId, view.Day, view.PostId, view.ViewerId); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a PostStats object, which will be populated with the statistics.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the id of the author of the posts.
+  - a slice of strings containing the days (format 2006-01-02) of the daily statistics, in order.
+
+The purpose of this function is to compute the views, the unique viewers, the reactions and the comments of the posts,
+in total and for each of the given days.
+The statistics are for the post of the PostId field when it is filled, or for all the posts of the author.
+The comments are counted on the day of their creation date, the reactions saved without a date only count in the total.
+
+The function returns 1 value:
+  - an error if one of the queries fails
+*/
+func (stats *PostStats) SelectFromDb(db *sql.DB, authorId string, days []string) error {
+	stats.Daily = make([]DailyPostStats, len(days))
+	for i, day := range days {
+		stats.Daily[i].Day = day
+	}
+
+	var since string
+	if len(days) > 0 {
+		since = days[0]
+	}
+
+	// The posts are selected by a subquery of an IN clause, so the quantity of posts doesn't change the query.
+	postsQuery := "SELECT Id FROM Post WHERE AuthorId = ?"
+	args := []any{authorId}
+	if stats.PostId != "" {
+		postsQuery += " AND Id = ?"
+		args = append(args, stats.PostId)
+	}
+
+	row := db.QueryRow(fmt.Sprintf("SELECT COUNT(*), COUNT(DISTINCT ViewerId) FROM PostView WHERE PostId IN (%s)", postsQuery), args...)
+	if err := row.Scan(&stats.Views, &stats.UniqueViewers); err != nil {
+		return err
+	}
+
+	row = db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM Reaction WHERE TargetType = 'Post' AND TargetId IN (%s)", postsQuery), args...)
+	if err := row.Scan(&stats.Reactions); err != nil {
+		return err
+	}
+
+	row = db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM Comment WHERE PostId IN (%s)", postsQuery), args...)
+	if err := row.Scan(&stats.Comments); err != nil {
+		return err
+	}
+
+	// Each query gives the quantity of the day, the dates which aren't dates are ignored.
+	dailyQueries := []struct {
+		query   string
+		counter func(*DailyPostStats) *int
+	}{
+		{
+			"SELECT Day, COUNT(*) FROM PostView WHERE PostId IN (%s) AND Day >= ? GROUP BY Day",
+			func(daily *DailyPostStats) *int { return &daily.Views },
+		},
+		{
+			"SELECT substr(CreationDate, 1, 10) AS Day, COUNT(*) FROM Reaction WHERE TargetType = 'Post' AND TargetId IN (%s) AND Day >= ? GROUP BY Day",
+			func(daily *DailyPostStats) *int { return &daily.Reactions },
+		},
+		{
+			"SELECT substr(CreationDate, 1, 10) AS Day, COUNT(*) FROM Comment WHERE PostId IN (%s) AND Day >= ? AND CreationDate GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*' GROUP BY Day",
+			func(daily *DailyPostStats) *int { return &daily.Comments },
+		},
+	}
+
+	for _, dailyQuery := range dailyQueries {
+		rows, err := db.Query(fmt.Sprintf(dailyQuery.query, postsQuery), append(args, since)...)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var day string
+			var count int
+			if err = rows.Scan(&day, &count); err != nil {
+				rows.Close()
+				return err
+			}
+
+			if index := slices.Index(days, day); index != -1 {
+				*dailyQuery.counter(&stats.Daily[index]) = count
+			}
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for AnnouncementAck struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to an AnnouncementAck object, which contains the acknowledgement to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the acknowledgement of an announcement into the "AnnouncementAck" table in the database.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (ack *AnnouncementAck) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (PostId, UserId, CreationDate) are empty
+	if ack.PostId == "" || ack.UserId == "" || ack.CreationDate == "" {
+		return errors.New("empty field")
+	}
+
+	// We call InsertIntoDb to insert the acknowledgement into the "AnnouncementAck" table in the database
+	return InsertIntoDb("AnnouncementAck", db, ack.PostId, ack.UserId, ack.CreationDate)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to an AnnouncementAcks object, which will be populated with the acknowledgements retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "AnnouncementAck" table.
+
+The purpose of this function is to retrieve the acknowledgements of the announcements based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (acks *AnnouncementAcks) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "AnnouncementAck" table based on the given conditions
+	userData, err := SelectFromDb("AnnouncementAck", db, where)
+	if err != nil {
+		return err
+	}
+
+	*acks, err = userData.ParseAnnouncementAcksData()
+
+	// Return any error encountered during parsing
+	return err
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for Comment struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a Comment object, which contains the comment data to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the comment data into the "Comment" table in the database.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (comment *Comment) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (Id, AuthorId, Text, CreationDate, PostId) are empty
+	if comment.Id == "" || comment.AuthorId == "" || comment.Text == "" || comment.CreationDate == "" || comment.PostId == "" {
+		// Return an error if any field is empty
+		return errors.New("empty field")
+	}
+
+	// We call InsertIntoDb to insert the comment data into the "Comment" table in the database
+	return InsertIntoDb("Comment", db, comment.Id, comment.AuthorId, comment.Text, comment.Image, comment.CreationDate, comment.PostId, 0, 0, comment.ContentWarning, comment.SensitiveMedia)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Comment object, which will be populated with the comment data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "CommentDetail" table.
+
+The purpose of this function is to retrieve comment data from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the Id field is not set, or if the data retrieval or parsing fails
+*/
+func (comment *Comment) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "CommentDetail" table based on the given conditions
+	userData, err := SelectFromDb("CommentDetail", db, where)
+	if err != nil {
+		// Return an error if the data retrieval fails
+		return err
+	}
+
+	// We parse the retrieved data into the Comment structure and assign it to the comment object
+	*comment, err = userData.ParseCommentData()
+
+	// Return any error encountered during parsing
+	return err
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a Comment object, which represents the comment data to be updated.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the updateData, which holds the values to be updated.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to update.
+
+The purpose of this function is to update the comment data in the "Comment" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the update operation fails
+*/
+func (comment *Comment) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
+	// We call UpdateDb to update the "Comment" table with the provided data and conditions
+	return UpdateDb("Comment", db, updateData, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Comment object, which represents the comment data to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete comment data from the "Comment" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (comment *Comment) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "Comment" table based on the specified conditions
+	return RemoveFromDB("Comment", db, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Comments object, which will be populated with the comment data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "CommentDetail" table.
+
+The purpose of this function is to retrieve multiple comment data entries from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (comments *Comments) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "CommentDetail" table based on the given conditions
+	userData, err := SelectFromDb("CommentDetail", db, where)
+	if err != nil {
+		// Return an error if the data retrieval fails
+		return err
+	}
+
+	// We parse the retrieved data into the Comments structure and assign it to the comments object
+	*comments, err = userData.ParseCommentsData()
+
+	// Return any error encountered during parsing
+	return err
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for Reaction struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a Reaction object, which contains the reaction data to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the reaction data into the "Reaction" table in the database.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (reaction *Reaction) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields are empty and if the kind of reaction is a known one
+	if reaction.TargetType == "" || reaction.TargetId == "" || reaction.UserId == "" || !slices.Contains(ReactionKinds, reaction.Kind) {
+		return errors.New("empty field")
+	}
+
+	// We call InsertIntoDb to insert the reaction data into the "Reaction" table in the database
+	return InsertIntoDb("Reaction", db, reaction.TargetType, reaction.TargetId, reaction.UserId, reaction.Kind)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Reaction object, which represents the reaction data to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete reaction data from the "Reaction" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (reaction *Reaction) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "Reaction" table based on the specified conditions
+	return RemoveFromDB("Reaction", db, where)
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to count, kind by kind, the reactions given to the target (TargetType and TargetId) of the Reaction object.
+
+The function returns 2 values:
+  - a map with the quantity of reactions for each kind (all the kinds are present, even with 0)
+  - an error if the count fails
+*/
+func (reaction *Reaction) CountByKind(db *sql.DB) (map[string]int, error) {
+	// We initialize every kind to 0 so the client always receives the same keys
+	counts := make(map[string]int, len(ReactionKinds))
+	for _, kind := range ReactionKinds {
+		counts[kind] = 0
+	}
+
+	// We let the db group the reactions by kind instead of loading all the rows
+	rows, err := db.Query("SELECT Kind, COUNT(*) FROM Reaction WHERE TargetType = ? AND TargetId = ? GROUP BY Kind", reaction.TargetType, reaction.TargetId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var kind string
+		var count int
+		if err = rows.Scan(&kind, &count); err != nil {
+			return nil, err
+		}
+
+		counts[kind] = count
+	}
+
+	return counts, rows.Err()
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Reactions object, which will be populated with the reaction data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "ReactionDetail" table.
+
+The purpose of this function is to retrieve multiple reaction data entries from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (reactions *Reactions) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "ReactionDetail" table based on the given conditions
+	userData, err := SelectFromDb("ReactionDetail", db, where)
+	if err != nil {
+		// Return an error if the data retrieval fails
+		return err
+	}
+
+	// We parse the retrieved data into the Reactions structure and assign it to the reactions object
+	*reactions, err = userData.ParseReactionsData()
+
+	// Return any error encountered during parsing
+	return err
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for Bookmark struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a Bookmark object, which contains the bookmark data to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the bookmark data into the "Bookmark" table in the database.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (bookmark *Bookmark) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields are empty, the collection is optional
+	if bookmark.UserId == "" || bookmark.PostId == "" || bookmark.CreationDate == "" {
+		return errors.New("empty field")
+	}
+
+	// We call InsertIntoDb to insert the bookmark data into the "Bookmark" table in the database
+	return InsertIntoDb("Bookmark", db, bookmark.UserId, bookmark.PostId, bookmark.Collection, bookmark.CreationDate)
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a Bookmark object, which represents the bookmark data to be updated.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the updateData, which holds the values to be updated.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to update.
+
+The purpose of this function is to update the bookmark data in the "Bookmark" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the update operation fails
+*/
+func (bookmark *Bookmark) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
+	// We call UpdateDb to update the "Bookmark" table with the provided data and conditions
+	return UpdateDb("Bookmark", db, updateData, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Bookmark object, which represents the bookmark data to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete bookmark data from the "Bookmark" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (bookmark *Bookmark) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "Bookmark" table based on the specified conditions
+	return RemoveFromDB("Bookmark", db, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Bookmarks object, which will be populated with the bookmark data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "Bookmark" table.
+
+The purpose of this function is to retrieve multiple bookmarks from the database based on the given conditions.
+The bookmarks are sorted from the newest to the oldest.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (bookmarks *Bookmarks) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "Bookmark" table based on the given conditions
+	userData, err := SelectFromDb("Bookmark", db, where)
+	if err != nil {
+		// Return an error if the data retrieval fails
+		return err
+	}
+
+	// We parse the retrieved data into the Bookmarks structure and assign it to the bookmarks object
+	if *bookmarks, err = userData.ParseBookmarksData(); err != nil {
+		return err
+	}
+
+	// The creation dates have a fixed format, so the comparison of the strings gives the chronological order
+	slices.SortStableFunc(*bookmarks, func(a, b Bookmark) int {
+		return strings.Compare(b.CreationDate, a.CreationDate)
+	})
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for Follower struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a Follower object, which contains the follower data to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the follower data into the "Follower" table in the database.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (follower *Follower) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (Id, UserId, FollowerId) are empty
+	if follower.Id == "" || follower.FollowerId == "" || follower.FollowedId == "" {
+		// Return an error if any field is empty
+		return errors.New("empty field")
+	}
+
+	// We call InsertIntoDb to insert the follower data into the "Follower" table in the database
+	return InsertIntoDb("Follower", db, follower.Id, follower.FollowerId, follower.FollowedId)
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to a Follower object, which represents the follower data to check.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to determine if a specific user is followed by the follower indicated in the Follower object.
+
+The function returns 2 values:
+  - a boolean indicating whether the follower is following the specified user.
+  - an error if any of the required IDs are empty or if the data retrieval fails.
+*/
+func (follower *Follower) IsFollowedBy(db *sql.DB) (bool, error) {
+	// We check if either the UserId or FollowerId fields are empty
+	if follower.FollowerId == "" || follower.FollowedId == "" {
+		// Return false and an error if any field is empty
+		return false, errors.New("empty id")
+	}
+
+	// We call SelectFromDb to check if there is a record of the follower in the database
+	userData, err := SelectFromDb("Follower", db, map[string]any{"UserId": follower.FollowerId, "FollowedId": follower.FollowedId})
+	if err != nil {
+		// Return false and the error if the data retrieval fails
+		return false, err
+	}
+
+	// We parse the retrieved data to check if the follower exists
+	res, err := userData.ParseFollowersData()
+	if err != nil {
+		// Return false and the error if the parsing fails
+		return false, err
+	}
+
+	// Return true if there is exactly one record of the follower, otherwise return false
+	return len(res) == 1, nil
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Followers object, which will be populated with the follower data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the conditions (WHERE clause) for selecting the data from the "Follower" table.
+
+The purpose of this function is to retrieve multiple follower data entries from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (followers *Followers) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "Follower" table based on the given conditions
+	userData, err := SelectFromDb("FollowDetail", db, where)
+	if err != nil {
+		// Return an error if the data retrieval fails
+		return err
+	}
+
+	// We parse the retrieved data into the Followers structure and assign it to the followers object
+	*followers, err = userData.ParseFollowersData()
+
+	// Return any error encountered during parsing
+	return err
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a Follower object, which represents the follower data to be updated.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the updateData, which holds the values to be updated.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to update.
+
+The purpose of this function is to update the follower data in the "Follower" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the update operation fails
+*/
+func (follower *Follower) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
+	// We call UpdateDb to update the "Follower" table with the provided data and conditions
+	return UpdateDb("Follower", db, updateData, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Follower object, which represents the follower data to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete follower data from the "Follower" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (follower *Follower) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "Follower" table based on the specified conditions
+	return RemoveFromDB("Follower", db, where)
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for FollowRequest struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+func (follower *FollowRequest) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (Id, UserId, FollowerId) are empty
+	if follower.FollowerId == "" || follower.FollowedId == "" {
+		// Return an error if any field is empty
+		return errors.New("empty field")
+	}
+
+	// We call InsertIntoDb to insert the follower data into the "Follower" table in the database
+	return InsertIntoDb("FollowingRequest", db, follower.FollowerId, follower.FollowedId)
+}
+
+func (follower *FollowRequests) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "UserInfo" table based on the given conditions
+	userData, err := SelectFromDb("FollowRequestDetail", db, where)
+	if err != nil {
+		// Return an error if the data retrieval fails
+		return err
+	}
+
+	*follower, err = userData.ParseFollowRequestsData()
+
+	// Return any error encountered during parsing
+	return err
+}
+
+func (follower *FollowRequest) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "Follower" table based on the specified conditions
+	return RemoveFromDB("FollowingRequest", db, where)
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for UserBlock struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserBlock object, which contains the block to be saved into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to block a user in a single transaction:
+the follows and the follow requests between the two users are deleted in both directions, and the block is saved.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if a query fails
+*/
+func (block *UserBlock) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (BlockerId, BlockedId, CreationDate) are empty
+	if block.BlockerId == "" || block.BlockedId == "" || block.CreationDate == "" {
+		return errors.New("empty field")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"Follower", "FollowingRequest"} {
+		if _, err = tx.Exec("DELETE FROM "+table+" WHERE (FollowerId = ? AND FollowedId = ?) OR (FollowerId = ? AND FollowedId = ?)",
+			block.BlockerId, block.BlockedId, block.BlockedId, block.BlockerId); err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.Exec("INSERT INTO UserBlock VALUES(?, ?, ?)", block.BlockerId, block.BlockedId, block.CreationDate); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a UserBlock object, which represents the block to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete blocks from the "UserBlock" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (block *UserBlock) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "UserBlock" table based on the specified conditions
+	return RemoveFromDB("UserBlock", db, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a UserBlocks object, which will be populated with the blocks retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "UserBlock" table.
+
+The purpose of this function is to retrieve the blocks from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (blocks *UserBlocks) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "UserBlock" table based on the given conditions
+	userData, err := SelectFromDb("UserBlock", db, where)
+	if err != nil {
+		return err
+	}
+
+	*blocks, err = userData.ParseUserBlocksData()
+
+	return err
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for UserMute struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a UserMute object, which contains the mute to be saved into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to save the mute of a user, an existing mute of the same a user is replaced.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the query fails
+*/
+func (mute *UserMute) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (UserId, MutedUserId, CreationDate) are empty
+	if mute.UserId == "" || mute.MutedUserId == "" || mute.CreationDate == "" {
+		return errors.New("empty field")
+	}
+
+	_, err := db.Exec("INSERT OR REPLACE INTO UserMute VALUES(?, ?, ?, ?)", mute.UserId, mute.MutedUserId, mute.CreationDate, mute.ExpirationDate)
+	return err
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the current date in the "2006-01-02 15:04" format (UTC).
+
+The purpose of this function is to check if the mute still applies at this date.
+
+The function returns true if the mute has no end or hasn't expired yet.
+*/
+func (mute UserMute) IsActive(now string) bool {
+	return mute.ExpirationDate == "" || mute.ExpirationDate > now
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a UserMute object, which represents the mute to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete mutes from the "UserMute" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (mute *UserMute) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "UserMute" table based on the specified conditions
+	return RemoveFromDB("UserMute", db, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a UserMutes object, which will be populated with the mutes retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "UserMute" table.
+
+The purpose of this function is to retrieve the mutes from the database based on the given conditions, expired or not.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (mutes *UserMutes) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "UserMute" table based on the given conditions
+	userData, err := SelectFromDb("UserMute", db, where)
+	if err != nil {
+		return err
+	}
+
+	*mutes, err = userData.ParseUserMutesData()
+
+	return err
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for GroupMute struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a GroupMute object, which contains the mute to be saved into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to save the mute of a group, an existing mute of the same a group is replaced.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the query fails
+*/
+func (mute *GroupMute) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (UserId, GroupId, CreationDate) are empty
+	if mute.UserId == "" || mute.GroupId == "" || mute.CreationDate == "" {
+		return errors.New("empty field")
+	}
+
+	_, err := db.Exec("INSERT OR REPLACE INTO GroupMute VALUES(?, ?, ?, ?)", mute.UserId, mute.GroupId, mute.CreationDate, mute.ExpirationDate)
+	return err
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the current date in the "2006-01-02 15:04" format (UTC).
+
+The purpose of this function is to check if the mute still applies at this date.
+
+The function returns true if the mute has no end or hasn't expired yet.
+*/
+func (mute GroupMute) IsActive(now string) bool {
+	return mute.ExpirationDate == "" || mute.ExpirationDate > now
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a GroupMute object, which represents the mute to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete mutes from the "GroupMute" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (mute *GroupMute) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "GroupMute" table based on the specified conditions
+	return RemoveFromDB("GroupMute", db, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a GroupMutes object, which will be populated with the mutes retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "GroupMute" table.
+
+The purpose of this function is to retrieve the mutes from the database based on the given conditions, expired or not.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (mutes *GroupMutes) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "GroupMute" table based on the given conditions
+	userData, err := SelectFromDb("GroupMute", db, where)
+	if err != nil {
+		return err
+	}
+
+	*mutes, err = userData.ParseGroupMutesData()
+
+	return err
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for Group struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a Group object, which contains the group data to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the group data into the "Groups" table in the database,
+with the leader and the members listed in MemberIds (optional) in the "GroupMember" table.
+Everything is done in a single transaction.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (group *Group) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (Id, LeaderId, GroupName, CreationDate) are empty
+	if group.Id == "" || group.LeaderId == "" || group.GroupName == "" || group.CreationDate == "" {
+		// Return an error if any field is empty
+		return errors.New("empty field")
+	}
+
+	if group.Banner == "" {
+		group.Banner = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAbAAAAD9CAYAAADd/yIsAAAABHNCSVQICAgIfAhkiAAAABl0RVh0U29mdHdhcmUAZ25vbWUtc2NyZWVuc2hvdO8Dvz4AAAAodEVYdENyZWF0aW9uIFRpbWUAbWVyLiAyMCBub3YuIDIwMjQgMTE6NDA6MjGc5VWRAAAD1ElEQVR4nO3VQQ0AIBDAMMC/58MDH7KkVbDf9szMAoCY8zsAAF4YGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkHQBjPMF9sYol6wAAAAASUVORK5CYII="
+	}
+
+	// A group without visibility keeps the join request flow.
+	if group.Visibility == "" {
+		group.Visibility = "private"
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("INSERT INTO Groups (Id, LeaderId, GroupName, GroupDescription, CreationDate, GroupPicture, Banner, Visibility) VALUES(?, ?, ?, ?, ?, ?, ?, ?)",
+		group.Id, group.LeaderId, group.GroupName, group.GroupDescription, group.CreationDate, group.GroupPicture, group.Banner, group.Visibility); err != nil {
+		return err
+	}
+
+	// The leader is the first member, the other members given in MemberIds join with them.
+	joinedAt := time.Now().UTC().Format(time.RFC3339)
+	if _, err = tx.Exec("INSERT INTO GroupMember VALUES(?, ?, ?, ?)", group.Id, group.LeaderId, "leader", joinedAt); err != nil {
+		return err
+	}
+
+	group.SplitMembers()
+	for _, memberId := range group.SplitMemberIds {
+		if memberId == "" || memberId == group.LeaderId {
+			continue
+		}
+
+		if _, err = tx.Exec("INSERT OR IGNORE INTO GroupMember VALUES(?, ?, ?, ?)", group.Id, memberId, "member", joinedAt); err != nil {
+			return err
+		}
+	}
+
+	if err = insertGroupCategories(tx, group.Id, group.Categories); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Group object, which will be populated with the group data retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the conditions (WHERE clause) for selecting the data from the "Groups" table.
+
+The purpose of this function is to retrieve group data from the database based on the given conditions.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (group *Group) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "Groups" table based on the given conditions
+	userData, err := SelectFromDb("GroupDetail", db, where)
+	if err != nil {
+		// Return an error if the data retrieval fails
+		return err
+	}
+
+	// We parse the retrieved data into the Group structure and assign it to the group object
+	*group, err = userData.ParseGroupData()
+
+	// Return any error encountered during parsing
+	return err
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Group object, whose Categories will be filled (Id must be set).
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to retrieve the categories of the group from the "GroupCategory" table, sorted by name.
+
+The function returns 1 value:
+  - an error if the query fails
+*/
+func (group *Group) SelectCategories(db *sql.DB) error {
+	rows, err := db.Query("SELECT Category FROM GroupCategory WHERE GroupId = ? ORDER BY Category", group.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	group.Categories = []string{}
+	for rows.Next() {
+		var category string
+		if err = rows.Scan(&category); err != nil {
+			return err
+		}
+
+		group.Categories = append(group.Categories, category)
+	}
+
+	return rows.Err()
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Group object, whose Questions will be filled (Id must be set).
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to retrieve the questions asked to the users who want to join the group, in their order.
+
+The function returns 1 value:
+  - an error if the query fails
+*/
+func (group *Group) SelectQuestions(db *sql.DB) error {
+	rows, err := db.Query("SELECT Question FROM GroupQuestion WHERE GroupId = ? ORDER BY Position", group.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	group.Questions = []string{}
+	for rows.Next() {
+		var question string
+		if err = rows.Scan(&question); err != nil {
+			return err
+		}
+
+		group.Questions = append(group.Questions, question)
+	}
+
+	return rows.Err()
+}
+
+/*
+This function takes 4 arguments:
+  - a pointer to a Group object, which represents the group to update (Id must be set).
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the new rules of the group.
+  - a slice of strings containing the new questions of the group, in their order.
+
+The purpose of this function is to replace the rules and the questions of the group in a single transaction.
+The pending join requests keep the questions they were answered with.
+
+The function returns 1 value:
+  - an error if one of the queries fails
+*/
+func (group *Group) UpdateRules(db *sql.DB, rules string, questions []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("UPDATE Groups SET Rules = ? WHERE Id = ?", rules, group.Id); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec("DELETE FROM GroupQuestion WHERE GroupId = ?", group.Id); err != nil {
+		return err
+	}
+
+	for position, question := range questions {
+		if _, err = tx.Exec("INSERT INTO GroupQuestion VALUES(?, ?, ?)", group.Id, position, question); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	group.Rules = rules
+	group.Questions = questions
+	return nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a Group object, which represents the group to categorize (Id must be set).
+  - a pointer to an sql.DB object, representing the database connection.
+  - a slice of strings containing the new categories of the group.
+
+The purpose of this function is to replace all the categories of the group in a single transaction.
+
+The function returns 1 value:
+  - an error if one of the queries fails
+*/
+func (group *Group) UpdateCategories(db *sql.DB, categories []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("DELETE FROM GroupCategory WHERE GroupId = ?", group.Id); err != nil {
+		return err
+	}
+
+	if err = insertGroupCategories(tx, group.Id, categories); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	group.Categories = categories
+	return nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an sql.Tx object, the transaction in which the categories are saved.
+  - a string containing the id of the group.
+  - a slice of strings containing the categories to add, the duplicates are ignored.
+
+The purpose of this function is to save the categories of a group during its creation or its update.
+
+The function returns 1 value:
+  - an error if an insertion fails
+*/
+func insertGroupCategories(tx *sql.Tx, groupId string, categories []string) error {
+	for _, category := range categories {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO GroupCategory VALUES(?, ?)", groupId, category); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a Group object, which contains the group data to be updated.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the updateData, which holds the values to be updated.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to update.
+
+The purpose of this function is to update the group data in the "Groups" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the update operation fails
+*/
+func (group *Group) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
+	// We call UpdateDb to update the "Groups" table with the provided data and conditions
+	return UpdateDb("Groups", db, updateData, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Group object, which represents the group data to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete group data from the "Groups" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (group *Group) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "Groups" table based on the specified conditions
+	return RemoveFromDB("Groups", db, where)
+}
+
+/*
+This function takes no arguments and is a method of the Group struct.
+
+The purpose of this function is to split the MemberIds string into a slice of individual member IDs, using " | " as the delimiter.
+
+This function updates the SplitMemberIds field of the Group struct with the resulting slice.
+*/
+func (group *Group) SplitMembers() {
+	// We split the MemberIds string into a slice of strings using " | " as the delimiter
+	group.SplitMemberIds = strings.Split(group.MemberIds, " | ")
+}
+
+/*
+This function takes no arguments and is a method of the Group struct.
+
+The purpose of this function is to join the SplitMemberIds slice into a single string, using " | " as the separator.
+
+This function updates the MemberIds field of the Group struct with the resulting string.
+*/
+func (group *Group) JoinMembers() {
+	// We join the SplitMemberIds slice into a single string using " | " as the separator
+	group.MemberIds = strings.Join(group.SplitMemberIds, " | ")
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a Group object, which contains the group whose leadership is transferred (Id and LeaderId).
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the id of the member who becomes the leader.
+
+The purpose of this function is to give the leadership of the group to one of its members in a single transaction.
+The previous leader stays in the group as a moderator.
+
+The function returns 1 value:
+  - an error if the new leader isn't a member of the group or if a query fails
+*/
+func (group *Group) TransferLeadership(db *sql.DB, newLeaderId string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var isMember bool
+	if err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM GroupMember WHERE GroupId = ? AND UserId = ?)", group.Id, newLeaderId).Scan(&isMember); err != nil {
+		return err
+	}
+
+	if !isMember {
+		return errors.New("the user isn't a member of the group")
+	}
+
+	if _, err = tx.Exec("UPDATE GroupMember SET Role = 'moderator' WHERE GroupId = ? AND UserId = ?", group.Id, group.LeaderId); err != nil {
+		return err
+	}
+
+	if err = setGroupLeader(tx, group.Id, newLeaderId); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	group.LeaderId = newLeaderId
+
+	return nil
+}
+
+func (groups *Groups) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "GroupDetail" view based on the given conditions
+	userData, err := SelectFromDb("GroupDetail", db, where)
+	if err != nil {
+		// Return an error if the data retrieval fails
+		return err
+	}
+
+	*groups, err = userData.ParseGroupsData()
+
+	// Return any error encountered during parsing
+	return err
+}
+
+// The columns of a list of groups found by a search or a suggestion: the columns of the "GroupDetail" view
+// (d, the pictures taken from the "Groups" table g), the number of members and the categories as a JSON array.
+const groupListColumns = `d.Id, d.LeaderId, d.Leader, COALESCE(d.MemberIds, ''), d.GroupName, COALESCE(d.GroupDescription, ''),
+	d.CreationDate, COALESCE(g.GroupPicture, ''), COALESCE(g.Banner, ''), d.Visibility, d.Rules, d.ArchivedAt,
+	(SELECT COUNT(*) FROM GroupMember AS m WHERE m.GroupId = g.Id) AS MemberCount,
+	(SELECT json_group_array(Category) FROM (SELECT Category FROM GroupCategory WHERE GroupId = g.Id ORDER BY Category)) AS Categories`
+
+/*
+This function takes 5 arguments:
+  - a pointer to a Groups object, which will be populated with the groups found.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a GroupSearch object, containing the words, the category, the order and the page of the search.
+  - a string containing the id of the user who searches.
+  - a string containing the first day (format 2006-01-02) counted in the activity of the groups.
+
+The purpose of this function is to search the groups whose name or description contains every word of the query
+(case insensitive for the ASCII letters), in the given category if any.
+The groups are sorted by number of members, by activity (the posts and the comments published in the group since the given day,
+the dates which aren't dates are ignored) or by name, and are returned with their categories and their number of members.
+The groups the user can't see are left out of the query, so the pages given by Offset and Limit stay full:
+a non-member doesn't see the archived groups, the groups they are banned from, and the secret groups they aren't invited in.
+
+The function returns 1 value:
+  - an error if the query fails
+*/
+func (groups *Groups) Search(db *sql.DB, search GroupSearch, userId, since string) error {
+	const dateGlob = "'[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*'"
+
+	whereClauses := []string{`(EXISTS (SELECT 1 FROM GroupMember AS m WHERE m.GroupId = g.Id AND m.UserId = ?)
+		OR (g.ArchivedAt = ''
+			AND NOT EXISTS (SELECT 1 FROM GroupBan AS b WHERE b.GroupId = g.Id AND b.UserId = ? AND (b.ExpirationDate = '' OR b.ExpirationDate > ?))
+			AND (g.Visibility <> 'secret' OR EXISTS (SELECT 1 FROM InviteGroupRequest AS i WHERE i.GroupId = g.Id AND i.ReceiverId = ?))))`}
+	args := []any{userId, userId, time.Now().UTC().Format("2006-01-02 15:04"), userId}
+
+	// The wildcards of LIKE written by the user are escaped to be searched as text.
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	for _, word := range strings.Fields(search.Query) {
+		pattern := "%" + escaper.Replace(word) + "%"
+		whereClauses = append(whereClauses, `(g.GroupName LIKE ? ESCAPE '\' OR g.GroupDescription LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+
+	if search.Category != "" {
+		whereClauses = append(whereClauses, "EXISTS (SELECT 1 FROM GroupCategory AS c WHERE c.GroupId = g.Id AND c.Category = ?)")
+		args = append(args, search.Category)
+	}
+
+	var orderString string
+	switch search.OrderBy {
+	case "members":
+		orderString = "MemberCount DESC, g.GroupName"
+	case "activity":
+		orderString = fmt.Sprintf(`(SELECT COUNT(*) FROM Post AS p WHERE p.IsGroup = g.Id AND p.CreationDate >= ? AND p.CreationDate GLOB %[1]s)
+		+ (SELECT COUNT(*) FROM Comment AS c INNER JOIN Post AS p ON p.Id = c.PostId WHERE p.IsGroup = g.Id AND c.CreationDate >= ? AND c.CreationDate GLOB %[1]s) DESC, g.GroupName`, dateGlob)
+		args = append(args, since, since)
+	default:
+		orderString = "g.GroupName"
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM Groups AS g INNER JOIN GroupDetail AS d ON d.Id = g.Id
+		WHERE %s ORDER BY %s LIMIT ? OFFSET ?`, groupListColumns, strings.Join(whereClauses, " AND "), orderString)
+
+	return groups.selectList(db, query, append(args, search.Limit, search.Offset)...)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a Groups object, which will be populated with the suggested groups.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the id of the user.
+
+The purpose of this function is to find the groups joined by the users followed by the user, and not by the user.
+The groups joined by the most followed users come first, then the biggest groups.
+The groups are returned with their categories and their number of members, their visibility isn't checked.
+
+The function returns 1 value:
+  - an error if the query fails
+*/
+func (groups *Groups) SelectSuggested(db *sql.DB, userId string) error {
+	query := fmt.Sprintf(`SELECT %s FROM Groups AS g INNER JOIN GroupDetail AS d ON d.Id = g.Id
+		WHERE g.Id NOT IN (SELECT GroupId FROM GroupMember WHERE UserId = ?)
+		AND g.Id IN (SELECT m.GroupId FROM Follower AS f INNER JOIN GroupMember AS m ON m.UserId = f.FollowedId WHERE f.FollowerId = ?)
+		ORDER BY (SELECT COUNT(DISTINCT m.UserId) FROM Follower AS f INNER JOIN GroupMember AS m ON m.UserId = f.FollowedId
+			WHERE f.FollowerId = ? AND m.GroupId = g.Id) DESC, MemberCount DESC`, groupListColumns)
+
+	return groups.selectList(db, query, userId, userId, userId)
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a Groups object, which will be populated with the groups.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing a query selecting the groupListColumns.
+  - the arguments of the query.
+
+The purpose of this function is to fetch the groups found by a search or a suggestion, with their categories
+and their number of members, in a single query.
+
+The function returns 1 value:
+  - an error if the query or the parsing of the categories fails
+*/
+func (groups *Groups) selectList(db *sql.DB, query string, args ...any) error {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	*groups = Groups{}
+	for rows.Next() {
+		var group Group
+		var categories string
+		err = rows.Scan(&group.Id, &group.LeaderId, &group.Leader, &group.MemberIds, &group.GroupName, &group.GroupDescription,
+			&group.CreationDate, &group.GroupPicture, &group.Banner, &group.Visibility, &group.Rules, &group.ArchivedAt,
+			&group.MemberCount, &categories)
+		if err != nil {
+			return err
+		}
+
+		if err = json.Unmarshal([]byte(categories), &group.Categories); err != nil {
+			return err
+		}
+
+		*groups = append(*groups, group)
+	}
+
+	return rows.Err()
+}
+
+/*
+This function takes 4 arguments:
+  - a pointer to a GroupStats object, which will be populated with the statistics (GroupId must be set).
+  - a pointer to an sql.DB object, representing the database connection.
+  - a slice of strings containing the days (format 2006-01-02) of the daily statistics, in order.
+  - an int, the maximum quantity of most active members.
+
+The purpose of this function is to compute the statistics of a group with aggregate queries:
+the growth of the members, the posts, the comments and the chat messages of each day,
+the most active members of the period, and the attendance of the events of the group.
+The members who left the group aren't kept, so the growth is the one of the current members.
+The dates which aren't dates are ignored, like for the statistics of the posts.
+
+The function returns 1 value:
+  - an error if one of the queries fails
+*/
+func (stats *GroupStats) SelectFromDb(db *sql.DB, days []string, maxActiveMembers int) error {
+	stats.Daily = make([]DailyGroupStats, len(days))
+	for i, day := range days {
+		stats.Daily[i].Day = day
+	}
+
+	var since string
+	if len(days) > 0 {
+		since = days[0]
+	}
+
+	// The members who joined before the period, the old memberships without a date included.
+	var members int
+	row := db.QueryRow("SELECT COUNT(*) FROM GroupMember WHERE GroupId = ? AND substr(JoinedAt, 1, 10) < ?", stats.GroupId, since)
+	if err := row.Scan(&members); err != nil {
+		return err
+	}
+
+	// Each query gives the quantity of the day.
+	dailyQueries := []struct {
+		query   string
+		counter func(*DailyGroupStats) *int
+	}{
+		{
+			"SELECT substr(JoinedAt, 1, 10) AS Day, COUNT(*) FROM GroupMember WHERE GroupId = ? AND Day >= ? GROUP BY Day",
+			func(daily *DailyGroupStats) *int { return &daily.NewMembers },
+		},
+		{
+			"SELECT substr(CreationDate, 1, 10) AS Day, COUNT(*) FROM Post WHERE IsGroup = ? AND Day >= ? AND CreationDate GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*' GROUP BY Day",
+			func(daily *DailyGroupStats) *int { return &daily.Posts },
+		},
+		{
+			`SELECT substr(c.CreationDate, 1, 10) AS Day, COUNT(*) FROM Comment AS c
+			INNER JOIN Post AS p ON c.PostId = p.Id
+			WHERE p.IsGroup = ? AND Day >= ? AND c.CreationDate GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*' GROUP BY Day`,
+			func(daily *DailyGroupStats) *int { return &daily.Comments },
+		},
+		{
+			"SELECT substr(CreationDate, 1, 10) AS Day, COUNT(*) FROM Chat WHERE GroupId = ? AND Day >= ? AND CreationDate GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*' GROUP BY Day",
+			func(daily *DailyGroupStats) *int { return &daily.Messages },
+		},
+	}
+
+	for _, dailyQuery := range dailyQueries {
+		rows, err := db.Query(dailyQuery.query, stats.GroupId, since)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var day string
+			var count int
+			if err = rows.Scan(&day, &count); err != nil {
+				rows.Close()
+				return err
+			}
+
+			if index := slices.Index(days, day); index != -1 {
+				*dailyQuery.counter(&stats.Daily[index]) = count
+			}
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	stats.Posts, stats.Comments, stats.Messages = 0, 0, 0
+	for i := range stats.Daily {
+		members += stats.Daily[i].NewMembers
+		stats.Daily[i].Members = members
+
+		stats.Posts += stats.Daily[i].Posts
+		stats.Comments += stats.Daily[i].Comments
+		stats.Messages += stats.Daily[i].Messages
+	}
+
+	row = db.QueryRow("SELECT COUNT(*) FROM GroupMember WHERE GroupId = ?", stats.GroupId)
+	if err := row.Scan(&stats.Members); err != nil {
+		return err
+	}
+
+	if err := stats.selectActiveMembers(db, since, maxActiveMembers); err != nil {
+		return err
+	}
+
+	return stats.selectEventAttendance(db)
+}
+
+/*
+This function takes 4 arguments:
+  - a pointer to a GroupStats object, whose ActiveMembers will be filled.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the first day (format 2006-01-02) of the period.
+  - an int, the maximum quantity of members.
+
+The purpose of this function is to rank the users by their posts, comments and chat messages in the group during the period.
+
+The function returns 1 value:
+  - an error if the query fails
+*/
+func (stats *GroupStats) selectActiveMembers(db *sql.DB, since string, limit int) error {
+	rows, err := db.Query(`SELECT UserId, SUM(Posts), SUM(Comments), SUM(Messages), COUNT(*) AS Total FROM (
+		SELECT AuthorId AS UserId, 1 AS Posts, 0 AS Comments, 0 AS Messages FROM Post
+		WHERE IsGroup = ? AND substr(CreationDate, 1, 10) >= ? AND CreationDate GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*'
+		UNION ALL
+		SELECT c.AuthorId, 0, 1, 0 FROM Comment AS c INNER JOIN Post AS p ON c.PostId = p.Id
+		WHERE p.IsGroup = ? AND substr(c.CreationDate, 1, 10) >= ? AND c.CreationDate GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*'
+		UNION ALL
+		SELECT SenderId, 0, 0, 1 FROM Chat
+		WHERE GroupId = ? AND substr(CreationDate, 1, 10) >= ? AND CreationDate GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*'
+	) GROUP BY UserId ORDER BY Total DESC, UserId LIMIT ?`, stats.GroupId, since, stats.GroupId, since, stats.GroupId, since, limit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	stats.ActiveMembers = []GroupMemberActivity{}
+	for rows.Next() {
+		var activity GroupMemberActivity
+		if err = rows.Scan(&activity.UserId, &activity.Posts, &activity.Comments, &activity.Messages, &activity.Total); err != nil {
+			return err
+		}
+
+		stats.ActiveMembers = append(stats.ActiveMembers, activity)
+	}
+
+	return rows.Err()
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a GroupStats object, whose Events and AttendanceRate will be filled.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to count the "going" (JoinEvent) and "not going" (DeclineEvent) answers of each event of the group,
+the most recent events first. The rate of an event without answer is 0.
+
+The function returns 1 value:
+  - an error if the query fails
+*/
+func (stats *GroupStats) selectEventAttendance(db *sql.DB) error {
+	rows, err := db.Query(`SELECT e.Id, IFNULL(e.Title, ''), IFNULL(e.DateOfTheEvent, ''),
+		(SELECT COUNT(*) FROM JoinEvent AS j WHERE j.EventId = e.Id),
+		(SELECT COUNT(*) FROM DeclineEvent AS d WHERE d.EventId = e.Id)
+	FROM Event AS e WHERE e.GroupId = ? ORDER BY e.DateOfTheEvent DESC`, stats.GroupId)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	stats.Events = []EventAttendance{}
+	var going, answers int
+	for rows.Next() {
+		var event EventAttendance
+		if err = rows.Scan(&event.EventId, &event.Title, &event.DateOfTheEvent, &event.Going, &event.NotGoing); err != nil {
+			return err
+		}
+
+		if event.Going+event.NotGoing > 0 {
+			event.Rate = float64(event.Going) / float64(event.Going+event.NotGoing)
+		}
+
+		going += event.Going
+		answers += event.Going + event.NotGoing
+		stats.Events = append(stats.Events, event)
+	}
+
+	stats.AttendanceRate = 0
+	if answers > 0 {
+		stats.AttendanceRate = float64(going) / float64(answers)
+	}
+
+	return rows.Err()
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for GroupMember struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a GroupMember object, which contains the membership to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to insert the membership into the "GroupMember" table in the database.
+The role is "member" and the join date is now when they are not given.
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if the insertion into the database fails
+*/
+func (member *GroupMember) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (GroupId, UserId) are empty
+	if member.GroupId == "" || member.UserId == "" {
+		return errors.New("empty field")
+	}
+
+	member.setDefaults()
+
+	// We call InsertIntoDb to insert the membership into the "GroupMember" table in the database
+	return InsertIntoDb("GroupMember", db, member.GroupId, member.UserId, member.Role, member.JoinedAt)
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a GroupMember object, which contains the membership to be inserted into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the table of the pending request ("JoinGroupRequest" or "InviteGroupRequest").
+  - a string containing the column of the table holding the id of the user ("UserId" or "ReceiverId").
+
+The purpose of this function is to accept a join request or an invitation:
+the membership is inserted and the request of the user for the group is deleted in a single transaction,
+so a user can't be left with both or none of them.
+
+The function returns 1 value:
+  - an error if there is no request, if the user is already a member or if a query fails
+*/
+func (member *GroupMember) InsertFromRequest(db *sql.DB, requestTable, userColumn string) error {
+	if member.GroupId == "" || member.UserId == "" {
+		return errors.New("empty field")
+	}
+
+	if requestTable != "JoinGroupRequest" && requestTable != "InviteGroupRequest" {
+		return errors.New("invalid request table")
+	}
+
+	member.setDefaults()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE GroupId = ? AND %s = ?", requestTable, userColumn), member.GroupId, member.UserId)
+	if err != nil {
+		return err
+	}
+
+	if deleted, err := result.RowsAffected(); err != nil || deleted == 0 {
+		return errors.New("there is no request")
+	}
+
+	if _, err = tx.Exec("INSERT INTO GroupMember VALUES(?, ?, ?, ?)", member.GroupId, member.UserId, member.Role, member.JoinedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a GroupMember object, which contains the membership to be removed (GroupId and UserId).
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the current date in the "2006-01-02 15:04" format (UTC).
+
+The purpose of this function is to remove a user from a group in a single transaction.
+When the leader leaves, the oldest moderator, or failing that the oldest member, becomes the leader,
+and the group is archived when nobody is left, so its content is only removed by a deletion after the retention period.
+
+The function returns 3 values:
+  - a boolean, true if the group is left without members
+  - a string containing the id of the new leader, empty if the leader hasn't changed
+  - an error if the user isn't a member of the group or if a query fails
+*/
+func (member *GroupMember) Leave(db *sql.DB, now string) (bool, string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, "", err
+	}
+	defer tx.Rollback()
+
+	var leaderId string
+	if err = tx.QueryRow("SELECT LeaderId FROM Groups WHERE Id = ?", member.GroupId).Scan(&leaderId); err != nil {
+		return false, "", err
+	}
+
+	result, err := tx.Exec("DELETE FROM GroupMember WHERE GroupId = ? AND UserId = ?", member.GroupId, member.UserId)
+	if err != nil {
+		return false, "", err
+	}
+
+	if deleted, err := result.RowsAffected(); err != nil || deleted == 0 {
+		return false, "", errors.New("the user isn't a member of the group")
+	}
+
+	// The moderators come first, then the members by join date.
+	var nextLeaderId string
+	err = tx.QueryRow("SELECT UserId FROM GroupMember WHERE GroupId = ? ORDER BY Role = 'moderator' DESC, JoinedAt, rowid LIMIT 1", member.GroupId).Scan(&nextLeaderId)
+	if err == sql.ErrNoRows {
+		// A group already archived keeps its date, the retention period isn't restarted.
+		if _, err = tx.Exec("UPDATE Groups SET ArchivedAt = ? WHERE Id = ? AND ArchivedAt = ''", now, member.GroupId); err != nil {
+			return false, "", err
+		}
+
+		return true, "", tx.Commit()
+	} else if err != nil {
+		return false, "", err
+	}
+
+	if leaderId != member.UserId {
+		return false, "", tx.Commit()
+	}
+
+	if err = setGroupLeader(tx, member.GroupId, nextLeaderId); err != nil {
+		return false, "", err
+	}
+
+	return false, nextLeaderId, tx.Commit()
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to an sql.Tx object, representing the current transaction.
+  - a string containing the id of the group.
+  - a string containing the id of the member who becomes the leader.
+
+The purpose of this function is to save a new leader, in the "Groups" table and in its membership.
+
+The function returns 1 value:
+  - an error if one of the updates fails
+*/
+func setGroupLeader(tx *sql.Tx, groupId, leaderId string) error {
+	if _, err := tx.Exec("UPDATE Groups SET LeaderId = ? WHERE Id = ?", leaderId, groupId); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec("UPDATE GroupMember SET Role = 'leader' WHERE GroupId = ? AND UserId = ?", groupId, leaderId)
+
+	return err
+}
+
+/*
+This function takes no arguments and is a method of the GroupMember struct.
+
+The purpose of this function is to set the role to "member" and the join date to now when they are empty.
+*/
+func (member *GroupMember) setDefaults() {
+	if member.Role == "" {
+		member.Role = "member"
+	}
+
+	if member.JoinedAt == "" {
+		member.JoinedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+}
+
+/*
+This function takes 3 arguments:
+  - a pointer to a GroupMember object, which represents the membership to be updated.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the updateData, which holds the values to be updated.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to update.
+
+The purpose of this function is to update memberships in the "GroupMember" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the update operation fails
+*/
+func (member *GroupMember) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
+	// We call UpdateDb to update the "GroupMember" table with the provided data and conditions
+	return UpdateDb("GroupMember", db, updateData, where)
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a GroupMembers object, which will be populated with the memberships retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "GroupMember" table.
+
+The purpose of this function is to retrieve the memberships from the database based on the given conditions, sorted by join date.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (members *GroupMembers) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "GroupMember" table based on the given conditions
+	userData, err := SelectFromDb("GroupMember", db, where)
+	if err != nil {
+		return err
+	}
+
+	if *members, err = userData.ParseGroupMembersData(); err != nil {
+		return err
+	}
+
+	// The sort is stable, so the members who joined at the same time keep the order of the table.
+	slices.SortStableFunc(*members, func(a, b GroupMember) int {
+		return strings.Compare(a.JoinedAt, b.JoinedAt)
+	})
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+//
+//	DB Method for GroupBan struct
+//
+// ----------------------------------------------------------------------------------------------
+// ----------------------------------------------------------------------------------------------
+
+/*
+This function takes 1 argument:
+  - a pointer to a GroupBan object, which contains the ban to be saved into the database.
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to ban a user from a group in a single transaction:
+the user is removed from the members, their join request and their invitations are deleted,
+and the ban is saved (an existing ban of the user is replaced).
+
+The function returns 1 value:
+  - an error if any of the required fields are empty or if a query fails
+*/
+func (ban *GroupBan) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (GroupId, UserId, BannedBy, CreationDate) are empty
+	if ban.GroupId == "" || ban.UserId == "" || ban.BannedBy == "" || ban.CreationDate == "" {
+		return errors.New("empty field")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("DELETE FROM GroupMember WHERE GroupId = ? AND UserId = ?", ban.GroupId, ban.UserId); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec("DELETE FROM JoinGroupRequest WHERE GroupId = ? AND UserId = ?", ban.GroupId, ban.UserId); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec("DELETE FROM InviteGroupRequest WHERE GroupId = ? AND ReceiverId = ?", ban.GroupId, ban.UserId); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec("INSERT OR REPLACE INTO GroupBan VALUES(?, ?, ?, ?, ?, ?)",
+		ban.GroupId, ban.UserId, ban.BannedBy, ban.Reason, ban.CreationDate, ban.ExpirationDate); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the current date in the "2006-01-02 15:04" format (UTC).
+
+The purpose of this function is to check if the ban still applies at this date.
+
+The function returns true if the ban is permanent or hasn't expired yet.
+*/
+func (ban GroupBan) IsActive(now string) bool {
+	return ban.ExpirationDate == "" || ban.ExpirationDate > now
+}
+
+/*
+This function takes 2 arguments:
+  - a pointer to a GroupBan object, which represents the ban to be deleted.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+
+The purpose of this function is to delete bans from the "GroupBan" table based on the provided conditions.
+
+The function returns 1 value:
+  - an error if the delete operation fails
+*/
+func (ban *GroupBan) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "GroupBan" table based on the specified conditions
+	return RemoveFromDB("GroupBan", db, where)
 }
 
 /*
 This function takes 2 arguments:
-  - a pointer to a Comments object, which will be populated with the comment data retrieved from the database.
+  - a pointer to a GroupBans object, which will be populated with the bans retrieved from the database.
   - a pointer to an sql.DB object, representing the database connection.
-  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "CommentDetail" table.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "GroupBan" table.
 
-The purpose of this function is to retrieve multiple comment data entries from the database based on the given conditions.
+The purpose of this function is to retrieve the bans from the database based on the given conditions, expired or not.
 
 The function returns 1 value:
   - an error if the data retrieval or parsing fails
 */
-func (comments *Comments) SelectFromDb(db *sql.DB, where map[string]any) error {
-	// We call SelectFromDb to retrieve data from the "CommentDetail" table based on the given conditions
-	userData, err := SelectFromDb("CommentDetail", db, where)
+func (bans *GroupBans) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "GroupBan" table based on the given conditions
+	userData, err := SelectFromDb("GroupBan", db, where)
 	if err != nil {
-		// Return an error if the data retrieval fails
 		return err
 	}
 
-	// We parse the retrieved data into the Comments structure and assign it to the comments object
-	*comments, err = userData.ParseCommentsData()
+	*bans, err = userData.ParseGroupBansData()
 
-	// Return any error encountered during parsing
 	return err
 }
 
 // ----------------------------------------------------------------------------------------------
 // ----------------------------------------------------------------------------------------------
 //
-//	DB Method for Follower struct
+//	DB Method for GroupInviteLink struct
 //
 // ----------------------------------------------------------------------------------------------
 // ----------------------------------------------------------------------------------------------
 
 /*
 This function takes 1 argument:
-  - a pointer to a Follower object, which contains the follower data to be inserted into the database.
+  - a pointer to a GroupInviteLink object, which contains the link to be inserted into the database.
   - a pointer to an sql.DB object, representing the database connection.
 
-The purpose of this function is to insert the follower data into the "Follower" table in the database.
+The purpose of this function is to insert a new invite link into the "GroupInviteLink" table, without any use.
 
 The function returns 1 value:
-  - an error if any of the required fields are empty or if the insertion into the database fails
+  - an error if any of the required fields are empty or if the insertion fails
 */
-func (follower *Follower) InsertIntoDb(db *sql.DB) error {
-	// We check if any of the required fields (Id, UserId, FollowerId) are empty
-	if follower.Id == "" || follower.FollowerId == "" || follower.FollowedId == "" {
-		// Return an error if any field is empty
+func (link *GroupInviteLink) InsertIntoDb(db *sql.DB) error {
+	// We check if any of the required fields (Token, GroupId, CreatorId, CreationDate) are empty
+	if link.Token == "" || link.GroupId == "" || link.CreatorId == "" || link.CreationDate == "" {
 		return errors.New("empty field")
 	}
 
-	// We call InsertIntoDb to insert the follower data into the "Follower" table in the database
-	return InsertIntoDb("Follower", db, follower.Id, follower.FollowerId, follower.FollowedId)
+	if link.MaxUses < 0 {
+		return errors.New("invalid max uses")
+	}
+
+	link.Uses = 0
+
+	// We call InsertIntoDb to insert the link into the "GroupInviteLink" table in the database
+	return InsertIntoDb("GroupInviteLink", db, link.Token, link.GroupId, link.CreatorId, link.CreationDate, link.ExpirationDate, link.MaxUses, link.Uses, link.SkipApproval)
 }
 
 /*
 This function takes 1 argument:
-  - a pointer to a Follower object, which represents the follower data to check.
+  - a string containing the current date in the "2006-01-02 15:04" format (UTC).
+
+The purpose of this function is to check if the link can still be used at this date.
+
+The function returns true if the link hasn't expired and hasn't reached its maximum number of uses.
+*/
+func (link GroupInviteLink) IsActive(now string) bool {
+	return (link.ExpirationDate == "" || link.ExpirationDate > now) && (link.MaxUses == 0 || link.Uses < link.MaxUses)
+}
+
+/*
+This function takes 5 arguments:
+  - a pointer to a GroupInviteLink object, which contains the link used (Token, GroupId and SkipApproval).
   - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the id of the user joining with the link.
+  - a string containing the current date in the "2006-01-02 15:04" format (UTC).
+  - a JoinGroupAnswers array, containing the answers to the questions of the group (ignored with SkipApproval).
 
-The purpose of this function is to determine if a specific user is followed by the follower indicated in the Follower object.
+The purpose of this function is to consume one use of the link in a single transaction:
+the use is only counted if the link is still active, so two users can't take the last use at the same time.
+With SkipApproval, the user becomes a member and their pending requests for the group are deleted,
+otherwise a join request is saved with its answers for the moderators of the group.
 
-The function returns 2 values:
-  - a boolean indicating whether the follower is following the specified user.
-  - an error if any of the required IDs are empty or if the data retrieval fails.
+The function returns 1 value:
+  - an error if the link has expired or has been fully used, or if a query fails
 */
-func (follower *Follower) IsFollowedBy(db *sql.DB) (bool, error) {
-	// We check if either the UserId or FollowerId fields are empty
-	if follower.FollowerId == "" || follower.FollowedId == "" {
-		// Return false and an error if any field is empty
-		return false, errors.New("empty id")
+func (link *GroupInviteLink) Use(db *sql.DB, userId, now string, answers JoinGroupAnswers) error {
+	if link.Token == "" || link.GroupId == "" || userId == "" {
+		return errors.New("empty field")
 	}
 
-	// We call SelectFromDb to check if there is a record of the follower in the database
-	userData, err := SelectFromDb("Follower", db, map[string]any{"UserId": follower.FollowerId, "FollowedId": follower.FollowedId})
+	tx, err := db.Begin()
 	if err != nil {
-		// Return false and the error if the data retrieval fails
-		return false, err
+		return err
 	}
+	defer tx.Rollback()
 
-	// We parse the retrieved data to check if the follower exists
-	res, err := userData.ParseFollowersData()
+	result, err := tx.Exec("UPDATE GroupInviteLink SET Uses = Uses + 1 WHERE Token = ? AND (ExpirationDate = '' OR ExpirationDate > ?) AND (MaxUses = 0 OR Uses < MaxUses)", link.Token, now)
 	if err != nil {
-		// Return false and the error if the parsing fails
-		return false, err
+		return err
 	}
 
-	// Return true if there is exactly one record of the follower, otherwise return false
-	return len(res) == 1, nil
-}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return errors.New("the link has expired or has been fully used")
+	}
 
-/*
-This function takes 2 arguments:
-  - a pointer to a Followers object, which will be populated with the follower data retrieved from the database.
-  - a pointer to an sql.DB object, representing the database connection.
-  - a map[string]any containing the conditions (WHERE clause) for selecting the data from the "Follower" table.
+	if link.SkipApproval {
+		if _, err = tx.Exec("DELETE FROM JoinGroupRequest WHERE GroupId = ? AND UserId = ?", link.GroupId, userId); err != nil {
+			return err
+		}
 
-The purpose of this function is to retrieve multiple follower data entries from the database based on the given conditions.
+		if _, err = tx.Exec("DELETE FROM InviteGroupRequest WHERE GroupId = ? AND ReceiverId = ?", link.GroupId, userId); err != nil {
+			return err
+		}
 
-The function returns 1 value:
-  - an error if the data retrieval or parsing fails
-*/
-func (followers *Followers) SelectFromDb(db *sql.DB, where map[string]any) error {
-	// We call SelectFromDb to retrieve data from the "Follower" table based on the given conditions
-	userData, err := SelectFromDb("FollowDetail", db, where)
-	if err != nil {
-		// Return an error if the data retrieval fails
-		return err
+		member := GroupMember{GroupId: link.GroupId, UserId: userId}
+		member.setDefaults()
+		if _, err = tx.Exec("INSERT INTO GroupMember VALUES(?, ?, ?, ?)", member.GroupId, member.UserId, member.Role, member.JoinedAt); err != nil {
+			return err
+		}
+	} else {
+		if _, err = tx.Exec("INSERT INTO JoinGroupRequest VALUES(?, ?)", userId, link.GroupId); err != nil {
+			return err
+		}
+
+		for _, answer := range answers {
+			if _, err = tx.Exec("INSERT INTO JoinGroupAnswer VALUES(?, ?, ?, ?, ?)", link.GroupId, userId, answer.Position, answer.Question, answer.Answer); err != nil {
+				return err
+			}
+		}
 	}
 
-	// We parse the retrieved data into the Followers structure and assign it to the followers object
-	*followers, err = userData.ParseFollowersData()
+	link.Uses++
 
-	// Return any error encountered during parsing
-	return err
+	return tx.Commit()
 }
 
 /*
-This function takes 3 arguments:
-  - a pointer to a Follower object, which represents the follower data to be updated.
+This function takes 2 arguments:
+  - a pointer to a GroupInviteLink object, which represents the link to be deleted.
   - a pointer to an sql.DB object, representing the database connection.
-  - a map[string]any containing the updateData, which holds the values to be updated.
-  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to update.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
 
-The purpose of this function is to update the follower data in the "Follower" table based on the provided conditions.
+The purpose of this function is to delete invite links from the "GroupInviteLink" table based on the provided conditions.
 
 The function returns 1 value:
-  - an error if the update operation fails
+  - an error if the delete operation fails
 */
-func (follower *Follower) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
-	// We call UpdateDb to update the "Follower" table with the provided data and conditions
-	return UpdateDb("Follower", db, updateData, where)
+func (link *GroupInviteLink) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "GroupInviteLink" table based on the specified conditions
+	return RemoveFromDB("GroupInviteLink", db, where)
 }
 
 /*
 This function takes 2 arguments:
-  - a pointer to a Follower object, which represents the follower data to be deleted.
+  - a pointer to a GroupInviteLinks object, which will be populated with the links retrieved from the database.
   - a pointer to an sql.DB object, representing the database connection.
-  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "GroupInviteLink" table.
 
-The purpose of this function is to delete follower data from the "Follower" table based on the provided conditions.
+The purpose of this function is to retrieve the invite links from the database based on the given conditions, active or not.
 
 The function returns 1 value:
-  - an error if the delete operation fails
+  - an error if the data retrieval or parsing fails
 */
-func (follower *Follower) DeleteFromDb(db *sql.DB, where map[string]any) error {
-	// We call RemoveFromDB to delete the record(s) from the "Follower" table based on the specified conditions
-	return RemoveFromDB("Follower", db, where)
-}
-
-// ----------------------------------------------------------------------------------------------
-// ----------------------------------------------------------------------------------------------
-//
-//	DB Method for FollowRequest struct
-//
-// ----------------------------------------------------------------------------------------------
-// ----------------------------------------------------------------------------------------------
-
-func (follower *FollowRequest) InsertIntoDb(db *sql.DB) error {
-	// We check if any of the required fields (Id, UserId, FollowerId) are empty
-	if follower.FollowerId == "" || follower.FollowedId == "" {
-		// Return an error if any field is empty
-		return errors.New("empty field")
-	}
-
-	// We call InsertIntoDb to insert the follower data into the "Follower" table in the database
-	return InsertIntoDb("FollowingRequest", db, follower.FollowerId, follower.FollowedId)
-}
-
-func (follower *FollowRequests) SelectFromDb(db *sql.DB, where map[string]any) error {
-	// We call SelectFromDb to retrieve data from the "UserInfo" table based on the given conditions
-	userData, err := SelectFromDb("FollowRequestDetail", db, where)
+func (links *GroupInviteLinks) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "GroupInviteLink" table based on the given conditions
+	userData, err := SelectFromDb("GroupInviteLink", db, where)
 	if err != nil {
-		// Return an error if the data retrieval fails
 		return err
 	}
 
-	*follower, err = userData.ParseFollowRequestsData()
+	*links, err = userData.ParseGroupInviteLinksData()
 
-	// Return any error encountered during parsing
 	return err
 }
 
-func (follower *FollowRequest) DeleteFromDb(db *sql.DB, where map[string]any) error {
-	// We call RemoveFromDB to delete the record(s) from the "Follower" table based on the specified conditions
-	return RemoveFromDB("FollowingRequest", db, where)
-}
-
 // ----------------------------------------------------------------------------------------------
 // ----------------------------------------------------------------------------------------------
 //
-//	DB Method for Group struct
+//	DB Method for GroupFile struct
 //
 // ----------------------------------------------------------------------------------------------
 // ----------------------------------------------------------------------------------------------
 
+// ErrGroupQuotaExceeded is returned when a file doesn't fit in the space left to its group.
+var ErrGroupQuotaExceeded = errors.New("the storage quota of the group is exceeded")
+
 /*
-This function takes 1 argument:
-  - a pointer to a Group object, which contains the group data to be inserted into the database.
+This function takes 3 arguments:
+  - a pointer to a GroupFile object, which contains the file to be inserted into the database.
   - a pointer to an sql.DB object, representing the database connection.
+  - an int64, the maximum quantity of bytes of all the files of the group.
 
-The purpose of this function is to insert the group data into the "Groups" table in the database.
+The purpose of this function is to insert the description of a file into the "GroupFile" table,
+only if the files of the group stay under the quota. The check and the insertion are done in a single query,
+so two uploads at the same time can't exceed the quota together.
 
 The function returns 1 value:
-  - an error if any of the required fields are empty or if the insertion into the database fails
+  - ErrGroupQuotaExceeded if the file doesn't fit, or an error if a field is empty or the insertion fails
 */
-func (group *Group) InsertIntoDb(db *sql.DB) error {
-	// We check if any of the required fields (Id, LeaderId, MemberIds, GroupName, CreationDate) are empty
-	if group.Id == "" || group.LeaderId == "" || group.MemberIds == "" || group.GroupName == "" || group.CreationDate == "" {
-		// Return an error if any field is empty
+func (file *GroupFile) InsertIntoDb(db *sql.DB, quota int64) error {
+	// We check if any of the required fields (Id, GroupId, UploaderId, Name, MimeType, CreationDate) are empty
+	if file.Id == "" || file.GroupId == "" || file.UploaderId == "" || file.Name == "" || file.MimeType == "" || file.CreationDate == "" {
 		return errors.New("empty field")
 	}
 
-	if group.Banner == "" {
-		group.Banner = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAbAAAAD9CAYAAADd/yIsAAAABHNCSVQICAgIfAhkiAAAABl0RVh0U29mdHdhcmUAZ25vbWUtc2NyZWVuc2hvdO8Dvz4AAAAodEVYdENyZWF0aW9uIFRpbWUAbWVyLiAyMCBub3YuIDIwMjQgMTE6NDA6MjGc5VWRAAAD1ElEQVR4nO3VQQ0AIBDAMMC/58MDH7KkVbDf9szMAoCY8zsAAF4YGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkGRgACQZGABJBgZAkoEBkHQBjPMF9sYol6wAAAAASUVORK5CYII="
+	result, err := db.Exec(`INSERT INTO GroupFile (Id, GroupId, UploaderId, Folder, Name, MimeType, Size, CreationDate)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ?
+		WHERE (SELECT IFNULL(SUM(Size), 0) FROM GroupFile WHERE GroupId = ?) + ? <= ?`,
+		file.Id, file.GroupId, file.UploaderId, file.Folder, file.Name, file.MimeType, file.Size, file.CreationDate,
+		file.GroupId, file.Size, quota)
+	if err != nil {
+		return err
+	}
+
+	if inserted, err := result.RowsAffected(); err != nil {
+		return err
+	} else if inserted == 0 {
+		return ErrGroupQuotaExceeded
 	}
 
-	// We call InsertIntoDb to insert the group data into the "Groups" table in the database
-	return InsertIntoDb("Groups", db, group.Id, group.LeaderId, group.MemberIds, group.GroupName, group.GroupDescription, group.CreationDate, group.GroupPicture, group.Banner)
+	return nil
 }
 
 /*
 This function takes 2 arguments:
-  - a pointer to a Group object, which will be populated with the group data retrieved from the database.
+  - a pointer to a GroupFile object, which will be populated with the file retrieved from the database.
   - a pointer to an sql.DB object, representing the database connection.
-  - a map[string]any containing the conditions (WHERE clause) for selecting the data from the "Groups" table.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "GroupFile" table.
 
-The purpose of this function is to retrieve group data from the database based on the given conditions.
+The purpose of this function is to retrieve the description of one file based on the given conditions.
 
 The function returns 1 value:
-  - an error if the data retrieval or parsing fails
+  - an error if there is no file or if the data retrieval fails
 */
-func (group *Group) SelectFromDb(db *sql.DB, where map[string]any) error {
-	// We call SelectFromDb to retrieve data from the "Groups" table based on the given conditions
-	userData, err := SelectFromDb("GroupDetail", db, where)
-	if err != nil {
-		// Return an error if the data retrieval fails
+func (file *GroupFile) SelectFromDb(db *sql.DB, where map[string]any) error {
+	var files GroupFiles
+	if err := files.SelectFromDb(db, where); err != nil {
 		return err
 	}
 
-	// We parse the retrieved data into the Group structure and assign it to the group object
-	*group, err = userData.ParseGroupData()
+	if len(files) == 0 {
+		return errors.New("there is no entry in the DB")
+	}
 
-	// Return any error encountered during parsing
-	return err
+	*file = files[0]
+	return nil
 }
 
 /*
-This function takes 3 arguments:
-  - a pointer to a Group object, which contains the group data to be updated.
+This function takes 2 arguments:
+  - a pointer to a GroupFile object, which represents the file to be deleted.
   - a pointer to an sql.DB object, representing the database connection.
-  - a map[string]any containing the updateData, which holds the values to be updated.
-  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to update.
+  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
 
-The purpose of this function is to update the group data in the "Groups" table based on the provided conditions.
+The purpose of this function is to delete the description of files from the "GroupFile" table, their content must be removed from the media store apart.
 
 The function returns 1 value:
-  - an error if the update operation fails
+  - an error if the delete operation fails
 */
-func (group *Group) UpdateDb(db *sql.DB, updateData, where map[string]any) error {
-	// We call UpdateDb to update the "Groups" table with the provided data and conditions
-	return UpdateDb("Groups", db, updateData, where)
+func (file *GroupFile) DeleteFromDb(db *sql.DB, where map[string]any) error {
+	// We call RemoveFromDB to delete the record(s) from the "GroupFile" table based on the specified conditions
+	return RemoveFromDB("GroupFile", db, where)
 }
 
 /*
 This function takes 2 arguments:
-  - a pointer to a Group object, which represents the group data to be deleted.
+  - a pointer to a GroupFiles object, which will be populated with the files retrieved from the database.
   - a pointer to an sql.DB object, representing the database connection.
-  - a map[string]any containing the where clause, which specifies the conditions for selecting the record(s) to delete.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "GroupFile" table.
 
-The purpose of this function is to delete group data from the "Groups" table based on the provided conditions.
+The purpose of this function is to retrieve the descriptions of the files based on the given conditions, sorted by name.
 
 The function returns 1 value:
-  - an error if the delete operation fails
+  - an error if the data retrieval or parsing fails
 */
-func (group *Group) DeleteFromDb(db *sql.DB, where map[string]any) error {
-	// We call RemoveFromDB to delete the record(s) from the "Groups" table based on the specified conditions
-	return RemoveFromDB("Groups", db, where)
-}
+func (files *GroupFiles) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "GroupFile" table based on the given conditions
+	userData, err := SelectFromDb("GroupFile", db, where)
+	if err != nil {
+		return err
+	}
 
-/*
-This function takes no arguments and is a method of the Group struct.
+	if *files, err = userData.ParseGroupFilesData(); err != nil {
+		return err
+	}
 
-The purpose of this function is to split the MemberIds string into a slice of individual member IDs, using " | " as the delimiter.
+	slices.SortFunc(*files, func(a, b GroupFile) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
 
-This function updates the SplitMemberIds field of the Group struct with the resulting slice.
-*/
-func (group *Group) SplitMembers() {
-	// We split the MemberIds string into a slice of strings using " | " as the delimiter
-	group.SplitMemberIds = strings.Split(group.MemberIds, " | ")
+	return nil
 }
 
 /*
-This function takes no arguments and is a method of the Group struct.
+This function takes 2 arguments:
+  - a pointer to an sql.DB object, representing the database connection.
+  - a string containing the id of the group.
 
-The purpose of this function is to join the SplitMemberIds slice into a single string, using " | " as the separator.
+The purpose of this function is to retrieve the folders which contain at least one file of the group, and the space used by the files.
 
-This function updates the MemberIds field of the Group struct with the resulting string.
+The function returns 3 values:
+  - the folders, sorted by path
+  - the quantity of bytes of all the files of the group
+  - an error if one of the queries fails
 */
-func (group *Group) JoinMembers() {
-	// We join the SplitMemberIds slice into a single string using " | " as the separator
-	group.MemberIds = strings.Join(group.SplitMemberIds, " | ")
-}
+func (files *GroupFiles) SelectFolders(db *sql.DB, groupId string) ([]string, int64, error) {
+	var used int64
+	if err := db.QueryRow("SELECT IFNULL(SUM(Size), 0) FROM GroupFile WHERE GroupId = ?", groupId).Scan(&used); err != nil {
+		return nil, 0, err
+	}
 
-func (groups *Groups) SelectFromDb(db *sql.DB, where map[string]any) error {
-	// We call SelectFromDb to retrieve data from the "UserInfo" table based on the given conditions
-	userData, err := SelectFromDb("Groups", db, where)
+	rows, err := db.Query("SELECT DISTINCT Folder FROM GroupFile WHERE GroupId = ? AND Folder <> '' ORDER BY Folder", groupId)
 	if err != nil {
-		// Return an error if the data retrieval fails
-		return err
+		return nil, 0, err
 	}
+	defer rows.Close()
 
-	*groups, err = userData.ParseGroupsData()
+	folders := []string{}
+	for rows.Next() {
+		var folder string
+		if err = rows.Scan(&folder); err != nil {
+			return nil, 0, err
+		}
 
-	// Return any error encountered during parsing
-	return err
+		folders = append(folders, folder)
+	}
+
+	return folders, used, rows.Err()
 }
 
 // ----------------------------------------------------------------------------------------------
@@ -1341,8 +4274,24 @@ func (joinGroup *JoinGroupRequest) InsertIntoDb(db *sql.DB) error {
 		return errors.New("empty field")
 	}
 
-	// We call InsertIntoDb to insert the group data into the "Groups" table in the database
-	return InsertIntoDb("JoinGroupRequest", db, joinGroup.UserId, joinGroup.GroupId)
+	// The request and its answers are saved together.
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("INSERT INTO JoinGroupRequest VALUES(?, ?)", joinGroup.UserId, joinGroup.GroupId); err != nil {
+		return err
+	}
+
+	for _, answer := range joinGroup.Answers {
+		if _, err = tx.Exec("INSERT INTO JoinGroupAnswer VALUES(?, ?, ?, ?, ?)", joinGroup.GroupId, joinGroup.UserId, answer.Position, answer.Question, answer.Answer); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (joinGroup *JoinGroupRequests) SelectFromDb(db *sql.DB, where map[string]any) error {
@@ -1358,6 +4307,36 @@ func (joinGroup *JoinGroupRequests) SelectFromDb(db *sql.DB, where map[string]an
 	return err
 }
 
+/*
+This function takes 2 arguments:
+  - a pointer to a JoinGroupAnswers object, which will be populated with the answers retrieved from the database.
+  - a pointer to an sql.DB object, representing the database connection.
+  - a map[string]any, which contains the conditions (WHERE clause) for selecting the data from the "JoinGroupAnswer" table.
+
+The purpose of this function is to retrieve the answers to the questions of a group, in the order of the questions.
+The answers are deleted with their join request by the "JoinGroupAnswerCleanup" trigger.
+
+The function returns 1 value:
+  - an error if the data retrieval or parsing fails
+*/
+func (answers *JoinGroupAnswers) SelectFromDb(db *sql.DB, where map[string]any) error {
+	// We call SelectFromDb to retrieve data from the "JoinGroupAnswer" table based on the given conditions
+	userData, err := SelectFromDb("JoinGroupAnswer", db, where)
+	if err != nil {
+		return err
+	}
+
+	if *answers, err = userData.ParseJoinGroupAnswersData(); err != nil {
+		return err
+	}
+
+	slices.SortFunc(*answers, func(a, b JoinGroupAnswer) int {
+		return a.Position - b.Position
+	})
+
+	return nil
+}
+
 // ----------------------------------------------------------------------------------------------
 // ----------------------------------------------------------------------------------------------
 //
@@ -39,6 +39,9 @@ type Register struct {
 
 	Status string `json:"Status"`
 
+	// "hide" to blur the content with a warning or a sensitive media, "expand" to show it directly.
+	SensitiveContent string `json:"SensitiveContent"`
+
 	GroupsJoined      string `json:"GroupsJoined"`
 	SplitGroupsJoined []string
 }
@@ -56,9 +59,179 @@ type Post struct {
 	IsGroup      string `json:"IsGroup"`
 	LikeCount    int    `json:"LikeCount"`
 	DislikeCount int    `json:"DislikeCount"`
+
+	// The id of the original post when the post is a repost or a quote post.
+	RepostOf    string `json:"RepostOf"`
+	RepostCount int    `json:"RepostCount"`
+
+	// An optional warning shown before the text, and the flag of an image to blur.
+	ContentWarning string `json:"ContentWarning"`
+	SensitiveMedia bool   `json:"SensitiveMedia"`
+
+	// An announcement of a group must be acknowledged by the members, Acknowledged is only filled for the reader.
+	IsAnnouncement bool `json:"IsAnnouncement"`
+	Acknowledged   bool `json:"Acknowledged"`
+
+	// The poll attached to the post, given at the creation of the post and sent back when the post is read.
+	Poll *Poll `json:"Poll,omitempty"`
+
+	// The preview card of the first link of the text, once it has been fetched.
+	LinkPreview *LinkPreview `json:"LinkPreview,omitempty"`
 }
 type Posts []Post
 
+type Poll struct {
+	Id             string `json:"Id"`
+	PostId         string `json:"PostId"`
+	MultipleChoice bool   `json:"MultipleChoice"`
+	Anonymous      bool   `json:"Anonymous"`
+
+	// The date of the closing in the "2006-01-02 15:04" format (UTC), empty if the poll never closes.
+	ClosingDate string `json:"ClosingDate"`
+
+	Options PollOptions `json:"Options"`
+}
+
+type PollOption struct {
+	Id       string `json:"Id"`
+	PollId   string `json:"PollId"`
+	Position int    `json:"Position"`
+	Text     string `json:"Text"`
+}
+type PollOptions []PollOption
+
+type PollVote struct {
+	PollId   string `json:"PollId"`
+	OptionId string `json:"OptionId"`
+	UserId   string `json:"UserId"`
+}
+type PollVotes []PollVote
+
+type PinnedPost struct {
+	PostId string `json:"PostId"`
+	// The author for a post pinned on a profile, or the group for a group post.
+	OwnerId  string `json:"OwnerId"`
+	Position int    `json:"Position"`
+}
+type PinnedPosts []PinnedPost
+
+type LinkPreview struct {
+	// The type of the content containing the link ("Post" or "Message") and its id.
+	SourceType  string `json:"SourceType"`
+	SourceId    string `json:"SourceId"`
+	Url         string `json:"Url"`
+	Title       string `json:"Title"`
+	Description string `json:"Description"`
+	Image       string `json:"Image"`
+	FetchDate   string `json:"FetchDate"`
+}
+type LinkPreviews []LinkPreview
+
+// A post seen by a user during a day, each view is only counted once a day.
+type PostView struct {
+	PostId   string `json:"PostId"`
+	ViewerId string `json:"ViewerId"`
+	Day      string `json:"Day"`
+}
+type PostViews []PostView
+
+type AnnouncementAck struct {
+	PostId       string `json:"PostId"`
+	UserId       string `json:"UserId"`
+	CreationDate string `json:"CreationDate"`
+}
+type AnnouncementAcks []AnnouncementAck
+
+type PostStats struct {
+	// The id of the post, or empty when the statistics are for all the posts of the author.
+	PostId        string `json:"PostId"`
+	Views         int    `json:"Views"`
+	UniqueViewers int    `json:"UniqueViewers"`
+	Reactions     int    `json:"Reactions"`
+	Comments      int    `json:"Comments"`
+
+	Daily []DailyPostStats `json:"Daily"`
+}
+
+type DailyPostStats struct {
+	Day       string `json:"Day"`
+	Views     int    `json:"Views"`
+	Reactions int    `json:"Reactions"`
+	Comments  int    `json:"Comments"`
+}
+
+type GroupStats struct {
+	GroupId string `json:"GroupId"`
+	// The current members, and the activity of the period.
+	Members  int `json:"Members"`
+	Posts    int `json:"Posts"`
+	Comments int `json:"Comments"`
+	Messages int `json:"Messages"`
+
+	Daily         []DailyGroupStats     `json:"Daily"`
+	ActiveMembers []GroupMemberActivity `json:"ActiveMembers"`
+	Events        []EventAttendance     `json:"Events"`
+	// The share of the answers to the events of the group which are "going", from 0 to 1.
+	AttendanceRate float64 `json:"AttendanceRate"`
+}
+
+type DailyGroupStats struct {
+	Day string `json:"Day"`
+	// The members at the end of the day, and those who joined during the day.
+	Members    int `json:"Members"`
+	NewMembers int `json:"NewMembers"`
+	Posts      int `json:"Posts"`
+	Comments   int `json:"Comments"`
+	Messages   int `json:"Messages"`
+}
+
+type GroupMemberActivity struct {
+	UserId   string `json:"UserId"`
+	Posts    int    `json:"Posts"`
+	Comments int    `json:"Comments"`
+	Messages int    `json:"Messages"`
+	Total    int    `json:"Total"`
+}
+
+type EventAttendance struct {
+	EventId        string  `json:"EventId"`
+	Title          string  `json:"Title"`
+	DateOfTheEvent string  `json:"DateOfTheEvent"`
+	Going          int     `json:"Going"`
+	NotGoing       int     `json:"NotGoing"`
+	Rate           float64 `json:"Rate"`
+}
+
+type PollResults struct {
+	Poll   Poll `json:"Poll"`
+	Closed bool `json:"Closed"`
+
+	// The quantity of votes for each option id, and the users who voted for it (empty when the poll is anonymous).
+	Counts      map[string]int      `json:"Counts"`
+	Voters      map[string][]string `json:"Voters"`
+	TotalVoters int                 `json:"TotalVoters"`
+
+	// The ids of the options chosen by the user who asked for the results.
+	UserVote []string `json:"UserVote"`
+}
+
+type PostDraft struct {
+	Id       string `json:"Id"`
+	AuthorId string `json:"AuthorId"`
+	Text     string `json:"Text"`
+	Image    string `json:"Image"`
+	Status   string `json:"Status"`
+	IsGroup  string `json:"IsGroup"`
+
+	// The date of the publication in the "2006-01-02 15:04" format (UTC), empty for a simple draft.
+	PublishDate string `json:"PublishDate"`
+
+	// The content warning and the sensitive media flag given to the post once published.
+	ContentWarning string `json:"ContentWarning"`
+	SensitiveMedia bool   `json:"SensitiveMedia"`
+}
+type PostDrafts []PostDraft
+
 type Comment struct {
 	Id           string `json:"Id"`
 	AuthorId     string `json:"AuthorId"`
@@ -67,12 +240,38 @@ type Comment struct {
 	CreationDate string `json:"CreationDate"`
 	PostId       string `json:"PostId"`
 
+	// An optional warning shown before the text, and the flag of an image to blur.
+	ContentWarning string `json:"ContentWarning"`
+	SensitiveMedia bool   `json:"SensitiveMedia"`
+
 	LikeCount    int `json:"LikeCount"`
 	DislikeCount int `json:"DislikeCount"`
 	Register     `json:",inline"`
 }
 type Comments []Comment
 
+var ReactionKinds = []string{"like", "love", "laugh", "sad", "angry", "dislike"}
+
+type Reaction struct {
+	TargetType   string `json:"TargetType"`
+	TargetId     string `json:"TargetId"`
+	UserId       string `json:"UserId"`
+	User_Name    string `json:"User_Name"`
+	User_Picture string `json:"User_Picture"`
+	Kind         string `json:"Kind"`
+}
+type Reactions []Reaction
+
+type Bookmark struct {
+	UserId       string `json:"UserId"`
+	PostId       string `json:"PostId"`
+	Collection   string `json:"Collection"`
+	CreationDate string `json:"CreationDate"`
+
+	Post Post `json:"Post"`
+}
+type Bookmarks []Bookmark
+
 type Follower struct {
 	Id string `json:"Id"`
 
@@ -99,6 +298,36 @@ type FollowRequest struct {
 }
 type FollowRequests []FollowRequest
 
+// A block between two users, it applies in both directions: they can't follow, write to or invite each other,
+// and their posts and comments are hidden from each other.
+type UserBlock struct {
+	BlockerId string `json:"BlockerId"`
+	BlockedId string `json:"BlockedId"`
+	// The date is in the "2006-01-02 15:04" format (UTC).
+	CreationDate string `json:"CreationDate"`
+}
+type UserBlocks []UserBlock
+
+// A mute of a user by the current user, the follow is kept but the posts and the notifications of the muted user are hidden.
+type UserMute struct {
+	UserId      string `json:"UserId"`
+	MutedUserId string `json:"MutedUserId"`
+	// The dates are in the "2006-01-02 15:04" format (UTC), an empty ExpirationDate is a mute without end.
+	CreationDate   string `json:"CreationDate"`
+	ExpirationDate string `json:"ExpirationDate"`
+}
+type UserMutes []UserMute
+
+// A mute of a group by one of its members, the notifications and the websocket messages of its posts and its chat are hidden.
+type GroupMute struct {
+	UserId  string `json:"UserId"`
+	GroupId string `json:"GroupId"`
+	// The dates are in the "2006-01-02 15:04" format (UTC), an empty ExpirationDate is a mute without end.
+	CreationDate   string `json:"CreationDate"`
+	ExpirationDate string `json:"ExpirationDate"`
+}
+type GroupMutes []GroupMute
+
 type Group struct {
 	Id               string `json:"Id"`
 	LeaderId         string `json:"LeaderId"`
@@ -111,6 +340,16 @@ type Group struct {
 
 	GroupPicture string `json:"GroupPicture"`
 	Banner       string `json:"Banner"`
+	// "public", "private" or "secret", see the GroupVisibility migration.
+	Visibility string `json:"Visibility"`
+	// The categories are saved in the "GroupCategory" table, they are only filled when needed.
+	Categories  []string `json:"Categories"`
+	MemberCount int      `json:"MemberCount"`
+	// The rules of the group, and the questions of the "GroupQuestion" table that are only filled when needed.
+	Rules     string   `json:"Rules"`
+	Questions []string `json:"Questions"`
+	// The date (format 2006-01-02 15:04, UTC) when the group has been archived, empty when it is active.
+	ArchivedAt string `json:"ArchivedAt"`
 
 	NotificationQuantity int
 }
@@ -121,9 +360,22 @@ type JoinGroupRequest struct {
 
 	GroupId   string `json:"GroupId"`
 	GroupName string `json:"GroupName"`
+
+	// The answers to the questions of the group, saved in the "JoinGroupAnswer" table.
+	Answers JoinGroupAnswers `json:"Answers"`
 }
 type JoinGroupRequests []JoinGroupRequest
 
+// The answer of a user to a question of a group, the question is kept as it was asked.
+type JoinGroupAnswer struct {
+	GroupId  string `json:"GroupId"`
+	UserId   string `json:"UserId"`
+	Position int    `json:"Position"`
+	Question string `json:"Question"`
+	Answer   string `json:"Answer"`
+}
+type JoinGroupAnswers []JoinGroupAnswer
+
 type InviteGroupRequest struct {
 	SenderId    string `json:"SenderId"`
 	Sender_Name string `json:"Sender_Name"`
@@ -138,6 +390,66 @@ type InviteGroupRequests []InviteGroupRequest
 
 type Groups []Group
 
+// The criteria of a search of groups, each word of Query must be in the name or the description of the group.
+type GroupSearch struct {
+	Query    string `json:"Query"`
+	Category string `json:"Category"`
+	// "members" or "activity", the groups are sorted by name otherwise.
+	OrderBy string `json:"OrderBy"`
+	// The pagination of the results.
+	Offset int `json:"Offset"`
+	Limit  int `json:"Limit"`
+}
+
+// The membership of a user in a group, the leader is also a member with the "leader" role.
+type GroupMember struct {
+	GroupId  string `json:"GroupId"`
+	UserId   string `json:"UserId"`
+	Role     string `json:"Role"`
+	JoinedAt string `json:"JoinedAt"`
+}
+type GroupMembers []GroupMember
+
+type GroupBan struct {
+	GroupId  string `json:"GroupId"`
+	UserId   string `json:"UserId"`
+	BannedBy string `json:"BannedBy"`
+	Reason   string `json:"Reason"`
+	// The dates are in the "2006-01-02 15:04" format (UTC), an empty ExpirationDate is a permanent ban.
+	CreationDate   string `json:"CreationDate"`
+	ExpirationDate string `json:"ExpirationDate"`
+}
+type GroupBans []GroupBan
+
+// A shareable link to join a group, a MaxUses of 0 is a link without limit of uses.
+type GroupInviteLink struct {
+	Token     string `json:"Token"`
+	GroupId   string `json:"GroupId"`
+	CreatorId string `json:"CreatorId"`
+	// The dates are in the "2006-01-02 15:04" format (UTC), an empty ExpirationDate is a link without expiry.
+	CreationDate   string `json:"CreationDate"`
+	ExpirationDate string `json:"ExpirationDate"`
+	MaxUses        int    `json:"MaxUses"`
+	Uses           int    `json:"Uses"`
+	// The users joining with the link become members without the approval of a moderator.
+	SkipApproval bool `json:"SkipApproval"`
+}
+type GroupInviteLinks []GroupInviteLink
+
+// The description of a file of the library of a group, its content is kept in a media store under its Id.
+type GroupFile struct {
+	Id         string `json:"Id"`
+	GroupId    string `json:"GroupId"`
+	UploaderId string `json:"UploaderId"`
+	// The folder is a path like "courses/week 1", empty for the root of the library.
+	Folder       string `json:"Folder"`
+	Name         string `json:"Name"`
+	MimeType     string `json:"MimeType"`
+	Size         int64  `json:"Size"`
+	CreationDate string `json:"CreationDate"`
+}
+type GroupFiles []GroupFile
+
 type Event struct {
 	Id             string `json:"Id"`
 	GroupId        string `json:"GroupId"`
@@ -196,6 +508,9 @@ type Message struct {
 	Receiver_Name string `json:"Receiver_Name"`
 	GroupId       string `json:"GroupId"`
 	Group_Name    string `json:"Group_Name"`
+
+	// The preview card of the first link of the message, once it has been fetched.
+	LinkPreview *LinkPreview `json:"LinkPreview,omitempty"`
 }
 type Messages []Message
 
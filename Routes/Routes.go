@@ -37,10 +37,32 @@ func Routes(mux *http.ServeMux) {
 	// Posts routes
 	mux.Handle("/createPost", handler.CreatePost(db))
 	mux.Handle("/getPost", handler.GetPost(db))
+	mux.Handle("/repost", handler.RepostPost(db))
+	mux.Handle("/flagPost", handler.FlagPost(db))
+	mux.Handle("/getPostStats", handler.GetPostStats(db))
+	mux.Handle("/deletePost", handler.DeletePost(db))
+
+	// Poll routes
+	mux.Handle("/votePoll", handler.VotePoll(db))
+	mux.Handle("/getPollResults", handler.GetPollResults(db))
+
+	// Pinned post routes
+	mux.Handle("/pinPost", handler.PinPost(db))
+	mux.Handle("/unpinPost", handler.UnpinPost(db))
+	mux.Handle("/reorderPinnedPosts", handler.ReorderPinnedPosts(db))
+	mux.Handle("/getPinnedPosts", handler.GetPinnedPosts(db))
+
+	// Draft and scheduled post routes
+	mux.Handle("/savePostDraft", handler.SavePostDraft(db))
+	mux.Handle("/getPostDrafts", handler.GetPostDrafts(db))
+	mux.Handle("/updatePostDraft", handler.UpdatePostDraft(db))
+	mux.Handle("/cancelPostDraft", handler.CancelPostDraft(db))
 
 	// Comments routes
 	mux.Handle("/createComment", handler.CreateComment(db))
 	mux.Handle("/getComment", handler.GetComment(db))
+	mux.Handle("/flagComment", handler.FlagComment(db))
+	mux.Handle("/deleteComment", handler.DeleteComment(db))
 
 	// Followers routes
 	mux.Handle("/addFollowed", handler.AddFollower(db))
@@ -57,9 +79,26 @@ func Routes(mux *http.ServeMux) {
 	mux.Handle("/removeFollowed", handler.RemoveFollowed(db))
 	mux.Handle("/removeFollower", handler.RemoveFollower(db))
 
-	// Like routes
-	mux.Handle("/like", handler.HandleLike(db))
-	mux.Handle("/getLikePost", handler.GetLike(db))
+	// Block routes
+	mux.Handle("/blockUser", handler.BlockUser(db))
+	mux.Handle("/unblockUser", handler.UnblockUser(db))
+	mux.Handle("/getBlockedUsers", handler.GetBlockedUsers(db))
+
+	// Mute routes
+	mux.Handle("/muteUser", handler.MuteUser(db))
+	mux.Handle("/unmuteUser", handler.UnmuteUser(db))
+	mux.Handle("/muteGroup", handler.MuteGroup(db))
+	mux.Handle("/unmuteGroup", handler.UnmuteGroup(db))
+	mux.Handle("/getMutes", handler.GetMutes(db))
+
+	// Reaction routes
+	mux.Handle("/reaction", handler.HandleReaction(db))
+	mux.Handle("/getReactions", handler.GetReactions(db))
+
+	// Bookmark routes
+	mux.Handle("/bookmarkPost", handler.BookmarkPost(db))
+	mux.Handle("/unbookmarkPost", handler.UnbookmarkPost(db))
+	mux.Handle("/getBookmarks", handler.GetBookmarks(db))
 
 	// Setting route
 	mux.Handle("/updateUserInfo", handler.HandleChangeUserData(db))
@@ -70,8 +109,26 @@ func Routes(mux *http.ServeMux) {
 	mux.Handle("/getGroup", handler.GetGroup(db))
 	mux.Handle("/getAllGroups", handler.GetAllGroups(db))
 	mux.Handle("/getGroupsJoined", handler.GetGroupsJoined(db))
+	mux.Handle("/searchGroups", handler.SearchGroups(db))
+	mux.Handle("/getSuggestedGroups", handler.GetSuggestedGroups(db))
 	mux.Handle("/getGroupsPosts", handler.GetGroupsPosts(db))
+	mux.Handle("/acknowledgeAnnouncement", handler.AcknowledgeAnnouncement(db))
+	mux.Handle("/getAnnouncementAcks", handler.GetAnnouncementAcks(db))
+	mux.Handle("/archiveGroup", handler.ArchiveGroup(db))
+	mux.Handle("/unarchiveGroup", handler.UnarchiveGroup(db))
 	mux.Handle("/deleteGroup", handler.DeleteGroup(db))
+	mux.Handle("/updateGroup", handler.UpdateGroup(db))
+	mux.Handle("/setGroupRules", handler.SetGroupRules(db))
+	mux.Handle("/getGroupStats", handler.GetGroupStats(db))
+	mux.Handle("/uploadGroupFile", handler.UploadGroupFile(db))
+	mux.Handle("/getGroupFiles", handler.GetGroupFiles(db))
+	mux.Handle("/downloadGroupFile", handler.DownloadGroupFile(db))
+	mux.Handle("/deleteGroupFile", handler.DeleteGroupFile(db))
+	mux.Handle("/setGroupRole", handler.SetGroupRole(db))
+	mux.Handle("/transferGroupLeadership", handler.TransferGroupLeadership(db))
+	mux.Handle("/removeGroupMember", handler.RemoveGroupMember(db))
+	mux.Handle("/banGroupMember", handler.BanGroupMember(db))
+	mux.Handle("/getGroupBans", handler.GetGroupBans(db))
 
 	mux.Handle("/joinGroup", handler.JoinGroup(db))
 	mux.Handle("/getSendJoinRequest", handler.GetSendJoinRequest(db))
@@ -84,6 +141,10 @@ func Routes(mux *http.ServeMux) {
 	mux.Handle("/getInvitationUserInGroup", handler.GetInvitationUserInGroup(db))
 	mux.Handle("/declineInvitationGroup", handler.DeclineInvitationGroup(db))
 	mux.Handle("/acceptInvitationGroup", handler.AcceptInvitationGroup(db))
+	mux.Handle("/createGroupInviteLink", handler.CreateGroupInviteLink(db))
+	mux.Handle("/getGroupInviteLinks", handler.GetGroupInviteLinks(db))
+	mux.Handle("/revokeGroupInviteLink", handler.RevokeGroupInviteLink(db))
+	mux.Handle("/joinGroupByLink", handler.JoinGroupByLink(db))
 
 	// Event routes
 	mux.Handle("/createEvent", handler.CreateEvent(db))
@@ -109,6 +170,8 @@ func Routes(mux *http.ServeMux) {
 	mux.Handle("/websocket/", handler.Websocket(db))
 
 	go utils.AutoDeleteEvent(db)
+	go handler.AutoPublishScheduledPosts(db)
+	go handler.AutoFlushPostViews(db)
 }
 
 // Mock Login handler for testing
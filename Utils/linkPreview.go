@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	model "social-network/Model"
+)
+
+// LinkPreviewFetcher is the interface used to get the preview card of a link.
+// The handlers use an HTTPLinkPreviewFetcher, the tests can use a fetcher allowed to reach a local server.
+type LinkPreviewFetcher interface {
+	Fetch(ctx context.Context, link string) (model.LinkPreview, error)
+}
+
+// HTTPLinkPreviewFetcher fetches the page of the link and reads its OpenGraph and Twitter meta tags.
+type HTTPLinkPreviewFetcher struct {
+	Client *http.Client
+	// The maximum quantity of bytes read from the page.
+	MaxBytes int64
+}
+
+const (
+	linkPreviewTimeout      = 5 * time.Second
+	linkPreviewMaxBytes     = 512 * 1024
+	linkPreviewMaxRedirects = 5
+
+	maxPreviewTitleLength       = 300
+	maxPreviewDescriptionLength = 1000
+)
+
+var (
+	urlRegexp       = regexp.MustCompile(`https?://[^\s<>"']+`)
+	metaTagRegexp   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	titleTagRegexp  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	attributeRegexp = regexp.MustCompile(`(?s)([a-zA-Z:_-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+
+	// The carrier-grade NAT range isn't covered by net.IP.IsPrivate.
+	sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+	// The addresses of "this network", 0.x.x.x reaches the local services on Linux.
+	thisNetwork = &net.IPNet{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)}
+	// The NAT64 prefix embeds an IPv4 address, which can be one of the blocked ranges.
+	nat64Prefix = &net.IPNet{IP: net.ParseIP("64:ff9b::"), Mask: net.CIDRMask(96, 128)}
+)
+
+/*
+This function takes 1 argument:
+  - a boolean, true to allow the requests to the private and loopback addresses
+
+The purpose of this function is to create the fetcher of the link previews, with a timeout, a limit on the size of
+the page and a protection against the SSRF: the address is checked when the connection is opened, after the DNS
+resolution, so a redirection or a domain resolved to a private address is refused too.
+
+The function returns the new fetcher.
+*/
+func NewLinkPreviewFetcher(allowPrivate bool) *HTTPLinkPreviewFetcher {
+	dialer := &net.Dialer{
+		Timeout: linkPreviewTimeout,
+	}
+
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || IsBlockedIP(ip) {
+				return fmt.Errorf("the address %s is blocked", host)
+			}
+
+			return nil
+		}
+	}
+
+	return &HTTPLinkPreviewFetcher{
+		Client: &http.Client{
+			Timeout: linkPreviewTimeout,
+			Transport: &http.Transport{
+				Proxy:                 nil,
+				DialContext:           dialer.DialContext,
+				TLSHandshakeTimeout:   linkPreviewTimeout,
+				ResponseHeaderTimeout: linkPreviewTimeout,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= linkPreviewMaxRedirects {
+					return errors.New("too many redirections")
+				}
+
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return errors.New("invalid scheme")
+				}
+
+				return nil
+			},
+		},
+		MaxBytes: linkPreviewMaxBytes,
+	}
+}
+
+/*
+This function takes 1 argument:
+  - a net.IP object
+
+The purpose of this function is to check if an address mustn't be reached by the fetcher:
+loopback, private, link-local, multicast, unspecified, shared, "this network" and NAT64 addresses.
+
+The function returns true if the address is blocked.
+*/
+func IsBlockedIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) || thisNetwork.Contains(ip) || nat64Prefix.Contains(ip)
+}
+
+/*
+This function takes 2 arguments:
+  - a context, which gives the deadline of the fetch
+  - a string containing the link
+
+The purpose of this function is to download the page of the link and to read its preview card.
+Only the first MaxBytes bytes of an HTML page are read.
+
+The function returns the preview (without its source) and an error if the page can't be fetched.
+*/
+func (fetcher *HTTPLinkPreviewFetcher) Fetch(ctx context.Context, link string) (model.LinkPreview, error) {
+	parsedUrl, err := url.Parse(link)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return model.LinkPreview{}, errors.New("invalid link")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedUrl.String(), nil)
+	if err != nil {
+		return model.LinkPreview{}, err
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := fetcher.Client.Do(req)
+	if err != nil {
+		return model.LinkPreview{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return model.LinkPreview{}, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if !strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		return model.LinkPreview{}, errors.New("the link isn't an HTML page")
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, fetcher.MaxBytes))
+	if err != nil {
+		return model.LinkPreview{}, err
+	}
+
+	preview := ParseLinkPreview(string(page), resp.Request.URL)
+	preview.Url = link
+
+	return preview, nil
+}
+
+/*
+This function takes 2 arguments:
+  - a string containing the HTML page
+  - a pointer to the url of the page, used to resolve a relative image
+
+The purpose of this function is to read the title, the description and the image of the page.
+The OpenGraph tags are preferred to the Twitter tags, then to the title and description of the page.
+
+The function returns the preview, without its url and its source.
+*/
+func ParseLinkPreview(page string, pageUrl *url.URL) model.LinkPreview {
+	metas := map[string]string{}
+	for _, tag := range metaTagRegexp.FindAllString(page, -1) {
+		var key, content string
+		for _, attribute := range attributeRegexp.FindAllStringSubmatch(tag, -1) {
+			value := attribute[2] + attribute[3] + attribute[4]
+
+			switch strings.ToLower(attribute[1]) {
+			case "property", "name":
+				key = strings.ToLower(value)
+			case "content":
+				content = value
+			}
+		}
+
+		// The first tag with a key is kept.
+		if _, exists := metas[key]; key != "" && !exists {
+			metas[key] = strings.TrimSpace(html.UnescapeString(content))
+		}
+	}
+
+	firstOf := func(keys ...string) string {
+		for _, key := range keys {
+			if metas[key] != "" {
+				return metas[key]
+			}
+		}
+		return ""
+	}
+
+	preview := model.LinkPreview{
+		Title:       firstOf("og:title", "twitter:title"),
+		Description: firstOf("og:description", "twitter:description", "description"),
+	}
+
+	if preview.Title == "" {
+		if match := titleTagRegexp.FindStringSubmatch(page); match != nil {
+			preview.Title = strings.TrimSpace(html.UnescapeString(match[1]))
+		}
+	}
+
+	if rawImage := firstOf("og:image", "twitter:image", "twitter:image:src"); rawImage != "" {
+		if image, err := url.Parse(rawImage); err == nil {
+			if pageUrl != nil {
+				image = pageUrl.ResolveReference(image)
+			}
+
+			if image.Scheme == "http" || image.Scheme == "https" {
+				preview.Image = image.String()
+			}
+		}
+	}
+
+	preview.Title = truncate(preview.Title, maxPreviewTitleLength)
+	preview.Description = truncate(preview.Description, maxPreviewDescriptionLength)
+
+	return preview
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the text of a post or of a message
+
+The purpose of this function is to find the first http or https link of the text.
+
+The function returns the link, or an empty string if there is none.
+*/
+func FindFirstURL(text string) string {
+	// The punctuation after a link is part of the sentence.
+	return strings.TrimRight(urlRegexp.FindString(text), ".,;:!?)]}")
+}
+
+// truncate cuts a text to a maximum quantity of characters.
+func truncate(text string, length int) string {
+	if runes := []rune(text); len(runes) > length {
+		return string(runes[:length])
+	}
+	return text
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const previewPage = `<html><head>
+	<title>Page title</title>
+	<meta property="og:title" content="Open &amp; Graph">
+	<meta name="twitter:title" content="Twitter title">
+	<meta name='description' content='The description'>
+	<meta property="og:image" content="/image.png">
+</head><body></body></html>`
+
+func TestLinkPreviewFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if r.URL.Path == "/big" {
+			w.Write([]byte(strings.Repeat(" ", linkPreviewMaxBytes) + `<meta property="og:title" content="too far">`))
+			return
+		}
+		w.Write([]byte(previewPage))
+	}))
+	defer server.Close()
+
+	preview, err := NewLinkPreviewFetcher(true).Fetch(context.Background(), server.URL+"/page")
+	if err != nil {
+		t.Fatalf("The page should be fetched : %v", err)
+	}
+
+	if preview.Title != "Open & Graph" || preview.Description != "The description" || preview.Image != server.URL+"/image.png" || preview.Url != server.URL+"/page" {
+		t.Fatalf("The preview is not the good one : %+v", preview)
+	}
+
+	// Only the beginning of the page is read
+	if preview, err = NewLinkPreviewFetcher(true).Fetch(context.Background(), server.URL+"/big"); err != nil || preview.Title != "" {
+		t.Fatalf("The end of a big page shouldn't be read : %+v %v", preview, err)
+	}
+
+	// The local server is refused by the default fetcher
+	if _, err = NewLinkPreviewFetcher(false).Fetch(context.Background(), server.URL+"/page"); err == nil {
+		t.Fatal("A loopback address shouldn't be fetched")
+	}
+
+	if _, err = NewLinkPreviewFetcher(true).Fetch(context.Background(), "file:///etc/passwd"); err == nil {
+		t.Fatal("Only the http and https links can be fetched")
+	}
+}
+
+func TestIsBlockedIP(t *testing.T) {
+	for address, blocked := range map[string]bool{
+		"127.0.0.1":       true,
+		"10.1.2.3":        true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"100.64.0.1":      true,
+		"0.0.0.0":         true,
+		"0.1.2.3":         true,
+		"64:ff9b::7f00:1": true,
+		"::1":             true,
+		"fd00::1":         true,
+		"93.184.216.34":   false,
+		"2606:4700::1111": false,
+	} {
+		if IsBlockedIP(net.ParseIP(address)) != blocked {
+			t.Errorf("The address %s should have blocked = %v", address, blocked)
+		}
+	}
+}
+
+func TestFindFirstURL(t *testing.T) {
+	for text, expected := range map[string]string{
+		"no link here":                                         "",
+		"look at https://example.com/page.":                    "https://example.com/page",
+		"(see http://example.com/a?b=c) and https://other.com": "http://example.com/a?b=c",
+	} {
+		if link := FindFirstURL(text); link != expected {
+			t.Errorf("The link of %q should be %q, not %q", text, expected, link)
+		}
+	}
+}
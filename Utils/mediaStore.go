@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MediaStore is the interface used to keep the content of the files shared in the groups, the DB only keeps their description.
+// The handlers use a DiskMediaStore, the tests can use a store in a temporary directory.
+type MediaStore interface {
+	Save(key string, content []byte) error
+	Load(key string) ([]byte, error)
+	Delete(key string) error
+}
+
+// DiskMediaStore keeps each file in a directory, under the name of its key.
+type DiskMediaStore struct {
+	Dir string
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the directory of the files, created at the first save
+
+The purpose of this function is to create a store of the files on the disk.
+
+The function returns the new store.
+*/
+func NewDiskMediaStore(dir string) *DiskMediaStore {
+	return &DiskMediaStore{Dir: dir}
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the key of a file
+
+The purpose of this function is to find the path of a file in the directory of the store.
+The key is an id generated by the server, a key which could leave the directory is refused.
+
+The function returns the path of the file, or an error if the key is invalid.
+*/
+func (store *DiskMediaStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", errors.New("invalid media key")
+	}
+
+	return filepath.Join(store.Dir, key), nil
+}
+
+/*
+This function takes 2 arguments:
+  - a string containing the key of the file
+  - a slice of bytes containing the content of the file
+
+The purpose of this function is to save a file. The content is written in a temporary file first,
+so a file is never read half-written.
+
+The function returns an error if the file can't be written.
+*/
+func (store *DiskMediaStore) Save(key string, content []byte) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(store.Dir, 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(store.Dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	// Remove does nothing once the temporary file has been renamed.
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the key of the file
+
+The purpose of this function is to read the content of a file.
+
+The function returns the content, or an error if the file can't be read.
+*/
+func (store *DiskMediaStore) Load(key string) ([]byte, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
+
+/*
+This function takes 1 argument:
+  - a string containing the key of the file
+
+The purpose of this function is to remove a file. Removing a file which doesn't exist isn't an error.
+
+The function returns an error if the file can't be removed.
+*/
+func (store *DiskMediaStore) Delete(key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
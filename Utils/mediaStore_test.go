@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskMediaStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "media")
+	store := NewDiskMediaStore(dir)
+
+	if err := store.Save("fileId", []byte("content")); err != nil {
+		t.Fatalf("The file should be saved : %v", err)
+	}
+
+	content, err := store.Load("fileId")
+	if err != nil || !bytes.Equal(content, []byte("content")) {
+		t.Fatalf("The file should be read : %q %v", content, err)
+	}
+
+	// Only the file is left in the directory, without the temporary file
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("The directory should only contain the file : %v %v", entries, err)
+	}
+
+	for _, key := range []string{"", "..", "../fileId", `dir\fileId`} {
+		if err = store.Save(key, []byte("content")); err == nil {
+			t.Fatalf("The key %q should be refused", key)
+		}
+	}
+
+	if err = store.Delete("fileId"); err != nil {
+		t.Fatalf("The file should be removed : %v", err)
+	}
+
+	if _, err = store.Load("fileId"); err == nil {
+		t.Fatal("The removed file can't be read")
+	}
+
+	if err = store.Delete("fileId"); err != nil {
+		t.Fatalf("Removing a missing file isn't an error : %v", err)
+	}
+}
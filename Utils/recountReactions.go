@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// CounterDiscrepancy describes a Post or Comment whose stored counters didn't match its Reaction rows.
+type CounterDiscrepancy struct {
+	Table string
+	Id    string
+
+	LikeCount       int
+	ExpectedLike    int
+	DislikeCount    int
+	ExpectedDislike int
+}
+
+/*
+This function takes 1 argument:
+  - a pointer to an sql.DB object, representing the database connection.
+
+The purpose of this function is to recompute the LikeCount and DislikeCount columns of every post and comment from the Reaction table.
+Only the rows with a wrong counter are updated, and everything is done in a single transaction.
+
+The function returns 2 values:
+  - the list of the posts and comments whose counters were wrong, with the stored and the expected values
+  - an error if the recount fails (nothing is updated in this case)
+*/
+func RecountReactions(db *sql.DB) ([]CounterDiscrepancy, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var discrepancies []CounterDiscrepancy
+	for _, table := range []string{"Post", "Comment"} {
+		// We let the db compare the stored counters with the source rows
+		rows, err := tx.Query(fmt.Sprintf(`
+			SELECT Id, LikeCount, ExpectedLike, DislikeCount, ExpectedDislike FROM (
+				SELECT
+					t.Id,
+					IFNULL(t.LikeCount, 0) AS LikeCount,
+					(SELECT COUNT(*) FROM Reaction AS r WHERE r.TargetType = '%[1]s' AND r.TargetId = t.Id AND r.Kind = 'like') AS ExpectedLike,
+					IFNULL(t.DislikeCount, 0) AS DislikeCount,
+					(SELECT COUNT(*) FROM Reaction AS r WHERE r.TargetType = '%[1]s' AND r.TargetId = t.Id AND r.Kind = 'dislike') AS ExpectedDislike
+				FROM %[1]s AS t
+			)
+			WHERE LikeCount <> ExpectedLike OR DislikeCount <> ExpectedDislike`, table))
+		if err != nil {
+			return nil, err
+		}
+
+		var tableDiscrepancies []CounterDiscrepancy
+		for rows.Next() {
+			discrepancy := CounterDiscrepancy{Table: table}
+			if err = rows.Scan(&discrepancy.Id, &discrepancy.LikeCount, &discrepancy.ExpectedLike, &discrepancy.DislikeCount, &discrepancy.ExpectedDislike); err != nil {
+				rows.Close()
+				return nil, err
+			}
+
+			tableDiscrepancies = append(tableDiscrepancies, discrepancy)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+
+		// We fix the counters once the rows are closed
+		for _, discrepancy := range tableDiscrepancies {
+			_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET LikeCount = ?, DislikeCount = ? WHERE Id = ?", table), discrepancy.ExpectedLike, discrepancy.ExpectedDislike, discrepancy.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		discrepancies = append(discrepancies, tableDiscrepancies...)
+	}
+
+	return discrepancies, tx.Commit()
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	model "social-network/Model"
+	"testing"
+)
+
+func TestRecountReactions(t *testing.T) {
+	db, err := model.OpenDb("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Erreur lors de la création de la base de données en mémoire : %v", err)
+		return
+	}
+	defer db.Close()
+
+	// The counters of the post are wrong on purpose
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS Post (
+			Id VARCHAR(36) NOT NULL PRIMARY KEY,
+			LikeCount INTEGER,
+			DislikeCount INTEGER
+		);
+
+		CREATE TABLE IF NOT EXISTS Comment (
+			Id VARCHAR(36) NOT NULL PRIMARY KEY,
+			LikeCount INTEGER,
+			DislikeCount INTEGER
+		);
+
+		CREATE TABLE IF NOT EXISTS Reaction (
+			TargetType VARCHAR(20) NOT NULL,
+			TargetId VARCHAR(36) NOT NULL,
+			UserId VARCHAR(36) NOT NULL,
+			Kind VARCHAR(20) NOT NULL
+		);
+
+		INSERT INTO Post VALUES ('wrong', 5, NULL), ('right', 1, 0);
+		INSERT INTO Comment VALUES ('comment', 0, 0);
+		INSERT INTO Reaction VALUES
+			('Post', 'wrong', 'a', 'like'),
+			('Post', 'wrong', 'b', 'dislike'),
+			('Post', 'wrong', 'c', 'love'),
+			('Post', 'right', 'a', 'like');
+	`)
+	if err != nil {
+		t.Fatalf("Erreur lors de la création des tables : %v", err)
+		return
+	}
+
+	discrepancies, err := RecountReactions(db)
+	if err != nil {
+		t.Fatalf("Error during the recount : %v", err)
+		return
+	}
+
+	if len(discrepancies) != 1 || discrepancies[0].Id != "wrong" || discrepancies[0].LikeCount != 5 || discrepancies[0].ExpectedLike != 1 || discrepancies[0].ExpectedDislike != 1 {
+		t.Fatalf("The discrepancies are not the good ones : %v", discrepancies)
+		return
+	}
+
+	var likeCount, dislikeCount int
+	if err = db.QueryRow("SELECT LikeCount, DislikeCount FROM Post WHERE Id = 'wrong'").Scan(&likeCount, &dislikeCount); err != nil {
+		t.Fatalf("Error during the fetch of the post : %v", err)
+		return
+	}
+
+	if likeCount != 1 || dislikeCount != 1 {
+		t.Fatalf("The counters have not been fixed : %d likes and %d dislikes", likeCount, dislikeCount)
+		return
+	}
+
+	// A second recount has nothing left to fix
+	if discrepancies, err = RecountReactions(db); err != nil || len(discrepancies) != 0 {
+		t.Fatalf("The second recount should find nothing : %v %v", discrepancies, err)
+	}
+}
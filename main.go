@@ -13,6 +13,7 @@ import (
 	middleware "social-network/Middleware"
 	model "social-network/Model"
 	routes "social-network/Routes"
+	utils "social-network/Utils"
 
 	"github.com/gorilla/websocket"
 	_ "github.com/mattn/go-sqlite3"
@@ -47,6 +48,26 @@ func init() {
 		end := time.Now()
 		fmt.Println(end.Sub(start))
 	}
+
+	if strings.ToLower(args[1]) == "--recount" || strings.ToLower(args[1]) == "-r" {
+		db, err := model.OpenDb("sqlite3", "./Database/Database.sqlite")
+		if err != nil {
+			fmt.Println(err)
+		}
+		defer db.Close()
+
+		// We recompute the reaction counters and report the ones who were wrong
+		discrepancies, err := utils.RecountReactions(db)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		for _, d := range discrepancies {
+			fmt.Printf("%s %s : likes %d -> %d, dislikes %d -> %d\n", d.Table, d.Id, d.LikeCount, d.ExpectedLike, d.DislikeCount, d.ExpectedDislike)
+		}
+		fmt.Printf("%d counter(s) fixed\n", len(discrepancies))
+	}
 }
 
 func main() {